@@ -52,7 +52,7 @@ func main() {
 }
 
 func InitializeAKOApi() {
-	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{})
+	akoApi := api.NewServer(lib.GetAkoApiServerPort(), []models.ApiModel{&lib.CertificatesModel{}})
 	akoApi.InitApi()
 	lib.SetApiServerInstance(akoApi)
 }
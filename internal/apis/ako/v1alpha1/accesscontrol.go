@@ -0,0 +1,26 @@
+/*
+ * Copyright 2019-2020 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package v1alpha1
+
+// AccessControl allows or denies the clients of a host in HostRule, or of a path in HTTPRule,
+// by their IP address. The clients in DenyCIDRs or DenyIPGroups are denied, and when any of
+// AllowCIDRs or AllowIPGroups is set, the clients not in them are denied as well.
+type AccessControl struct {
+	AllowCIDRs []string `json:"allowCIDRs,omitempty"`
+	DenyCIDRs  []string `json:"denyCIDRs,omitempty"`
+	// AllowIPGroups and DenyIPGroups refer to IP address groups on the Avi controller
+	AllowIPGroups []string `json:"allowIPGroups,omitempty"`
+	DenyIPGroups  []string `json:"denyIPGroups,omitempty"`
+}
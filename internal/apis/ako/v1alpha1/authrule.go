@@ -0,0 +1,80 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthRule is a top-level type, it attaches authentication to a host, or to some of its paths
+type AuthRule struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Status AuthRuleStatus `json:"status,omitempty"`
+
+	Spec AuthRuleSpec `json:"spec,omitempty"`
+}
+
+// AuthRuleSpec consists of the host to authenticate, and exactly one of JWT or AuthProfile
+type AuthRuleSpec struct {
+	Fqdn string `json:"fqdn,omitempty"`
+	// Paths restricts the authentication to the requests with these path prefixes,
+	// all the requests of the host are authenticated when empty
+	Paths       []string            `json:"paths,omitempty"`
+	JWT         AuthRuleJWT         `json:"jwt,omitempty"`
+	AuthProfile AuthRuleAuthProfile `json:"authProfile,omitempty"`
+}
+
+// AuthRuleJWT validates the JSON Web Tokens sent by the clients of the host
+type AuthRuleJWT struct {
+	Issuer   string `json:"issuer,omitempty"`
+	Audience string `json:"audience,omitempty"`
+	// JWKS refers to a Secret in the AuthRule namespace, which holds the JSON Web Key Set under the jwks key
+	JWKS string `json:"jwks,omitempty"`
+	// TokenLocation is either header, for the Authorization header, or query, in which case
+	// the token is read from the query parameter TokenName
+	TokenLocation string `json:"tokenLocation,omitempty"`
+	TokenName     string `json:"tokenName,omitempty"`
+}
+
+// AuthRuleAuthProfile refers to a SAML auth profile on the Avi controller, which redirects
+// the clients of the host to the identity provider for single sign-on
+type AuthRuleAuthProfile struct {
+	Name string `json:"name,omitempty"`
+	// EntityID and SingleSignonURL are configured for the host on the identity provider
+	EntityID        string `json:"entityID,omitempty"`
+	SingleSignonURL string `json:"singleSignonURL,omitempty"`
+}
+
+// AuthRuleStatus holds the status of the AuthRule
+type AuthRuleStatus struct {
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthRuleList has the list of AuthRule objects
+type AuthRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AuthRule `json:"items"`
+}
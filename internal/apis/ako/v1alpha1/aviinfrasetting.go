@@ -42,6 +42,7 @@ type AviInfraSettingSpec struct {
 type AviInfraSettingNetwork struct {
 	Name      string `json:"name,omitempty"`
 	EnableRhi *bool  `json:"enableRhi,omitempty"`
+	IPFamily  string `json:"ipFamily,omitempty"`
 }
 
 type AviInfraSettingSeGroup struct {
@@ -50,6 +51,9 @@ type AviInfraSettingSeGroup struct {
 
 type AviInfraL7Settings struct {
 	ShardSize string `json:"shardSize,omitempty"`
+	// DefaultCertificate is the namespace/name of the secret used for the TLS hosts
+	// of the Ingresses of the IngressClass which do not have a usable secret.
+	DefaultCertificate string `json:"defaultCertificate,omitempty"`
 }
 
 // AviInfraSettingStatus holds the status of the AviInfraSetting
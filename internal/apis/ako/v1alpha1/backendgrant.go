@@ -0,0 +1,60 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendGrant is a top-level type, created in the namespace of the backend Services,
+// it allows Ingresses from other namespaces to refer to these Services
+type BackendGrant struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackendGrantSpec `json:"spec,omitempty"`
+}
+
+// BackendGrantSpec consists of the objects which are granted access, and the Services they are granted access to
+type BackendGrantSpec struct {
+	From []BackendGrantFrom `json:"from,omitempty"`
+	To   []BackendGrantTo   `json:"to,omitempty"`
+}
+
+// BackendGrantFrom describes the objects which are allowed to refer to the Services,
+// kind is either Ingress or Gateway
+type BackendGrantFrom struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BackendGrantTo describes the Services which can be referred to, an empty name refers to all the Services
+type BackendGrantTo struct {
+	Name string `json:"name,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendGrantList has the list of BackendGrant objects
+type BackendGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BackendGrant `json:"items"`
+}
@@ -44,16 +44,68 @@ type HostRuleVirtualHost struct {
 	EnableVirtualHost  *bool              `json:"enableVirtualHost,omitempty"`
 	ErrorPageProfile   string             `json:"errorPageProfile,omitempty"`
 	Fqdn               string             `json:"fqdn,omitempty"`
+	FqdnType           string             `json:"fqdnType,omitempty"`
 	HTTPPolicy         HostRuleHTTPPolicy `json:"httpPolicy,omitempty"`
 	TLS                HostRuleTLS        `json:"tls,omitempty"`
 	WAFPolicy          string             `json:"wafPolicy,omitempty"`
+	RateLimit          RateLimit          `json:"rateLimit,omitempty"`
+	// MaxConnectionsPerServer applies to the pools of the host, unless set for the path in HTTPRule
+	MaxConnectionsPerServer int32         `json:"maxConnectionsPerServer,omitempty"`
+	AccessControl           AccessControl `json:"accessControl,omitempty"`
 }
 
 // HostRuleTLS holds secure host specific properties
 type HostRuleTLS struct {
-	SSLKeyCertificate HostRuleSecret `json:"sslKeyCertificate,omitempty"`
-	SSLProfile        string         `json:"sslProfile,omitempty"`
-	Termination       string         `json:"termination,omitempty"`
+	ClientCertificate HostRuleClientCertificate `json:"clientCertificate,omitempty"`
+	SSLKeyCertificate HostRuleSSLKeyCertificate `json:"sslKeyCertificate,omitempty"`
+	SSLProfile        string                    `json:"sslProfile,omitempty"`
+	Termination       string                    `json:"termination,omitempty"`
+	Redirect          HostRuleTLSRedirect       `json:"redirect,omitempty"`
+	HSTS              HostRuleHSTS              `json:"hsts,omitempty"`
+	// MinVersion and MaxVersion bound the TLS versions accepted for the host, among 1.0, 1.1, 1.2 and 1.3
+	MinVersion string `json:"minVersion,omitempty"`
+	MaxVersion string `json:"maxVersion,omitempty"`
+	// Ciphers is the OpenSSL cipher list for TLS 1.2 and below, Ciphersuites the TLS 1.3 cipher suites
+	Ciphers      string `json:"ciphers,omitempty"`
+	Ciphersuites string `json:"ciphersuites,omitempty"`
+}
+
+// HostRuleTLSRedirect controls the redirect of the insecure requests of the host to HTTPS,
+// which is enabled by default for secure hosts
+type HostRuleTLSRedirect struct {
+	Enabled    *bool `json:"enabled,omitempty"`
+	StatusCode int32 `json:"statusCode,omitempty"`
+}
+
+// HostRuleHSTS adds the Strict-Transport-Security header to the responses of the host,
+// MaxAge is in seconds and defaults to a year
+type HostRuleHSTS struct {
+	Enabled           bool  `json:"enabled,omitempty"`
+	MaxAge            int64 `json:"maxAge,omitempty"`
+	IncludeSubDomains bool  `json:"includeSubDomains,omitempty"`
+	Preload           bool  `json:"preload,omitempty"`
+}
+
+// HostRuleClientCertificate holds the settings used to validate the certificates
+// presented by clients of the host
+type HostRuleClientCertificate struct {
+	CABundle HostRuleCABundle           `json:"caBundle,omitempty"`
+	Mode     string                     `json:"mode,omitempty"`
+	Headers  []HostRuleClientCertHeader `json:"headers,omitempty"`
+}
+
+// HostRuleCABundle refers to a Secret or ConfigMap in the HostRule namespace,
+// which holds the CA bundle under the ca.crt key. ConfigMaps are supported only in the AKO namespace.
+type HostRuleCABundle struct {
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// HostRuleClientCertHeader passes a property of the verified client certificate
+// to the backends as a request header
+type HostRuleClientCertHeader struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
 }
 
 // HostRuleSecret is required to provide distinction between Avi SSLKeyCertificate
@@ -63,6 +115,14 @@ type HostRuleSecret struct {
 	Type string `json:"type,omitempty"`
 }
 
+// HostRuleSSLKeyCertificate refers to the Avi SSLKeyCertificate of the host, AlternateCertificate to
+// a second one whose key uses another algorithm, e.g. an ECDSA certificate next to an RSA one
+type HostRuleSSLKeyCertificate struct {
+	Name                 string         `json:"name,omitempty"`
+	Type                 string         `json:"type,omitempty"`
+	AlternateCertificate HostRuleSecret `json:"alternateCertificate,omitempty"`
+}
+
 // HostRuleHTTPPolicy holds knobs and refs for httpPolicySets
 type HostRuleHTTPPolicy struct {
 	PolicySets []string `json:"policySets,omitempty"`
@@ -39,10 +39,26 @@ type HTTPRuleSpec struct {
 
 // HTTPRulePaths has settings for a specific target path
 type HTTPRulePaths struct {
-	Target             string           `json:"target,omitempty"`
-	LoadBalancerPolicy HTTPRuleLBPolicy `json:"loadBalancerPolicy,omitempty"`
-	TLS                HTTPRuleTLS      `json:"tls,omitempty"`
-	HealthMonitors     []string         `json:"healthMonitors,omitempty"`
+	Target             string                  `json:"target,omitempty"`
+	LoadBalancerPolicy HTTPRuleLBPolicy        `json:"loadBalancerPolicy,omitempty"`
+	Persistence        HTTPRulePersistence     `json:"persistence,omitempty"`
+	TLS                HTTPRuleTLS             `json:"tls,omitempty"`
+	HealthMonitors     []string                `json:"healthMonitors,omitempty"`
+	HealthMonitorSpecs []HTTPRuleHealthMonitor `json:"healthMonitorSpecs,omitempty"`
+	RequestHeaders     HTTPRuleHeaders         `json:"requestHeaders,omitempty"`
+	ResponseHeaders    HTTPRuleHeaders         `json:"responseHeaders,omitempty"`
+	Rewrite            HTTPRuleRewrite         `json:"rewrite,omitempty"`
+	Redirect           HTTPRuleRedirect        `json:"redirect,omitempty"`
+	Weight             *int32                  `json:"weight,omitempty"`
+	Backends           []HTTPRuleBackend       `json:"backends,omitempty"`
+	Canary             HTTPRuleCanary          `json:"canary,omitempty"`
+	RateLimit          RateLimit               `json:"rateLimit,omitempty"`
+	// MaxConnectionsPerServer is the maximum number of concurrent connections to each server of the path pools
+	MaxConnectionsPerServer int32              `json:"maxConnectionsPerServer,omitempty"`
+	Connection              HTTPRuleConnection `json:"connection,omitempty"`
+	AccessControl           AccessControl      `json:"accessControl,omitempty"`
+	// BackendProtocol is one of http1, h2c or grpc, it overrides the appProtocol of the service port
+	BackendProtocol string `json:"backendProtocol,omitempty"`
 }
 
 // HTTPRuleLBPolicy holds a path/pool's load balancer policies
@@ -52,17 +68,116 @@ type HTTPRuleLBPolicy struct {
 	HostHeader string `json:"hostHeader,omitempty"`
 }
 
+// HTTPRulePersistence pins the clients of a path/pool to a server, either with a persistence
+// profile created by AKO from Type, or with the persistence profile Profile on the Avi controller
+type HTTPRulePersistence struct {
+	// Type is one of cookie, header or clientIP
+	Type       string `json:"type,omitempty"`
+	CookieName string `json:"cookieName,omitempty"`
+	HeaderName string `json:"headerName,omitempty"`
+	// Timeout is in minutes, for the cookie and clientIP types
+	Timeout int32  `json:"timeout,omitempty"`
+	Profile string `json:"profile,omitempty"`
+}
+
+// HTTPRuleConnection holds the timeouts, connection reuse and retry settings of the connections
+// from a path/pool to its servers, the timeouts are in seconds
+type HTTPRuleConnection struct {
+	// RequestTimeout bounds establishing the server connection and completing the request on it
+	RequestTimeout int32 `json:"requestTimeout,omitempty"`
+	// IdleTimeout closes the server connections which are idle in the connection pool for longer
+	IdleTimeout int32 `json:"idleTimeout,omitempty"`
+	// MaxReuse is the number of requests a server connection is reused for, DisableReuse
+	// uses a new server connection for every request
+	MaxReuse     int32 `json:"maxReuse,omitempty"`
+	DisableReuse bool  `json:"disableReuse,omitempty"`
+	// Retries is the number of times a request is retried on another server, when
+	// the server responds with a 5xx status, RetryTimeout bounds each of the attempts
+	Retries      int32 `json:"retries,omitempty"`
+	RetryTimeout int32 `json:"retryTimeout,omitempty"`
+}
+
 // HTTPRuleTLS holds secure path/pool specific properties
 type HTTPRuleTLS struct {
 	Type          string `json:"type,omitempty"`
 	SSLProfile    string `json:"sslProfile,omitempty"`
 	DestinationCA string `json:"destinationCA,omitempty"`
+	// ClientCertificate is the name of a kubernetes.io/tls Secret in the HTTPRule namespace,
+	// the key/cert in it is presented to the backends which require client certificates
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+}
+
+// HTTPRuleHeaders holds the headers to be added, replaced or removed
+// in the requests or responses of a path
+type HTTPRuleHeaders struct {
+	Add     []HTTPRuleHeader `json:"add,omitempty"`
+	Replace []HTTPRuleHeader `json:"replace,omitempty"`
+	Remove  []string         `json:"remove,omitempty"`
+}
+
+// HTTPRuleHeader is a header name and value pair
+type HTTPRuleHeader struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPRuleRewrite holds the URL rewrite settings of a path, the target
+// path prefix is either replaced with PathPrefix or stripped
+type HTTPRuleRewrite struct {
+	PathPrefix      string `json:"pathPrefix,omitempty"`
+	StripPathPrefix bool   `json:"stripPathPrefix,omitempty"`
+	Host            string `json:"host,omitempty"`
+}
+
+// HTTPRuleRedirect holds the redirect settings of a path, requests
+// to the path are redirected instead of being sent to the pool
+type HTTPRuleRedirect struct {
+	Protocol   string `json:"protocol,omitempty"`
+	Host       string `json:"host,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	StatusCode int32  `json:"statusCode,omitempty"`
+}
+
+// HTTPRuleBackend is a service in the namespace of the ingress which shares
+// the traffic of a path with the ingress backend, in the ratio of the weights
+type HTTPRuleBackend struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	Port        int32  `json:"port,omitempty"`
+	Weight      *int32 `json:"weight,omitempty"`
+}
+
+// HTTPRuleCanary is a service in the namespace of the ingress which receives
+// the requests of a path that match the header or cookie
+type HTTPRuleCanary struct {
+	ServiceName string         `json:"serviceName,omitempty"`
+	Port        int32          `json:"port,omitempty"`
+	Header      HTTPRuleHeader `json:"header,omitempty"`
+	Cookie      HTTPRuleHeader `json:"cookie,omitempty"`
+}
+
+// HTTPRuleHealthMonitor is a health monitor definition for the pools of a path,
+// the health monitor object is created and managed by AKO.
+// Type is one of HTTP, HTTPS or TCP. gRPC checks are not supported as the Avi SDK in use
+// has no gRPC health monitor type, and no monitor is derived from the readinessProbe of the pods.
+type HTTPRuleHealthMonitor struct {
+	Name             string   `json:"name,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	Path             string   `json:"path,omitempty"`
+	ExpectedCodes    []string `json:"expectedCodes,omitempty"`
+	Port             int32    `json:"port,omitempty"`
+	Interval         int32    `json:"interval,omitempty"`
+	Timeout          int32    `json:"timeout,omitempty"`
+	SuccessfulChecks int32    `json:"successfulChecks,omitempty"`
+	FailedChecks     int32    `json:"failedChecks,omitempty"`
 }
 
 // HTTPRuleStatus holds the status of the HTTPRule
 type HTTPRuleStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error,omitempty"`
+	// Warning carries the limitations of an accepted httprule, such as the grpc paths being health checked over TCP only
+	Warning string `json:"warning,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
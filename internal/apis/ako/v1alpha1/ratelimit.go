@@ -0,0 +1,34 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package v1alpha1
+
+// RateLimit limits the rate of requests to a host in HostRule, or to a path in HTTPRule.
+// The requests in excess of RequestsPerSecond, after allowing a Burst, are handled as per the Action.
+type RateLimit struct {
+	RequestsPerSecond int32 `json:"requestsPerSecond,omitempty"`
+	Burst             int32 `json:"burst,omitempty"`
+	// Key is clientIP to limit the requests of each client IP, or header to limit
+	// the requests of each value of the Header. The default is clientIP.
+	Key    string          `json:"key,omitempty"`
+	Header string          `json:"header,omitempty"`
+	Action RateLimitAction `json:"action,omitempty"`
+}
+
+// RateLimitAction is drop to close the connection, reject to respond with status 429,
+// or redirect to redirect the request as per Redirect
+type RateLimitAction struct {
+	Type     string           `json:"type,omitempty"`
+	Redirect HTTPRuleRedirect `json:"redirect,omitempty"`
+}
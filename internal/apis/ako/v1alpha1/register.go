@@ -54,6 +54,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&HTTPRuleList{},
 		&AviInfraSetting{},
 		&AviInfraSettingList{},
+		&BackendGrant{},
+		&BackendGrantList{},
+		&AuthRule{},
+		&AuthRuleList{},
 	)
 
 	scheme.AddKnownTypes(
@@ -24,6 +24,138 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRule) DeepCopyInto(out *AuthRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRule.
+func (in *AuthRule) DeepCopy() *AuthRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRuleAuthProfile) DeepCopyInto(out *AuthRuleAuthProfile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRuleAuthProfile.
+func (in *AuthRuleAuthProfile) DeepCopy() *AuthRuleAuthProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRuleAuthProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRuleJWT) DeepCopyInto(out *AuthRuleJWT) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRuleJWT.
+func (in *AuthRuleJWT) DeepCopy() *AuthRuleJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRuleJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRuleList) DeepCopyInto(out *AuthRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AuthRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRuleList.
+func (in *AuthRuleList) DeepCopy() *AuthRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRuleSpec) DeepCopyInto(out *AuthRuleSpec) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.JWT = in.JWT
+	out.AuthProfile = in.AuthProfile
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRuleSpec.
+func (in *AuthRuleSpec) DeepCopy() *AuthRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRuleStatus) DeepCopyInto(out *AuthRuleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRuleStatus.
+func (in *AuthRuleStatus) DeepCopy() *AuthRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AviInfraL7Settings) DeepCopyInto(out *AviInfraL7Settings) {
 	*out = *in
@@ -173,6 +305,160 @@ func (in *AviInfraSettingStatus) DeepCopy() *AviInfraSettingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendGrant) DeepCopyInto(out *BackendGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendGrant.
+func (in *BackendGrant) DeepCopy() *BackendGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendGrantFrom) DeepCopyInto(out *BackendGrantFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendGrantFrom.
+func (in *BackendGrantFrom) DeepCopy() *BackendGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendGrantList) DeepCopyInto(out *BackendGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackendGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendGrantList.
+func (in *BackendGrantList) DeepCopy() *BackendGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendGrantSpec) DeepCopyInto(out *BackendGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]BackendGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]BackendGrantTo, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendGrantSpec.
+func (in *BackendGrantSpec) DeepCopy() *BackendGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendGrantTo) DeepCopyInto(out *BackendGrantTo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendGrantTo.
+func (in *BackendGrantTo) DeepCopy() *BackendGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControl) DeepCopyInto(out *AccessControl) {
+	*out = *in
+	if in.AllowCIDRs != nil {
+		in, out := &in.AllowCIDRs, &out.AllowCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyCIDRs != nil {
+		in, out := &in.DenyCIDRs, &out.DenyCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowIPGroups != nil {
+		in, out := &in.AllowIPGroups, &out.AllowIPGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyIPGroups != nil {
+		in, out := &in.DenyIPGroups, &out.DenyIPGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControl.
+func (in *AccessControl) DeepCopy() *AccessControl {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControl)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRule) DeepCopyInto(out *HTTPRule) {
 	*out = *in
@@ -201,6 +487,129 @@ func (in *HTTPRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleBackend) DeepCopyInto(out *HTTPRuleBackend) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleBackend.
+func (in *HTTPRuleBackend) DeepCopy() *HTTPRuleBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleCanary) DeepCopyInto(out *HTTPRuleCanary) {
+	*out = *in
+	out.Header = in.Header
+	out.Cookie = in.Cookie
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleCanary.
+func (in *HTTPRuleCanary) DeepCopy() *HTTPRuleCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleConnection) DeepCopyInto(out *HTTPRuleConnection) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleConnection.
+func (in *HTTPRuleConnection) DeepCopy() *HTTPRuleConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeader) DeepCopyInto(out *HTTPRuleHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeader.
+func (in *HTTPRuleHeader) DeepCopy() *HTTPRuleHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHealthMonitor) DeepCopyInto(out *HTTPRuleHealthMonitor) {
+	*out = *in
+	if in.ExpectedCodes != nil {
+		in, out := &in.ExpectedCodes, &out.ExpectedCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHealthMonitor.
+func (in *HTTPRuleHealthMonitor) DeepCopy() *HTTPRuleHealthMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHealthMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleHeaders) DeepCopyInto(out *HTTPRuleHeaders) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]HTTPRuleHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replace != nil {
+		in, out := &in.Replace, &out.Replace
+		*out = make([]HTTPRuleHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleHeaders.
+func (in *HTTPRuleHeaders) DeepCopy() *HTTPRuleHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleLBPolicy) DeepCopyInto(out *HTTPRuleLBPolicy) {
 	*out = *in
@@ -254,12 +663,40 @@ func (in *HTTPRuleList) DeepCopyObject() runtime.Object {
 func (in *HTTPRulePaths) DeepCopyInto(out *HTTPRulePaths) {
 	*out = *in
 	out.LoadBalancerPolicy = in.LoadBalancerPolicy
+	out.Persistence = in.Persistence
 	out.TLS = in.TLS
 	if in.HealthMonitors != nil {
 		in, out := &in.HealthMonitors, &out.HealthMonitors
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HealthMonitorSpecs != nil {
+		in, out := &in.HealthMonitorSpecs, &out.HealthMonitorSpecs
+		*out = make([]HTTPRuleHealthMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.RequestHeaders.DeepCopyInto(&out.RequestHeaders)
+	in.ResponseHeaders.DeepCopyInto(&out.ResponseHeaders)
+	out.Rewrite = in.Rewrite
+	out.Redirect = in.Redirect
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]HTTPRuleBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Canary = in.Canary
+	out.RateLimit = in.RateLimit
+	out.Connection = in.Connection
+	in.AccessControl.DeepCopyInto(&out.AccessControl)
 	return
 }
 
@@ -273,6 +710,54 @@ func (in *HTTPRulePaths) DeepCopy() *HTTPRulePaths {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRulePersistence) DeepCopyInto(out *HTTPRulePersistence) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRulePersistence.
+func (in *HTTPRulePersistence) DeepCopy() *HTTPRulePersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRulePersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRedirect) DeepCopyInto(out *HTTPRuleRedirect) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRedirect.
+func (in *HTTPRuleRedirect) DeepCopy() *HTTPRuleRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRewrite) DeepCopyInto(out *HTTPRuleRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRewrite.
+func (in *HTTPRuleRewrite) DeepCopy() *HTTPRuleRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleSpec) DeepCopyInto(out *HTTPRuleSpec) {
 	*out = *in
@@ -356,6 +841,60 @@ func (in *HostRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleCABundle) DeepCopyInto(out *HostRuleCABundle) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleCABundle.
+func (in *HostRuleCABundle) DeepCopy() *HostRuleCABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleCABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleClientCertHeader) DeepCopyInto(out *HostRuleClientCertHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleClientCertHeader.
+func (in *HostRuleClientCertHeader) DeepCopy() *HostRuleClientCertHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleClientCertHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleClientCertificate) DeepCopyInto(out *HostRuleClientCertificate) {
+	*out = *in
+	out.CABundle = in.CABundle
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HostRuleClientCertHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleClientCertificate.
+func (in *HostRuleClientCertificate) DeepCopy() *HostRuleClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleHTTPPolicy) DeepCopyInto(out *HostRuleHTTPPolicy) {
 	*out = *in
@@ -426,6 +965,23 @@ func (in *HostRuleSecret) DeepCopy() *HostRuleSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleSSLKeyCertificate) DeepCopyInto(out *HostRuleSSLKeyCertificate) {
+	*out = *in
+	out.AlternateCertificate = in.AlternateCertificate
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleSSLKeyCertificate.
+func (in *HostRuleSSLKeyCertificate) DeepCopy() *HostRuleSSLKeyCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleSSLKeyCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleSpec) DeepCopyInto(out *HostRuleSpec) {
 	*out = *in
@@ -459,10 +1015,29 @@ func (in *HostRuleStatus) DeepCopy() *HostRuleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleHSTS) DeepCopyInto(out *HostRuleHSTS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleHSTS.
+func (in *HostRuleHSTS) DeepCopy() *HostRuleHSTS {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleHSTS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleTLS) DeepCopyInto(out *HostRuleTLS) {
 	*out = *in
+	in.ClientCertificate.DeepCopyInto(&out.ClientCertificate)
 	out.SSLKeyCertificate = in.SSLKeyCertificate
+	in.Redirect.DeepCopyInto(&out.Redirect)
+	out.HSTS = in.HSTS
 	return
 }
 
@@ -476,6 +1051,27 @@ func (in *HostRuleTLS) DeepCopy() *HostRuleTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRuleTLSRedirect) DeepCopyInto(out *HostRuleTLSRedirect) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRuleTLSRedirect.
+func (in *HostRuleTLSRedirect) DeepCopy() *HostRuleTLSRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRuleTLSRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRuleVirtualHost) DeepCopyInto(out *HostRuleVirtualHost) {
 	*out = *in
@@ -490,7 +1086,9 @@ func (in *HostRuleVirtualHost) DeepCopyInto(out *HostRuleVirtualHost) {
 		**out = **in
 	}
 	in.HTTPPolicy.DeepCopyInto(&out.HTTPPolicy)
-	out.TLS = in.TLS
+	in.TLS.DeepCopyInto(&out.TLS)
+	out.RateLimit = in.RateLimit
+	in.AccessControl.DeepCopyInto(&out.AccessControl)
 	return
 }
 
@@ -503,3 +1101,37 @@ func (in *HostRuleVirtualHost) DeepCopy() *HostRuleVirtualHost {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	out.Action = in.Action
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitAction) DeepCopyInto(out *RateLimitAction) {
+	*out = *in
+	out.Redirect = in.Redirect
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitAction.
+func (in *RateLimitAction) DeepCopy() *RateLimitAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitAction)
+	in.DeepCopyInto(out)
+	return out
+}
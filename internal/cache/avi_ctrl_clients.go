@@ -45,8 +45,13 @@ func SharedAVIClients() *utils.AviRestClientPool {
 		if shardSize != 0 {
 			if AviClientInstance == nil || len(AviClientInstance.AviClient) == 0 {
 				// initializing shardSize+1 clients in pool, the +1 is used by CRD ref verification calls
+				numClients := shardSize + 1
+				if lib.IsPodReadinessRuntimeCheckEnabled() {
+					// one more client is used by the pool runtime checks for the pod readiness gate
+					numClients += 1
+				}
 				AviClientInstance, err = utils.NewAviRestClientPool(
-					shardSize+1,
+					numClients,
 					ctrlIpAddress,
 					ctrlUsername,
 					ctrlPassword,
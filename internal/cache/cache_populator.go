@@ -0,0 +1,229 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/avinetworks/sdk/go/clients"
+	"github.com/avinetworks/sdk/go/session"
+)
+
+const cachePopulateRetries = 3
+
+// cachePopulator fetches the collections which populate the cache from the controller. Each request
+// borrows one of the rest workers' clients for its duration, so that at most the configured number of
+// requests are in flight and no two of them share a client. The clients are not taken away from the
+// rest workers, which may use them at the same time.
+type cachePopulator struct {
+	clients chan *clients.AviClient
+	timeout time.Duration
+}
+
+func newCachePopulator(client *clients.AviClient) *cachePopulator {
+	aviClients := []*clients.AviClient{client}
+	if AviClientInstance != nil && len(AviClientInstance.AviClient) > 0 {
+		aviClients = AviClientInstance.AviClient
+	}
+	concurrency := lib.GetCachePopulateConcurrency()
+	if concurrency > len(aviClients) {
+		concurrency = len(aviClients)
+	}
+	p := &cachePopulator{
+		clients: make(chan *clients.AviClient, concurrency),
+		timeout: lib.GetCachePopulateTimeout(),
+	}
+	for _, aviClient := range aviClients[:concurrency] {
+		p.clients <- aviClient
+	}
+	utils.AviLog.Infof("Populating the cache with %d concurrent requests, timing out after %v", concurrency, p.timeout)
+	return p
+}
+
+func (c *AviObjCache) cachePopulator(client *clients.AviClient) *cachePopulator {
+	c.populatorOnce.Do(func() {
+		c.populator = newCachePopulator(client)
+	})
+	return c.populator
+}
+
+// populateConcurrently populates each stage of object types after the previous one, as the objects refer to
+// the ones of the previous stages, and the object types of a stage concurrently.
+func populateConcurrently(stages ...[]func()) {
+	for _, stage := range stages {
+		var wg sync.WaitGroup
+		for _, populate := range stage {
+			wg.Add(1)
+			go func(populate func()) {
+				defer wg.Done()
+				populate()
+			}(populate)
+		}
+		wg.Wait()
+	}
+}
+
+// getCollection returns the objects of all the pages of a collection, and their count on the controller.
+func (p *cachePopulator) getCollection(objType, uri string) ([]json.RawMessage, int, error) {
+	pages, count, err := p.getCollectionPages(objType, uri)
+	if err != nil {
+		return nil, 0, err
+	}
+	var elems []json.RawMessage
+	for _, page := range pages {
+		var pageElems []json.RawMessage
+		if err := json.Unmarshal(page, &pageElems); err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal %s data, err: %v", objType, err)
+			return nil, 0, err
+		}
+		elems = append(elems, pageElems...)
+	}
+	return elems, count, nil
+}
+
+// getNamePrefixCollection returns the objects of a type which has no created_by field, the ones created by AKO
+// are matched by the name prefix. As name.contains matches the prefix anywhere in the name, the objects whose
+// name does not start with the prefix are left out.
+func (p *cachePopulator) getNamePrefixCollection(objType string) ([]json.RawMessage, int, error) {
+	uri := "/api/" + objType + "/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&page_size=100"
+	elems, count, err := p.getCollection(objType, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for %s %v", uri, objType, err)
+		return nil, 0, err
+	}
+	var prefixElems []json.RawMessage
+	for _, elem := range elems {
+		obj := struct {
+			Name *string `json:"name"`
+		}{}
+		if err := json.Unmarshal(elem, &obj); err == nil && obj.Name != nil &&
+			!strings.HasPrefix(*obj.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		prefixElems = append(prefixElems, elem)
+	}
+	return prefixElems, count, nil
+}
+
+// getCollectionPages fetches the first page of a collection to learn its size, and then the other pages
+// concurrently. The results of the pages are returned in order, along with the count of the objects.
+func (p *cachePopulator) getCollectionPages(objType, uri string) ([]json.RawMessage, int, error) {
+	result, err := p.getCollectionRaw(uri)
+	if err != nil {
+		return nil, 0, err
+	}
+	var firstPage []json.RawMessage
+	if err := json.Unmarshal(result.Results, &firstPage); err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal %s data, err: %v", objType, err)
+		return nil, 0, err
+	}
+	pageCount := 1
+	if result.Next != "" && len(firstPage) > 0 {
+		// the size of the first page is the page size of the collection
+		pageCount = (result.Count + len(firstPage) - 1) / len(firstPage)
+	}
+	apimodels.RestStatus.UpdateCachePopulate(objType, result.Count, pageCount)
+
+	pages := make([]json.RawMessage, pageCount)
+	pages[0] = result.Results
+	errs := make([]error, pageCount)
+	var wg sync.WaitGroup
+	for page := 2; page <= pageCount; page++ {
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+			pageResult, err := p.getCollectionRaw(uri + "&page=" + strconv.Itoa(page))
+			if err != nil {
+				errs[page-1] = err
+				return
+			}
+			pages[page-1] = pageResult.Results
+			apimodels.RestStatus.UpdateCachePopulate(objType, result.Count, pageCount)
+		}(page)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return pages, result.Count, nil
+}
+
+// getCollectionRaw fetches a page of a collection, and retries it with a backoff if it times out
+// or fails with an error that may be transient.
+func (p *cachePopulator) getCollectionRaw(uri string) (session.AviCollectionResult, error) {
+	var err error
+	for retry := 0; retry < cachePopulateRetries; retry++ {
+		if retry > 0 {
+			time.Sleep(time.Duration(retry) * time.Second)
+		}
+		var result session.AviCollectionResult
+		result, err = p.get(uri)
+		if err == nil {
+			apimodels.RestStatus.UpdateAviApiRestStatus(utils.AVIAPI_CONNECTED, nil)
+			return result, nil
+		}
+		apimodels.RestStatus.UpdateAviApiRestStatus("", err)
+		if !isTransientError(err) {
+			break
+		}
+		utils.AviLog.Warnf("Get uri %v failed, attempt %d of %d, err: %v", uri, retry+1, cachePopulateRetries, err)
+	}
+	return session.AviCollectionResult{}, err
+}
+
+// get fetches a page with a client of the pool, which is only returned to the pool once the request
+// completes, so that a request which timed out still counts against the requests in flight.
+func (p *cachePopulator) get(uri string) (session.AviCollectionResult, error) {
+	type response struct {
+		result session.AviCollectionResult
+		err    error
+	}
+	client := <-p.clients
+	done := make(chan response, 1)
+	go func() {
+		result, err := client.AviSession.GetCollectionRaw(uri)
+		p.clients <- client
+		done <- response{result, err}
+	}()
+	select {
+	case resp := <-done:
+		return resp.result, resp.err
+	case <-time.After(p.timeout):
+		return session.AviCollectionResult{}, fmt.Errorf("get uri %s timed out after %v", uri, p.timeout)
+	}
+}
+
+// isTransientError returns false for the errors that fail the same on a retry, such as an object
+// which does not exist, a request which is not authorized or a response which is not valid.
+func isTransientError(err error) bool {
+	switch e := err.(type) {
+	case session.AviError:
+		return e.HttpStatusCode == 0 || e.HttpStatusCode == 429 || e.HttpStatusCode >= 500
+	case *json.SyntaxError, *json.UnmarshalTypeError:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/avinetworks/sdk/go/clients"
+	"github.com/avinetworks/sdk/go/session"
+)
+
+const cacheSnapshotVersion = 1
+
+// CacheSnapshot is the persisted copy of the objects of the cache which keep the last_modified of their
+// controller object up to date, so that it can be checked cheaply against the controller when AKO restarts.
+// The objects are keyed by namespace/name, with the checksums of the models last pushed to the controller.
+type CacheSnapshot struct {
+	Version         int
+	Cluster         string
+	Cloud           string
+	Created         time.Time
+	VirtualServices map[string]*AviVsCache
+	Pools           map[string]*AviPoolCache
+	PoolGroups      map[string]*AviPGCache
+	VSVIPs          map[string]*AviVSVIPCache
+	HTTPPolicies    map[string]*AviHTTPPolicyCache
+	L4Policies      map[string]*AviL4PolicyCache
+	ModelChecksums  map[string]uint32
+}
+
+func snapshotKey(k interface{}) (string, bool) {
+	key, ok := k.(NamespaceName)
+	if !ok {
+		return "", false
+	}
+	return key.Namespace + "/" + key.Name, true
+}
+
+func cacheKey(k string) NamespaceName {
+	if parts := strings.SplitN(k, "/", 2); len(parts) == 2 {
+		return NamespaceName{Namespace: parts[0], Name: parts[1]}
+	}
+	return NamespaceName{Namespace: lib.GetTenant(), Name: k}
+}
+
+// Snapshot copies the objects of the cache which are persisted in the cache snapshot.
+func (c *AviObjCache) Snapshot(cloud string) *CacheSnapshot {
+	snapshot := &CacheSnapshot{
+		Version:         cacheSnapshotVersion,
+		Cluster:         lib.GetClusterName(),
+		Cloud:           cloud,
+		Created:         time.Now(),
+		VirtualServices: make(map[string]*AviVsCache),
+		Pools:           make(map[string]*AviPoolCache),
+		PoolGroups:      make(map[string]*AviPGCache),
+		VSVIPs:          make(map[string]*AviVSVIPCache),
+		HTTPPolicies:    make(map[string]*AviHTTPPolicyCache),
+		L4Policies:      make(map[string]*AviL4PolicyCache),
+	}
+	for k, v := range c.VsCacheMeta.ShallowCopy() {
+		key, ok := snapshotKey(k)
+		vs, isVS := v.(*AviVsCache)
+		if !ok || !isVS || vs.Name == lib.DummyVSForStaleData {
+			continue
+		}
+		if vsCopy, done := vs.GetVSCopy(); done {
+			snapshot.VirtualServices[key] = vsCopy
+		}
+	}
+	for k, v := range c.PoolCache.ShallowCopy() {
+		if key, ok := snapshotKey(k); ok {
+			if pool, ok := v.(*AviPoolCache); ok {
+				poolCopy := *pool
+				snapshot.Pools[key] = &poolCopy
+			}
+		}
+	}
+	for k, v := range c.PgCache.ShallowCopy() {
+		if key, ok := snapshotKey(k); ok {
+			if pg, ok := v.(*AviPGCache); ok {
+				pgCopy := *pg
+				snapshot.PoolGroups[key] = &pgCopy
+			}
+		}
+	}
+	for k, v := range c.VSVIPCache.ShallowCopy() {
+		if key, ok := snapshotKey(k); ok {
+			if vsvip, ok := v.(*AviVSVIPCache); ok {
+				vsvipCopy := *vsvip
+				snapshot.VSVIPs[key] = &vsvipCopy
+			}
+		}
+	}
+	for k, v := range c.HTTPPolicyCache.ShallowCopy() {
+		if key, ok := snapshotKey(k); ok {
+			if httppol, ok := v.(*AviHTTPPolicyCache); ok {
+				httppolCopy := *httppol
+				snapshot.HTTPPolicies[key] = &httppolCopy
+			}
+		}
+	}
+	for k, v := range c.L4PolicyCache.ShallowCopy() {
+		if key, ok := snapshotKey(k); ok {
+			if l4pol, ok := v.(*AviL4PolicyCache); ok {
+				l4polCopy := *l4pol
+				snapshot.L4Policies[key] = &l4polCopy
+			}
+		}
+	}
+	return snapshot
+}
+
+// AviObjCachePopulateFromSnapshot populates the cache from a snapshot instead of fetching every object from
+// the controller. The snapshot is only used if the name and last_modified of each of its objects match the
+// ones on the controller, otherwise an error is returned and the cache has to be populated in full. The
+// objects which are not part of the snapshot are populated from the controller as usual.
+func (c *AviObjCache) AviObjCachePopulateFromSnapshot(client *clients.AviClient, version string, cloud string, snapshot *CacheSnapshot) (err error) {
+	SetTenant := session.SetTenant(lib.GetTenant())
+	SetTenant(client.AviSession)
+	SetVersion := session.SetVersion(version)
+	SetVersion(client.AviSession)
+	apimodels.RestStatus.StartCachePopulate()
+	defer func() {
+		apimodels.RestStatus.FinishCachePopulate(err)
+	}()
+	if err = c.ValidateCacheSnapshot(client, cloud, snapshot); err != nil {
+		return err
+	}
+	if err = c.AviObjVrfCachePopulate(client, cloud); err != nil {
+		return err
+	}
+	populateConcurrently([]func(){
+		func() { c.PopulatePkiProfilesToCache(client) },
+		func() { c.PopulateHealthMonitorsToCache(client) },
+		func() { c.PopulateAppProfilesToCache(client) },
+		func() { c.PopulateSSLProfilesToCache(client) },
+		func() { c.PopulateJWTProfilesToCache(client) },
+		func() { c.PopulateAuthProfilesToCache(client) },
+		func() { c.PopulateSSOPoliciesToCache(client) },
+		func() { c.PopulatePersistenceProfilesToCache(client) },
+		func() { c.PopulateSSLKeyToCache(client, cloud) },
+	})
+	for key, vs := range snapshot.VirtualServices {
+		c.VsCacheMeta.AviCacheAdd(cacheKey(key), vs)
+	}
+	for key, pool := range snapshot.Pools {
+		c.PoolCache.AviCacheAdd(cacheKey(key), pool)
+	}
+	for key, pg := range snapshot.PoolGroups {
+		c.PgCache.AviCacheAdd(cacheKey(key), pg)
+	}
+	for key, vsvip := range snapshot.VSVIPs {
+		c.VSVIPCache.AviCacheAdd(cacheKey(key), vsvip)
+	}
+	for key, httppol := range snapshot.HTTPPolicies {
+		c.HTTPPolicyCache.AviCacheAdd(cacheKey(key), httppol)
+	}
+	for key, l4pol := range snapshot.L4Policies {
+		c.L4PolicyCache.AviCacheAdd(cacheKey(key), l4pol)
+	}
+	// datascripts refer to the poolgroups of the snapshot
+	c.PopulateDSDataToCache(client, cloud)
+	utils.AviLog.Infof("Cache populated from the snapshot of %s with %d virtual services and %d pools",
+		snapshot.Created.UTC(), len(snapshot.VirtualServices), len(snapshot.Pools))
+	return c.AviCloudPropertiesPopulate(client, cloud)
+}
+
+// ValidateCacheSnapshot checks that a snapshot was taken for the same cluster and cloud, and that
+// the objects of the controller were neither created, modified nor deleted since it was taken.
+func (c *AviObjCache) ValidateCacheSnapshot(client *clients.AviClient, cloud string, snapshot *CacheSnapshot) error {
+	if snapshot.Version != cacheSnapshotVersion {
+		return fmt.Errorf("snapshot version %d is not supported", snapshot.Version)
+	}
+	if snapshot.Cluster != lib.GetClusterName() || snapshot.Cloud != cloud {
+		return fmt.Errorf("snapshot was taken for cluster %s and cloud %s", snapshot.Cluster, snapshot.Cloud)
+	}
+	akoUser := lib.AKOUser
+	collections := []struct {
+		objType      string
+		uri          string
+		lastModified map[string]string
+	}{
+		{"virtualservice", "/api/virtualservice/?include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100", make(map[string]string)},
+		{"pool", "/api/pool/?include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100", make(map[string]string)},
+		{"poolgroup", "/api/poolgroup/?include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100", make(map[string]string)},
+		{"vsvip", "/api/vsvip/?name.contains=" + lib.GetNamePrefix() + "&include_name=true&cloud_ref.name=" + cloud + "&page_size=100", make(map[string]string)},
+		{"httppolicyset", "/api/httppolicyset/?include_name=true&created_by=" + akoUser + "&page_size=100", make(map[string]string)},
+		{"l4policyset", "/api/l4policyset/?include_name=true&created_by=" + akoUser + "&page_size=100", make(map[string]string)},
+	}
+	for _, vs := range snapshot.VirtualServices {
+		collections[0].lastModified[vs.Name] = vs.LastModified
+	}
+	for _, pool := range snapshot.Pools {
+		collections[1].lastModified[pool.Name] = pool.LastModified
+	}
+	for _, pg := range snapshot.PoolGroups {
+		collections[2].lastModified[pg.Name] = pg.LastModified
+	}
+	for _, vsvip := range snapshot.VSVIPs {
+		collections[3].lastModified[vsvip.Name] = vsvip.LastModified
+	}
+	for _, httppol := range snapshot.HTTPPolicies {
+		collections[4].lastModified[httppol.Name] = httppol.LastModified
+	}
+	for _, l4pol := range snapshot.L4Policies {
+		collections[5].lastModified[l4pol.Name] = l4pol.LastModified
+	}
+
+	for _, collection := range collections {
+		lastModified, err := aviGetLastModified(client, collection.objType, collection.uri)
+		if err != nil {
+			return err
+		}
+		if len(lastModified) != len(collection.lastModified) {
+			return fmt.Errorf("%d %s objects on the controller, %d in the snapshot",
+				len(lastModified), collection.objType, len(collection.lastModified))
+		}
+		for name, modified := range lastModified {
+			if snapshotModified, found := collection.lastModified[name]; !found || snapshotModified != modified {
+				return fmt.Errorf("%s %s was modified since the snapshot", collection.objType, name)
+			}
+		}
+	}
+	return nil
+}
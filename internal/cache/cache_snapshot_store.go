@@ -0,0 +1,211 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// a ConfigMap is limited to 1MiB, the chunks leave room for the base64 encoding of the binary data
+	cacheSnapshotChunkSize   = 512 * 1024
+	cacheSnapshotKey         = "snapshot"
+	cacheSnapshotChunksKey   = "chunks"
+	cacheSnapshotChecksumKey = "sha256"
+)
+
+// SaveCacheSnapshot persists a compressed snapshot of the cache in the store configured for AKO.
+func SaveCacheSnapshot(snapshot *CacheSnapshot) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(snapshot); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	switch lib.GetCacheSnapshotStore() {
+	case lib.CacheSnapshotPVC:
+		return saveCacheSnapshotFile(buf.Bytes())
+	case lib.CacheSnapshotConfigMap:
+		return saveCacheSnapshotConfigMaps(buf.Bytes())
+	}
+	return nil
+}
+
+// LoadCacheSnapshot reads the snapshot of the cache from the store configured for AKO, nil if there is none.
+func LoadCacheSnapshot() (*CacheSnapshot, error) {
+	var data []byte
+	var err error
+	switch lib.GetCacheSnapshotStore() {
+	case lib.CacheSnapshotPVC:
+		data, err = ioutil.ReadFile(lib.GetCacheSnapshotPath())
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	case lib.CacheSnapshotConfigMap:
+		data, err = loadCacheSnapshotConfigMaps()
+	}
+	if err != nil || data == nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	snapshot := &CacheSnapshot{}
+	if err := json.NewDecoder(zr).Decode(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// DeleteCacheSnapshot removes the snapshot of the cache, once the objects of AKO are deleted from the controller.
+func DeleteCacheSnapshot() {
+	switch lib.GetCacheSnapshotStore() {
+	case lib.CacheSnapshotPVC:
+		if err := os.Remove(lib.GetCacheSnapshotPath()); err != nil && !os.IsNotExist(err) {
+			utils.AviLog.Warnf("Unable to delete the cache snapshot: %v", err)
+		}
+	case lib.CacheSnapshotConfigMap:
+		deleteCacheSnapshotConfigMaps(0)
+	}
+}
+
+// saveCacheSnapshotFile writes the snapshot next to the previous one and renames it,
+// so that a restart while it is written does not leave a truncated snapshot behind.
+func saveCacheSnapshotFile(data []byte) error {
+	path := lib.GetCacheSnapshotPath()
+	if err := ioutil.WriteFile(path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func cacheSnapshotChunkName(i int) string {
+	return lib.AviCacheSnapshot + "-" + strconv.Itoa(i)
+}
+
+// saveCacheSnapshotConfigMaps writes the snapshot in chunks, and then the ConfigMap listing them with their
+// checksum. A snapshot read while it is written does not match the checksum, and is not used.
+func saveCacheSnapshotConfigMaps(data []byte) error {
+	var chunks int
+	for offset := 0; offset < len(data); offset += cacheSnapshotChunkSize {
+		end := offset + cacheSnapshotChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := applyCacheSnapshotConfigMap(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: cacheSnapshotChunkName(chunks), Namespace: utils.GetAKONamespace()},
+			BinaryData: map[string][]byte{cacheSnapshotKey: data[offset:end]},
+		}); err != nil {
+			return err
+		}
+		chunks++
+	}
+	checksum := sha256.Sum256(data)
+	if err := applyCacheSnapshotConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: lib.AviCacheSnapshot, Namespace: utils.GetAKONamespace()},
+		Data: map[string]string{
+			cacheSnapshotChunksKey:   strconv.Itoa(chunks),
+			cacheSnapshotChecksumKey: hex.EncodeToString(checksum[:]),
+		},
+	}); err != nil {
+		return err
+	}
+	// the chunks left over by a larger snapshot
+	deleteCacheSnapshotChunks(chunks)
+	return nil
+}
+
+func applyCacheSnapshotConfigMap(cm *corev1.ConfigMap) error {
+	cmClient := utils.GetInformers().ClientSet.CoreV1().ConfigMaps(cm.Namespace)
+	existing, err := cmClient.Get(context.TODO(), cm.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = cmClient.Create(context.TODO(), cm, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+	cm.ResourceVersion = existing.ResourceVersion
+	_, err = cmClient.Update(context.TODO(), cm, metav1.UpdateOptions{})
+	return err
+}
+
+func loadCacheSnapshotConfigMaps() ([]byte, error) {
+	cmClient := utils.GetInformers().ClientSet.CoreV1().ConfigMaps(utils.GetAKONamespace())
+	cm, err := cmClient.Get(context.TODO(), lib.AviCacheSnapshot, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	chunks, err := strconv.Atoi(cm.Data[cacheSnapshotChunksKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid number of chunks in the cache snapshot: %v", err)
+	}
+	var data []byte
+	for i := 0; i < chunks; i++ {
+		chunk, err := cmClient.Get(context.TODO(), cacheSnapshotChunkName(i), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk.BinaryData[cacheSnapshotKey]...)
+	}
+	checksum := sha256.Sum256(data)
+	if hex.EncodeToString(checksum[:]) != cm.Data[cacheSnapshotChecksumKey] {
+		return nil, fmt.Errorf("checksum of the cache snapshot does not match")
+	}
+	return data, nil
+}
+
+func deleteCacheSnapshotConfigMaps(from int) {
+	cmClient := utils.GetInformers().ClientSet.CoreV1().ConfigMaps(utils.GetAKONamespace())
+	if err := cmClient.Delete(context.TODO(), lib.AviCacheSnapshot, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		utils.AviLog.Warnf("Unable to delete the cache snapshot: %v", err)
+	}
+	deleteCacheSnapshotChunks(from)
+}
+
+// deleteCacheSnapshotChunks deletes the chunks of the snapshot starting from the given one.
+func deleteCacheSnapshotChunks(from int) {
+	cmClient := utils.GetInformers().ClientSet.CoreV1().ConfigMaps(utils.GetAKONamespace())
+	for i := from; ; i++ {
+		err := cmClient.Delete(context.TODO(), cacheSnapshotChunkName(i), metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			return
+		} else if err != nil {
+			utils.AviLog.Warnf("Unable to delete chunk %d of the cache snapshot: %v", i, err)
+			return
+		}
+	}
+}
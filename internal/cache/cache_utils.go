@@ -15,6 +15,7 @@ package cache
 
 import (
 	"encoding/json"
+	"strconv"
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -36,9 +37,15 @@ type AviPoolCache struct {
 	CloudConfigCksum     string
 	ServiceMetadataObj   ServiceMetadataObj
 	PkiProfileCollection NamespaceName
-	LastModified         string
-	InvalidData          bool
-	HasReference         bool
+	// client key/cert presented by the pool to the backends
+	SSLKeyCertCollection NamespaceName
+	// health monitors of the pool created by AKO
+	HealthMonitorCollection []NamespaceName
+	// persistence profile of the pool created by AKO
+	PersistenceProfileCollection NamespaceName
+	LastModified                 string
+	InvalidData                  bool
+	HasReference                 bool
 }
 
 type ServiceMetadataObj struct {
@@ -87,6 +94,7 @@ type AviVsCache struct {
 	Tenant               string
 	Uuid                 string
 	Vip                  string
+	V6Vip                string
 	CloudConfigCksum     string
 	PGKeyCollection      []NamespaceName
 	VSVipKeyCollection   []NamespaceName
@@ -95,6 +103,9 @@ type AviVsCache struct {
 	HTTPKeyCollection    []NamespaceName
 	SSLKeyCertCollection []NamespaceName
 	L4PolicyCollection   []NamespaceName
+	AppProfileCollection []NamespaceName
+	SSLProfileCollection []NamespaceName
+	SSOPolicyCollection  []NamespaceName
 	SNIChildCollection   []string
 	ParentVSRef          NamespaceName
 	PassthroughParentRef NamespaceName
@@ -103,6 +114,10 @@ type AviVsCache struct {
 	LastModified         string
 	InvalidData          bool
 	VSCacheLock          sync.RWMutex
+	// refIndex is the reverse index of the AviCache the VS is stored in, kept in step by the
+	// AddTo*/RemoveFrom* collection helpers. refKey is the key of the VS in that cache.
+	refIndex *vsRefIndex
+	refKey   NamespaceName
 }
 
 func (c *AviCache) AviCacheAddVS(k NamespaceName) *AviVsCache {
@@ -114,9 +129,11 @@ func (c *AviCache) AviCacheAddVS(k NamespaceName) *AviVsCache {
 		if ok {
 			return aviVS
 		}
+		c.indexDelete(k)
 	}
 	vsObj := AviVsCache{Name: k.Name, Tenant: k.Namespace}
 	c.cache[k] = &vsObj
+	c.indexAdd(k, &vsObj)
 	return &vsObj
 }
 
@@ -129,16 +146,25 @@ func (c *AviCache) AviCacheAddPool(k NamespaceName) *AviPoolCache {
 		if ok {
 			return aviPool
 		}
+		c.indexDelete(k)
 	}
 	poolObj := AviPoolCache{Name: k.Name, Tenant: k.Namespace}
 	c.cache[k] = &poolObj
+	c.indexAdd(k, &poolObj)
 	return &poolObj
 }
 
 func (v *AviVsCache) SetPGKeyCollection(keyCollection []NamespaceName) {
 	v.VSCacheLock.Lock()
 	defer v.VSCacheLock.Unlock()
+	oldCollection := v.PGKeyCollection
 	v.PGKeyCollection = keyCollection
+	for _, k := range oldCollection {
+		v.unindexRef(VSRefPG, k, v.PGKeyCollection)
+	}
+	for _, k := range keyCollection {
+		v.indexRef(VSRefPG, k)
+	}
 }
 
 func Remove(s []NamespaceName, r NamespaceName) []NamespaceName {
@@ -166,6 +192,7 @@ func (v *AviVsCache) AddToPGKeyCollection(k NamespaceName) {
 	if !utils.HasElem(v.PGKeyCollection, k) {
 		v.PGKeyCollection = append(v.PGKeyCollection, k)
 	}
+	v.indexRef(VSRefPG, k)
 }
 
 func (v *AviVsCache) RemoveFromPGKeyCollection(k NamespaceName) {
@@ -173,6 +200,7 @@ func (v *AviVsCache) RemoveFromPGKeyCollection(k NamespaceName) {
 		return
 	}
 	v.PGKeyCollection = Remove(v.PGKeyCollection, k)
+	v.unindexRef(VSRefPG, k, v.PGKeyCollection)
 }
 
 func (v *AviVsCache) AddToVSVipKeyCollection(k NamespaceName) {
@@ -182,6 +210,7 @@ func (v *AviVsCache) AddToVSVipKeyCollection(k NamespaceName) {
 	if !utils.HasElem(v.VSVipKeyCollection, k) {
 		v.VSVipKeyCollection = append(v.VSVipKeyCollection, k)
 	}
+	v.indexRef(VSRefVSVip, k)
 }
 
 func (v *AviVsCache) RemoveFromVSVipKeyCollection(k NamespaceName) {
@@ -189,16 +218,19 @@ func (v *AviVsCache) RemoveFromVSVipKeyCollection(k NamespaceName) {
 		return
 	}
 	v.VSVipKeyCollection = Remove(v.VSVipKeyCollection, k)
+	v.unindexRef(VSRefVSVip, k, v.VSVipKeyCollection)
 }
 
 func (v *AviVsCache) AddToPoolKeyCollection(k NamespaceName) {
 	if v.PoolKeyCollection == nil {
 		v.PoolKeyCollection = []NamespaceName{k}
+		v.indexRef(VSRefPool, k)
 		return
 	}
 	if !utils.HasElem(v.PoolKeyCollection, k) {
 		v.PoolKeyCollection = append(v.PoolKeyCollection, k)
 	}
+	v.indexRef(VSRefPool, k)
 }
 
 func (v *AviVsCache) RemoveFromPoolKeyCollection(k NamespaceName) {
@@ -206,6 +238,7 @@ func (v *AviVsCache) RemoveFromPoolKeyCollection(k NamespaceName) {
 		return
 	}
 	v.PoolKeyCollection = Remove(v.PoolKeyCollection, k)
+	v.unindexRef(VSRefPool, k, v.PoolKeyCollection)
 }
 
 func (v *AviVsCache) AddToDSKeyCollection(k NamespaceName) {
@@ -215,6 +248,7 @@ func (v *AviVsCache) AddToDSKeyCollection(k NamespaceName) {
 	if !utils.HasElem(v.DSKeyCollection, k) {
 		v.DSKeyCollection = append(v.DSKeyCollection, k)
 	}
+	v.indexRef(VSRefDS, k)
 }
 
 func (v *AviVsCache) RemoveFromDSKeyCollection(k NamespaceName) {
@@ -222,6 +256,7 @@ func (v *AviVsCache) RemoveFromDSKeyCollection(k NamespaceName) {
 		return
 	}
 	v.DSKeyCollection = Remove(v.DSKeyCollection, k)
+	v.unindexRef(VSRefDS, k, v.DSKeyCollection)
 }
 
 func (v *AviVsCache) AddToHTTPKeyCollection(k NamespaceName) {
@@ -231,6 +266,7 @@ func (v *AviVsCache) AddToHTTPKeyCollection(k NamespaceName) {
 	if !utils.HasElem(v.HTTPKeyCollection, k) {
 		v.HTTPKeyCollection = append(v.HTTPKeyCollection, k)
 	}
+	v.indexRef(VSRefHTTP, k)
 }
 
 func (v *AviVsCache) RemoveFromHTTPKeyCollection(k NamespaceName) {
@@ -238,6 +274,7 @@ func (v *AviVsCache) RemoveFromHTTPKeyCollection(k NamespaceName) {
 		return
 	}
 	v.HTTPKeyCollection = Remove(v.HTTPKeyCollection, k)
+	v.unindexRef(VSRefHTTP, k, v.HTTPKeyCollection)
 }
 
 func (v *AviVsCache) AddToSSLKeyCertCollection(k NamespaceName) {
@@ -247,6 +284,7 @@ func (v *AviVsCache) AddToSSLKeyCertCollection(k NamespaceName) {
 	if !utils.HasElem(v.SSLKeyCertCollection, k) {
 		v.SSLKeyCertCollection = append(v.SSLKeyCertCollection, k)
 	}
+	v.indexRef(VSRefSSLKeyCert, k)
 }
 
 func (v *AviVsCache) RemoveFromSSLKeyCertCollection(k NamespaceName) {
@@ -254,6 +292,61 @@ func (v *AviVsCache) RemoveFromSSLKeyCertCollection(k NamespaceName) {
 		return
 	}
 	v.SSLKeyCertCollection = Remove(v.SSLKeyCertCollection, k)
+	v.unindexRef(VSRefSSLKeyCert, k, v.SSLKeyCertCollection)
+}
+
+func (v *AviVsCache) AddToAppProfileCollection(k NamespaceName) {
+	if v.AppProfileCollection == nil {
+		v.AppProfileCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.AppProfileCollection, k) {
+		v.AppProfileCollection = append(v.AppProfileCollection, k)
+	}
+	v.indexRef(VSRefAppProfile, k)
+}
+
+func (v *AviVsCache) RemoveFromAppProfileCollection(k NamespaceName) {
+	if v.AppProfileCollection == nil {
+		return
+	}
+	v.AppProfileCollection = Remove(v.AppProfileCollection, k)
+	v.unindexRef(VSRefAppProfile, k, v.AppProfileCollection)
+}
+
+func (v *AviVsCache) AddToSSLProfileCollection(k NamespaceName) {
+	if v.SSLProfileCollection == nil {
+		v.SSLProfileCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.SSLProfileCollection, k) {
+		v.SSLProfileCollection = append(v.SSLProfileCollection, k)
+	}
+	v.indexRef(VSRefSSLProfile, k)
+}
+
+func (v *AviVsCache) RemoveFromSSLProfileCollection(k NamespaceName) {
+	if v.SSLProfileCollection == nil {
+		return
+	}
+	v.SSLProfileCollection = Remove(v.SSLProfileCollection, k)
+	v.unindexRef(VSRefSSLProfile, k, v.SSLProfileCollection)
+}
+
+func (v *AviVsCache) AddToSSOPolicyCollection(k NamespaceName) {
+	if v.SSOPolicyCollection == nil {
+		v.SSOPolicyCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.SSOPolicyCollection, k) {
+		v.SSOPolicyCollection = append(v.SSOPolicyCollection, k)
+	}
+	v.indexRef(VSRefSSOPolicy, k)
+}
+
+func (v *AviVsCache) RemoveFromSSOPolicyCollection(k NamespaceName) {
+	if v.SSOPolicyCollection == nil {
+		return
+	}
+	v.SSOPolicyCollection = Remove(v.SSOPolicyCollection, k)
+	v.unindexRef(VSRefSSOPolicy, k, v.SSOPolicyCollection)
 }
 
 func (v *AviVsCache) AddToL4PolicyCollection(k NamespaceName) {
@@ -263,6 +356,7 @@ func (v *AviVsCache) AddToL4PolicyCollection(k NamespaceName) {
 	if !utils.HasElem(v.L4PolicyCollection, k) {
 		v.L4PolicyCollection = append(v.L4PolicyCollection, k)
 	}
+	v.indexRef(VSRefL4Policy, k)
 }
 
 func (v *AviVsCache) RemoveFromL4PolicyCollection(k NamespaceName) {
@@ -270,6 +364,7 @@ func (v *AviVsCache) RemoveFromL4PolicyCollection(k NamespaceName) {
 		return
 	}
 	v.L4PolicyCollection = Remove(v.L4PolicyCollection, k)
+	v.unindexRef(VSRefL4Policy, k, v.L4PolicyCollection)
 }
 
 func (v *AviVsCache) AddToSNIChildCollection(k string) {
@@ -292,6 +387,119 @@ func (v *AviVsCache) RemoveFromSNIChildCollection(k string) {
 	v.SNIChildCollection = RemoveString(v.SNIChildCollection, k)
 }
 
+// VSRefType identifies the collection of an AviVsCache through which the VS references an object.
+type VSRefType string
+
+const (
+	VSRefPG         VSRefType = "PoolGroup"
+	VSRefVSVip      VSRefType = "VsVip"
+	VSRefPool       VSRefType = "Pool"
+	VSRefDS         VSRefType = "VSDataScriptSet"
+	VSRefHTTP       VSRefType = "HTTPPolicySet"
+	VSRefSSLKeyCert VSRefType = "SSLKeyAndCertificate"
+	VSRefL4Policy   VSRefType = "L4PolicySet"
+	VSRefAppProfile VSRefType = "ApplicationProfile"
+	VSRefSSLProfile VSRefType = "SSLProfile"
+	VSRefSSOPolicy  VSRefType = "SSOPolicy"
+)
+
+func (v *AviVsCache) refCollections() map[VSRefType][]NamespaceName {
+	return map[VSRefType][]NamespaceName{
+		VSRefPG:         v.PGKeyCollection,
+		VSRefVSVip:      v.VSVipKeyCollection,
+		VSRefPool:       v.PoolKeyCollection,
+		VSRefDS:         v.DSKeyCollection,
+		VSRefHTTP:       v.HTTPKeyCollection,
+		VSRefSSLKeyCert: v.SSLKeyCertCollection,
+		VSRefL4Policy:   v.L4PolicyCollection,
+		VSRefAppProfile: v.AppProfileCollection,
+		VSRefSSLProfile: v.SSLProfileCollection,
+		VSRefSSOPolicy:  v.SSOPolicyCollection,
+	}
+}
+
+func (v *AviVsCache) indexRef(refType VSRefType, k NamespaceName) {
+	if v.refIndex != nil {
+		v.refIndex.add(refType, k, v.refKey)
+	}
+}
+
+// unindexRef drops the reference to k unless the collection still holds it.
+func (v *AviVsCache) unindexRef(refType VSRefType, k NamespaceName, collection []NamespaceName) {
+	if v.refIndex != nil && !utils.HasElem(collection, k) {
+		v.refIndex.remove(refType, k, v.refKey)
+	}
+}
+
+func (v *AviVsCache) attachRefIndex(refIndex *vsRefIndex, vsKey NamespaceName) {
+	v.refIndex = refIndex
+	v.refKey = vsKey
+	for refType, collection := range v.refCollections() {
+		for _, k := range collection {
+			refIndex.add(refType, k, vsKey)
+		}
+	}
+}
+
+func (v *AviVsCache) detachRefIndex(refIndex *vsRefIndex) {
+	if v.refIndex != refIndex {
+		return
+	}
+	for refType, collection := range v.refCollections() {
+		for _, k := range collection {
+			refIndex.remove(refType, k, v.refKey)
+		}
+	}
+	v.refIndex = nil
+}
+
+type vsRef struct {
+	refType VSRefType
+	key     NamespaceName
+}
+
+// vsRefIndex maps the objects referenced by the virtual services of an AviCache to the keys of the
+// virtual services referencing them. It has a lock of its own as the collections of a VS are updated
+// without holding the lock of the AviCache.
+type vsRefIndex struct {
+	lock sync.RWMutex
+	refs map[vsRef]map[NamespaceName]struct{}
+}
+
+func newVSRefIndex() *vsRefIndex {
+	return &vsRefIndex{refs: make(map[vsRef]map[NamespaceName]struct{})}
+}
+
+func (r *vsRefIndex) add(refType VSRefType, k, vsKey NamespaceName) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	ref := vsRef{refType: refType, key: k}
+	if r.refs[ref] == nil {
+		r.refs[ref] = make(map[NamespaceName]struct{})
+	}
+	r.refs[ref][vsKey] = struct{}{}
+}
+
+func (r *vsRefIndex) remove(refType VSRefType, k, vsKey NamespaceName) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	ref := vsRef{refType: refType, key: k}
+	delete(r.refs[ref], vsKey)
+	if len(r.refs[ref]) == 0 {
+		delete(r.refs, ref)
+	}
+}
+
+func (r *vsRefIndex) get(refType VSRefType, k NamespaceName) []NamespaceName {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var vsKeys []NamespaceName
+	for vsKey := range r.refs[vsRef{refType: refType, key: k}] {
+		vsKeys = append(vsKeys, vsKey)
+	}
+	return vsKeys
+}
+
 type AviSSLCache struct {
 	Name             string
 	Tenant           string
@@ -315,6 +523,116 @@ type AviPkiProfileCache struct {
 	HasReference     bool
 }
 
+type AviAppProfileCache struct {
+	Name                 string
+	Tenant               string
+	Uuid                 string
+	CloudConfigCksum     string
+	PkiProfileCollection NamespaceName
+	LastModified         string
+	InvalidData          bool
+	HasReference         bool
+}
+
+type AviSSLProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+type AviSSOPolicyCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+type AviAuthProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+type AviJWTProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+type AviPersistenceProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+type AviHealthMonitorCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+// AviProfileCacheObj is implemented by the cache objects of the profiles and healthmonitors created by AKO,
+// which are populated, created, updated and deleted alike.
+type AviProfileCacheObj interface {
+	// GetCacheMeta returns the name, uuid and checksum of the object, along with its InvalidData flag
+	GetCacheMeta() (name, uuid, checksum string, invalidData *bool)
+}
+
+func (c *AviAppProfileCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, c.CloudConfigCksum, &c.InvalidData
+}
+
+func (c *AviPkiProfileCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
+func (c *AviSSLProfileCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
+func (c *AviSSOPolicyCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
+func (c *AviAuthProfileCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
+func (c *AviJWTProfileCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
+func (c *AviPersistenceProfileCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
+func (c *AviHealthMonitorCache) GetCacheMeta() (string, string, string, *bool) {
+	return c.Name, c.Uuid, strconv.FormatUint(uint64(c.CloudConfigCksum), 10), &c.InvalidData
+}
+
 type NextPage struct {
 	Next_uri   string
 	Collection interface{}
@@ -340,6 +658,7 @@ type AviVSVIPCache struct {
 	LastModified     string
 	InvalidData      bool
 	Vips             []string
+	V6Vips           []string
 	NetworkName      string
 	HasReference     bool
 }
@@ -397,11 +716,28 @@ func (v *AviVsCache) GetVSCopy() (*AviVsCache, bool) {
 type AviCache struct {
 	cache_lock sync.RWMutex
 	cache      map[interface{}]interface{}
+	// Secondary indexes, maintained on add and delete under cache_lock.
+	uuids    map[string]interface{}
+	tenants  map[string]map[interface{}]struct{}
+	children map[NamespaceName]map[interface{}]struct{}
+	indexed  map[interface{}]cacheIndexEntry
+	refs     *vsRefIndex
+}
+
+// cacheIndexEntry is the uuid and parent VS a key was last indexed with.
+type cacheIndexEntry struct {
+	uuid   string
+	parent NamespaceName
 }
 
 func NewAviCache() *AviCache {
 	c := AviCache{}
 	c.cache = make(map[interface{}]interface{})
+	c.uuids = make(map[string]interface{})
+	c.tenants = make(map[string]map[interface{}]struct{})
+	c.children = make(map[NamespaceName]map[interface{}]struct{})
+	c.indexed = make(map[interface{}]cacheIndexEntry)
+	c.refs = newVSRefIndex()
 	return &c
 }
 
@@ -429,9 +765,9 @@ func (c *AviCache) AviCacheGetAllChildVSForParent(parentVsKey NamespaceName) []s
 	c.cache_lock.RLock()
 	defer c.cache_lock.RUnlock()
 	var uuids []string
-	for _, val := range c.cache {
-		if val.(*AviVsCache).ParentVSRef == parentVsKey {
-			uuids = append(uuids, val.(*AviVsCache).Uuid)
+	for k := range c.children[parentVsKey] {
+		if vs, ok := c.cache[k].(*AviVsCache); ok {
+			uuids = append(uuids, vs.Uuid)
 		}
 	}
 	return uuids
@@ -447,82 +783,219 @@ func (c *AviCache) AviGetAllKeys() []NamespaceName {
 	return keys
 }
 
+// AviCacheGetKeysByTenant returns the keys of the objects of the tenant.
+func (c *AviCache) AviCacheGetKeysByTenant(tenant string) []NamespaceName {
+	c.cache_lock.RLock()
+	defer c.cache_lock.RUnlock()
+	var keys []NamespaceName
+	for key := range c.tenants[tenant] {
+		keys = append(keys, key.(NamespaceName))
+	}
+	return keys
+}
+
+// AviCacheGetVSKeysReferencing returns the keys of the virtual services which reference the object k
+// through the collection of refType.
+func (c *AviCache) AviCacheGetVSKeysReferencing(refType VSRefType, k NamespaceName) []NamespaceName {
+	return c.refs.get(refType, k)
+}
+
 func (c *AviCache) AviCacheGetKeyByUuid(uuid string) (interface{}, bool) {
 	c.cache_lock.RLock()
 	defer c.cache_lock.RUnlock()
-	for key, value := range c.cache {
-		switch value.(type) {
-		case *AviVsCache:
-			if value.(*AviVsCache).Uuid == uuid {
-				return key, true
-			}
-		case *AviVSVIPCache:
-			if value.(*AviVSVIPCache).Uuid == uuid {
-				return key, true
-			}
-		}
+	key, found := c.uuids[uuid]
+	if !found {
+		return nil, false
 	}
-	return nil, false
+	if objUuid, _ := cacheObjUuidName(c.cache[key]); objUuid != uuid {
+		return nil, false
+	}
+	return key, true
 }
 
 func (c *AviCache) AviCacheGetNameByUuid(uuid string) (interface{}, bool) {
 	c.cache_lock.RLock()
 	defer c.cache_lock.RUnlock()
-	for _, value := range c.cache {
-		switch value.(type) {
-		case *AviPoolCache:
-			if value.(*AviPoolCache).Uuid == uuid {
-				return value.(*AviPoolCache).Name, true
-			}
-		case *AviVSVIPCache:
-			if value.(*AviVSVIPCache).Uuid == uuid {
-				return value.(*AviVSVIPCache).Name, true
-			}
-		case *AviSSLCache:
-			if value.(*AviSSLCache).Uuid == uuid {
-				return value.(*AviSSLCache).Name, true
-			}
-		case *AviDSCache:
-			if value.(*AviDSCache).Uuid == uuid {
-				return value.(*AviDSCache).Name, true
-			}
-		case *AviL4PolicyCache:
-			if value.(*AviL4PolicyCache).Uuid == uuid {
-				return value.(*AviL4PolicyCache).Name, true
-			}
-		case *AviHTTPPolicyCache:
-			if value.(*AviHTTPPolicyCache).Uuid == uuid {
-				return value.(*AviHTTPPolicyCache).Name, true
-			}
-		case *AviPGCache:
-			if value.(*AviPGCache).Uuid == uuid {
-				return value.(*AviPGCache).Name, true
-			}
-		case *AviPkiProfileCache:
-			if value.(*AviPkiProfileCache).Uuid == uuid {
-				return value.(*AviPkiProfileCache).Name, true
-			}
-		}
+	key, found := c.uuids[uuid]
+	if !found {
+		return nil, false
+	}
+	objUuid, name := cacheObjUuidName(c.cache[key])
+	if objUuid != uuid {
+		return nil, false
 	}
-	return nil, false
+	return name, true
+}
+
+func cacheObjUuidName(val interface{}) (string, string) {
+	switch obj := val.(type) {
+	case *AviVsCache:
+		return obj.Uuid, obj.Name
+	case *AviPoolCache:
+		return obj.Uuid, obj.Name
+	case *AviVSVIPCache:
+		return obj.Uuid, obj.Name
+	case *AviSSLCache:
+		return obj.Uuid, obj.Name
+	case *AviDSCache:
+		return obj.Uuid, obj.Name
+	case *AviL4PolicyCache:
+		return obj.Uuid, obj.Name
+	case *AviHTTPPolicyCache:
+		return obj.Uuid, obj.Name
+	case *AviPGCache:
+		return obj.Uuid, obj.Name
+	case *AviPkiProfileCache:
+		return obj.Uuid, obj.Name
+	case *AviHealthMonitorCache:
+		return obj.Uuid, obj.Name
+	case *AviAppProfileCache:
+		return obj.Uuid, obj.Name
+	case *AviSSLProfileCache:
+		return obj.Uuid, obj.Name
+	case *AviPersistenceProfileCache:
+		return obj.Uuid, obj.Name
+	case *AviSSOPolicyCache:
+		return obj.Uuid, obj.Name
+	case *AviAuthProfileCache:
+		return obj.Uuid, obj.Name
+	case *AviJWTProfileCache:
+		return obj.Uuid, obj.Name
+	case *AviVrfCache:
+		return obj.Uuid, obj.Name
+	}
+	return "", ""
 }
 
 func (c *AviCache) AviCacheAdd(k interface{}, val interface{}) {
-	c.cache_lock.Lock()
-	defer c.cache_lock.Unlock()
+	unlock := c.lockEntry(k)
+	defer unlock()
+	if _, found := c.cache[k]; found {
+		c.indexDelete(k)
+	}
 	c.cache[k] = val
+	c.indexAdd(k, val)
 }
 
 func (c *AviCache) AviCacheDelete(k interface{}) {
+	unlock := c.lockEntry(k)
+	defer unlock()
+	if _, found := c.cache[k]; found {
+		c.indexDelete(k)
+	}
+	delete(c.cache, k)
+}
+
+// lockEntry locks the cache to replace or delete the object of k. When the object is a VS its lock is taken
+// first, as detaching it from the ref index reads its collections, and the rest layer takes the lock of a VS
+// before the lock of the cache. It returns the function releasing both locks.
+func (c *AviCache) lockEntry(k interface{}) func() {
+	for {
+		val, _ := c.AviCacheGet(k)
+		vs, _ := val.(*AviVsCache)
+		if vs != nil {
+			vs.VSCacheLock.Lock()
+		}
+		c.cache_lock.Lock()
+		if current, _ := c.cache[k].(*AviVsCache); current == vs {
+			return func() {
+				c.cache_lock.Unlock()
+				if vs != nil {
+					vs.VSCacheLock.Unlock()
+				}
+			}
+		}
+		// the object was replaced in the meantime
+		c.cache_lock.Unlock()
+		if vs != nil {
+			vs.VSCacheLock.Unlock()
+		}
+	}
+}
+
+// AviCacheReindex refreshes the uuid and parent VS indexes of k, to be called once its object has
+// been modified in place.
+func (c *AviCache) AviCacheReindex(k interface{}) {
 	c.cache_lock.Lock()
 	defer c.cache_lock.Unlock()
-	delete(c.cache, k)
+	if val, found := c.cache[k]; found {
+		c.indexUpdate(k, val)
+	}
+}
+
+func (c *AviCache) indexAdd(k interface{}, val interface{}) {
+	if nsKey, ok := k.(NamespaceName); ok {
+		if c.tenants[nsKey.Namespace] == nil {
+			c.tenants[nsKey.Namespace] = make(map[interface{}]struct{})
+		}
+		c.tenants[nsKey.Namespace][k] = struct{}{}
+		if vs, ok := val.(*AviVsCache); ok {
+			vs.attachRefIndex(c.refs, nsKey)
+		}
+	}
+	c.indexUpdate(k, val)
+}
+
+func (c *AviCache) indexUpdate(k interface{}, val interface{}) {
+	entry := cacheIndexEntry{}
+	entry.uuid, _ = cacheObjUuidName(val)
+	if vs, ok := val.(*AviVsCache); ok {
+		entry.parent = vs.ParentVSRef
+	}
+	old := c.indexed[k]
+	if old.uuid != entry.uuid {
+		if old.uuid != "" && c.uuids[old.uuid] == k {
+			delete(c.uuids, old.uuid)
+		}
+		if entry.uuid != "" {
+			c.uuids[entry.uuid] = k
+		}
+	}
+	if old.parent != entry.parent {
+		if old.parent != (NamespaceName{}) {
+			delete(c.children[old.parent], k)
+			if len(c.children[old.parent]) == 0 {
+				delete(c.children, old.parent)
+			}
+		}
+		if entry.parent != (NamespaceName{}) {
+			if c.children[entry.parent] == nil {
+				c.children[entry.parent] = make(map[interface{}]struct{})
+			}
+			c.children[entry.parent][k] = struct{}{}
+		}
+	}
+	c.indexed[k] = entry
+}
+
+func (c *AviCache) indexDelete(k interface{}) {
+	c.indexUpdate(k, nil)
+	delete(c.indexed, k)
+	if nsKey, ok := k.(NamespaceName); ok {
+		delete(c.tenants[nsKey.Namespace], k)
+		if len(c.tenants[nsKey.Namespace]) == 0 {
+			delete(c.tenants, nsKey.Namespace)
+		}
+	}
+	if vs, ok := c.cache[k].(*AviVsCache); ok {
+		vs.detachRefIndex(c.refs)
+	}
+}
+
+// AviCacheRange calls f for the objects of the cache as of the time of the call, the cache is not
+// locked while f runs so that f may add to or delete from it. Iteration stops when f returns false.
+func (c *AviCache) AviCacheRange(f func(k interface{}, val interface{}) bool) {
+	for k, val := range c.ShallowCopy() {
+		if !f(k, val) {
+			return
+		}
+	}
 }
 
 func (c *AviCache) ShallowCopy() map[interface{}]interface{} {
 	// Shallow copy, does not dereference the pointers.
-	c.cache_lock.Lock()
-	defer c.cache_lock.Unlock()
+	c.cache_lock.RLock()
+	defer c.cache_lock.RUnlock()
 	newMap := make(map[interface{}]interface{})
 	for key, value := range c.cache {
 		newMap[key] = value
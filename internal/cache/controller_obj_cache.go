@@ -28,6 +28,7 @@ import (
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	"github.com/avinetworks/sdk/go/clients"
@@ -37,19 +38,29 @@ import (
 )
 
 type AviObjCache struct {
-	PgCache            *AviCache
-	DSCache            *AviCache
-	PoolCache          *AviCache
-	CloudKeyCache      *AviCache
-	HTTPPolicyCache    *AviCache
-	L4PolicyCache      *AviCache
-	SSLKeyCache        *AviCache
-	PKIProfileCache    *AviCache
-	VSVIPCache         *AviCache
-	VrfCache           *AviCache
-	VsCacheMeta        *AviCache
-	VsCacheLocal       *AviCache
-	ClusterStatusCache *AviCache
+	PgCache                 *AviCache
+	DSCache                 *AviCache
+	PoolCache               *AviCache
+	CloudKeyCache           *AviCache
+	HTTPPolicyCache         *AviCache
+	L4PolicyCache           *AviCache
+	SSLKeyCache             *AviCache
+	PKIProfileCache         *AviCache
+	HealthMonitorCache      *AviCache
+	AppProfileCache         *AviCache
+	SSLProfileCache         *AviCache
+	SSOPolicyCache          *AviCache
+	AuthProfileCache        *AviCache
+	JWTProfileCache         *AviCache
+	PersistenceProfileCache *AviCache
+	VSVIPCache              *AviCache
+	VrfCache                *AviCache
+	VsCacheMeta             *AviCache
+	VsCacheLocal            *AviCache
+	ClusterStatusCache      *AviCache
+	// bounds the requests populating the cache, created along with the first of them
+	populator     *cachePopulator
+	populatorOnce sync.Once
 }
 
 func NewAviObjCache() *AviObjCache {
@@ -66,6 +77,13 @@ func NewAviObjCache() *AviObjCache {
 	c.VSVIPCache = NewAviCache()
 	c.VrfCache = NewAviCache()
 	c.PKIProfileCache = NewAviCache()
+	c.HealthMonitorCache = NewAviCache()
+	c.AppProfileCache = NewAviCache()
+	c.SSLProfileCache = NewAviCache()
+	c.SSOPolicyCache = NewAviCache()
+	c.AuthProfileCache = NewAviCache()
+	c.JWTProfileCache = NewAviCache()
+	c.PersistenceProfileCache = NewAviCache()
 	c.ClusterStatusCache = NewAviCache()
 	return &c
 }
@@ -81,28 +99,141 @@ func SharedAviObjCache() *AviObjCache {
 }
 
 func (c *AviObjCache) AviRefreshObjectCache(client *clients.AviClient, cloud string) {
-	c.PopulatePkiProfilesToCache(client)
-	c.PopulatePoolsToCache(client, cloud)
-	c.PopulatePgDataToCache(client, cloud)
-	c.PopulateDSDataToCache(client, cloud)
-	c.PopulateSSLKeyToCache(client, cloud)
-	c.PopulateHttpPolicySetToCache(client, cloud)
-	c.PopulateL4PolicySetToCache(client, cloud)
-	c.PopulateVsVipDataToCache(client, cloud)
+	populateConcurrently(
+		[]func(){
+			func() { c.PopulatePkiProfilesToCache(client) },
+			func() { c.PopulateHealthMonitorsToCache(client) },
+			func() { c.PopulateAppProfilesToCache(client) },
+			func() { c.PopulateSSLProfilesToCache(client) },
+			func() { c.PopulateJWTProfilesToCache(client) },
+			func() { c.PopulateAuthProfilesToCache(client) },
+			func() { c.PopulateSSOPoliciesToCache(client) },
+			func() { c.PopulatePersistenceProfilesToCache(client) },
+			func() { c.PopulateSSLKeyToCache(client, cloud) },
+			func() { c.PopulateVsVipDataToCache(client, cloud) },
+		},
+		// pools refer to the pki profiles, health monitors, persistence profiles and client key/certs
+		[]func(){
+			func() { c.PopulatePoolsToCache(client, cloud) },
+		},
+		// poolgroups and l4 policies refer to the pools
+		[]func(){
+			func() { c.PopulatePgDataToCache(client, cloud) },
+			func() { c.PopulateL4PolicySetToCache(client, cloud) },
+		},
+		// datascripts and http policies refer to the poolgroups
+		[]func(){
+			func() { c.PopulateDSDataToCache(client, cloud) },
+			func() { c.PopulateHttpPolicySetToCache(client, cloud) },
+		},
+	)
 }
 
 func (c *AviObjCache) AviCacheRefresh(client *clients.AviClient, cloud string) {
 	c.AviCloudPropertiesPopulate(client, cloud)
 }
 
-func (c *AviObjCache) AviObjCachePopulate(client *clients.AviClient, version string, cloud string) ([]NamespaceName, []NamespaceName, error) {
+// aviGetLastModified returns the _last_modified of the objects of a collection by their name,
+// only these two fields are fetched so that the listing stays cheap for large clusters.
+func aviGetLastModified(client *clients.AviClient, objType, uri string) (map[string]string, error) {
+	lastModified := make(map[string]string)
+	uri += "&fields=name,_last_modified"
+	for uri != "" {
+		var rest_response interface{}
+		if err := lib.AviGet(client, uri, &rest_response); err != nil {
+			utils.AviLog.Warnf("Get uri %v returned err for %s %v", uri, objType, err)
+			return nil, err
+		}
+		resp, ok := rest_response.(map[string]interface{})
+		if !ok {
+			utils.AviLog.Warnf("Get uri %v returned %v type %T", uri, rest_response, rest_response)
+			return nil, fmt.Errorf("%s type is wrong", objType)
+		}
+		results, ok := resp["results"].([]interface{})
+		if !ok {
+			utils.AviLog.Warnf("results not of type []interface{} Instead of type %T", resp["results"])
+			return nil, fmt.Errorf("Results are not of right type for %s", objType)
+		}
+		for _, objIntf := range results {
+			if obj, ok := objIntf.(map[string]interface{}); ok {
+				if name, ok := obj["name"].(string); ok {
+					lastModified[name], _ = obj["_last_modified"].(string)
+				}
+			}
+		}
+		uri = ""
+		if next, ok := resp["next"].(string); ok {
+			if nextURI := strings.Split(next, "/api/"+objType); len(nextURI) > 1 {
+				uri = "/api/" + objType + nextURI[1]
+			}
+		}
+	}
+	return lastModified, nil
+}
+
+// AviVsCacheRefreshIncremental refreshes the VS cache by the last_modified of the virtual services on the
+// controller, only the ones modified since AKO last saw them are fetched again. Their checksum is cleared so
+// that the next push of their model restores them, the keys of the affected parent virtual services are returned.
+func (c *AviObjCache) AviVsCacheRefreshIncremental(client *clients.AviClient, cloud string) ([]NamespaceName, error) {
+	uri := "/api/virtualservice/?" + "include_name=true" + "&cloud_ref.name=" + cloud + "&created_by=" + lib.AKOUser + "&page_size=100"
+	lastModified, err := aviGetLastModified(client, "virtualservice", uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var driftedKeys []NamespaceName
+	c.VsCacheMeta.AviCacheRange(func(k interface{}, vsCache interface{}) bool {
+		vsKey, isKey := k.(NamespaceName)
+		vsCacheObj, ok := vsCache.(*AviVsCache)
+		if !isKey || !ok || vsKey.Name == lib.DummyVSForStaleData {
+			return true
+		}
+		vsCacheObj.VSCacheLock.RLock()
+		cachedLastModified, parentKey := vsCacheObj.LastModified, vsCacheObj.ParentVSRef
+		passthroughParent := vsCacheObj.ServiceMetadataObj.PassthroughParentRef
+		vsCacheObj.VSCacheLock.RUnlock()
+		if current, found := lastModified[vsKey.Name]; found && current == cachedLastModified {
+			return true
+		}
+
+		utils.AviLog.Infof("VS %s was modified on the controller, refreshing its cache", vsKey)
+		if err := c.AviObjOneVSCachePopulate(client, cloud, vsKey.Name); err != nil {
+			return true
+		}
+		if vsCache, found := c.VsCacheMeta.AviCacheGet(vsKey); found {
+			if vsCacheObj, ok := vsCache.(*AviVsCache); ok {
+				vsCacheObj.VSCacheLock.Lock()
+				vsCacheObj.CloudConfigCksum = ""
+				vsCacheObj.InvalidData = true
+				vsCacheObj.VSCacheLock.Unlock()
+			}
+		}
+		if parentKey == (NamespaceName{}) {
+			parentKey = vsKey
+			if passthroughParent != "" {
+				parentKey = NamespaceName{Namespace: vsKey.Namespace, Name: passthroughParent}
+			}
+		}
+		if !utils.HasElem(driftedKeys, parentKey) {
+			driftedKeys = append(driftedKeys, parentKey)
+		}
+		return true
+	})
+	return driftedKeys, nil
+}
+
+func (c *AviObjCache) AviObjCachePopulate(client *clients.AviClient, version string, cloud string) (vsCacheCopy []NamespaceName, allVsKeys []NamespaceName, err error) {
 	SetTenant := session.SetTenant(lib.GetTenant())
 	SetTenant(client.AviSession)
 	SetVersion := session.SetVersion(version)
 	SetVersion(client.AviSession)
-	vsCacheCopy := []NamespaceName{}
-	allVsKeys := []NamespaceName{}
-	err := c.AviObjVrfCachePopulate(client, cloud)
+	apimodels.RestStatus.StartCachePopulate()
+	defer func() {
+		apimodels.RestStatus.FinishCachePopulate(err)
+	}()
+	vsCacheCopy = []NamespaceName{}
+	allVsKeys = []NamespaceName{}
+	err = c.AviObjVrfCachePopulate(client, cloud)
 	if err != nil {
 		return vsCacheCopy, allVsKeys, err
 	}
@@ -327,27 +458,16 @@ func (c *AviObjCache) DeleteUnmarked() {
 
 }
 
-func (c *AviObjCache) AviPopulateAllPGs(client *clients.AviClient, cloud string, pgData *[]AviPGCache, override_uri ...NextPage) (*[]AviPGCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllPGs(client *clients.AviClient, cloud string, pgData *[]AviPGCache) (*[]AviPGCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(override_uri) == 1 {
-		uri = override_uri[0].Next_uri
-	} else {
-		uri = "/api/poolgroup/?" + "include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100"
-	}
+	uri := "/api/poolgroup/?" + "include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100"
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, count, err := c.cachePopulator(client).getCollection("poolgroup", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for pg %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		utils.AviLog.Warnf("Failed to unmarshal pg data, err: %v", err)
-		return nil, 0, err
-	}
 	for i := 0; i < len(elems); i++ {
 		pg := models.PoolGroup{}
 		err = json.Unmarshal(elems[i], &pg)
@@ -381,19 +501,7 @@ func (c *AviObjCache) AviPopulateAllPGs(client *clients.AviClient, cloud string,
 		}
 		*pgData = append(*pgData, pgCacheObj)
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/poolgroup")
-		if len(next_uri) > 1 {
-			override_uri := "/api/poolgroup" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllPGs(client, cloud, pgData, nextPage)
-			if err != nil {
-				return nil, 0, err
-			}
-		}
-	}
-	return pgData, result.Count, nil
+	return pgData, count, nil
 }
 
 func (c *AviObjCache) PopulatePgDataToCache(client *clients.AviClient, cloud string) {
@@ -429,26 +537,16 @@ func (c *AviObjCache) PopulatePgDataToCache(client *clients.AviClient, cloud str
 	}
 }
 
-func (c *AviObjCache) AviPopulateAllPkiPRofiles(client *clients.AviClient, pkiData *[]AviPkiProfileCache, override_uri ...NextPage) (*[]AviPkiProfileCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllPkiPRofiles(client *clients.AviClient, pkiData *[]AviPkiProfileCache) (*[]AviPkiProfileCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(override_uri) == 1 {
-		uri = override_uri[0].Next_uri
-	} else {
-		uri = "/api/pkiprofile/?" + "&include_name=true&" + "&created_by=" + akoUser + "&page_size=100"
-	}
+	uri := "/api/pkiprofile/?" + "&include_name=true&" + "&created_by=" + akoUser + "&page_size=100"
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, count, err := c.cachePopulator(client).getCollection("pkiprofile", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for pool %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		return nil, 0, err
-	}
 	for i := 0; i < len(elems); i++ {
 		pki := models.PKIprofile{}
 		err = json.Unmarshal(elems[i], &pki)
@@ -474,42 +572,349 @@ func (c *AviObjCache) AviPopulateAllPkiPRofiles(client *clients.AviClient, pkiDa
 		*pkiData = append(*pkiData, pkiCacheObj)
 
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/pkiprofile")
-		if len(next_uri) > 1 {
-			override_uri := "/api/pkiprofile" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllPkiPRofiles(client, pkiData, nextPage)
-			if err != nil {
-				return nil, 0, err
-			}
+
+	return pkiData, count, nil
+}
+
+func (c *AviObjCache) AviPopulateAllHealthMonitors(client *clients.AviClient, hmData *[]AviHealthMonitorCache) (*[]AviHealthMonitorCache, int, error) {
+	elems, count, err := c.cachePopulator(client).getNamePrefixCollection("healthmonitor")
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		hm := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &hm)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+
+		if hm.Name == nil || hm.UUID == nil || hm.Type == nil {
+			utils.AviLog.Warnf("Incomplete healthmonitor data unmarshalled, %s", utils.Stringify(hm))
+			continue
 		}
+		checksum := AviHealthMonitorChecksum(&hm)
+		hmCacheObj := AviHealthMonitorCache{
+			Name:             *hm.Name,
+			Uuid:             *hm.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: checksum,
+		}
+		*hmData = append(*hmData, hmCacheObj)
 	}
 
-	return pkiData, result.Count, nil
+	return hmData, count, nil
 }
 
-func (c *AviObjCache) AviPopulateAllPools(client *clients.AviClient, cloud string, poolData *[]AviPoolCache, override_uri ...NextPage) (*[]AviPoolCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllAppProfiles(client *clients.AviClient, appProfileData *[]AviAppProfileCache) (*[]AviAppProfileCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(override_uri) == 1 {
-		uri = override_uri[0].Next_uri
-	} else {
-		uri = "/api/pool/?" + "&include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100"
+	uri := "/api/applicationprofile/?" + "&include_name=true&" + "&created_by=" + akoUser + "&page_size=100"
+
+	elems, count, err := c.cachePopulator(client).getCollection("applicationprofile", uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return nil, 0, err
 	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationprofile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+		*appProfileData = append(*appProfileData, c.getAppProfileCacheObj(&appProfile))
+	}
+
+	return appProfileData, count, nil
+}
+
+// getAppProfileCacheObj builds the cache object of an application profile created by AKO,
+// the pki profile is resolved from the pki profile cache
+func (c *AviObjCache) getAppProfileCacheObj(appProfile *models.ApplicationProfile) AviAppProfileCache {
+	var pkiKey NamespaceName
+	if httpProfile := appProfile.HTTPProfile; httpProfile != nil && httpProfile.PkiProfileRef != nil {
+		pkiUuid := ExtractUuid(*httpProfile.PkiProfileRef, "pkiprofile-.*.#")
+		pkiName, foundPki := c.PKIProfileCache.AviCacheGetNameByUuid(pkiUuid)
+		if foundPki {
+			pkiKey = NamespaceName{Namespace: lib.GetTenant(), Name: pkiName.(string)}
+		}
+	}
+
+	var lastModified, cksum string
+	if appProfile.LastModified != nil {
+		lastModified = *appProfile.LastModified
+	}
+	if appProfile.CloudConfigCksum != nil {
+		cksum = *appProfile.CloudConfigCksum
+	}
+	return AviAppProfileCache{
+		Name:                 *appProfile.Name,
+		Uuid:                 *appProfile.UUID,
+		Tenant:               lib.GetTenant(),
+		CloudConfigCksum:     cksum,
+		PkiProfileCollection: pkiKey,
+		LastModified:         lastModified,
+	}
+}
+
+func (c *AviObjCache) AviPopulateAllSSLProfiles(client *clients.AviClient, sslProfileData *[]AviSSLProfileCache) (*[]AviSSLProfileCache, int, error) {
+	elems, count, err := c.cachePopulator(client).getNamePrefixCollection("sslprofile")
 	if err != nil {
-		utils.AviLog.Warnf("Get uri %v returned err for pool %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
+	for i := 0; i < len(elems); i++ {
+		sslProfile := models.SSLProfile{}
+		err = json.Unmarshal(elems[i], &sslProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal sslprofile data, err: %v", err)
+			continue
+		}
+
+		if sslProfile.Name == nil || sslProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete sslprofile data unmarshalled, %s", utils.Stringify(sslProfile))
+			continue
+		}
+		sslProfileCacheObj := AviSSLProfileCache{
+			Name:             *sslProfile.Name,
+			Uuid:             *sslProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviSSLProfileChecksum(&sslProfile),
+		}
+		*sslProfileData = append(*sslProfileData, sslProfileCacheObj)
+	}
+
+	return sslProfileData, count, nil
+}
+
+// AviSSLProfileChecksum computes the checksum of an sslprofile object,
+// it matches the checksum of the sslprofile nodes in the model
+func AviSSLProfileChecksum(sslProfile *models.SSLProfile) uint32 {
+	var acceptedVersions []string
+	for _, version := range sslProfile.AcceptedVersions {
+		if version.Type != nil {
+			acceptedVersions = append(acceptedVersions, *version.Type)
+		}
+	}
+	var acceptedCiphers, ciphersuites string
+	if sslProfile.AcceptedCiphers != nil {
+		acceptedCiphers = *sslProfile.AcceptedCiphers
+	}
+	if sslProfile.Ciphersuites != nil {
+		ciphersuites = *sslProfile.Ciphersuites
+	}
+	return lib.SSLProfileChecksum(*sslProfile.Name, acceptedVersions, acceptedCiphers, ciphersuites)
+}
+
+func (c *AviObjCache) AviPopulateAllSSOPolicies(client *clients.AviClient, ssoPolicyData *[]AviSSOPolicyCache) (*[]AviSSOPolicyCache, int, error) {
+	elems, count, err := c.cachePopulator(client).getNamePrefixCollection("ssopolicy")
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		ssoPolicy := models.SSOPolicy{}
+		err = json.Unmarshal(elems[i], &ssoPolicy)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal ssopolicy data, err: %v", err)
+			continue
+		}
+
+		if ssoPolicy.Name == nil || ssoPolicy.UUID == nil {
+			utils.AviLog.Warnf("Incomplete ssopolicy data unmarshalled, %s", utils.Stringify(ssoPolicy))
+			continue
+		}
+		ssoPolicyCacheObj := AviSSOPolicyCache{
+			Name:             *ssoPolicy.Name,
+			Uuid:             *ssoPolicy.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviSSOPolicyChecksum(&ssoPolicy),
+		}
+		*ssoPolicyData = append(*ssoPolicyData, ssoPolicyCacheObj)
+	}
+
+	return ssoPolicyData, count, nil
+}
+
+func (c *AviObjCache) AviPopulateAllAuthProfiles(client *clients.AviClient, authProfileData *[]AviAuthProfileCache) (*[]AviAuthProfileCache, int, error) {
+	elems, count, err := c.cachePopulator(client).getNamePrefixCollection("authprofile")
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		authProfile := models.AuthProfile{}
+		err = json.Unmarshal(elems[i], &authProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal authprofile data, err: %v", err)
+			continue
+		}
+
+		if authProfile.Name == nil || authProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete authprofile data unmarshalled, %s", utils.Stringify(authProfile))
+			continue
+		}
+		authProfileCacheObj := AviAuthProfileCache{
+			Name:             *authProfile.Name,
+			Uuid:             *authProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviAuthProfileChecksum(&authProfile),
+		}
+		*authProfileData = append(*authProfileData, authProfileCacheObj)
+	}
+
+	return authProfileData, count, nil
+}
+
+func (c *AviObjCache) AviPopulateAllJWTProfiles(client *clients.AviClient, jwtProfileData *[]AviJWTProfileCache) (*[]AviJWTProfileCache, int, error) {
+	elems, count, err := c.cachePopulator(client).getNamePrefixCollection("jwtserverprofile")
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		jwtProfile := models.JWTServerProfile{}
+		err = json.Unmarshal(elems[i], &jwtProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal jwtserverprofile data, err: %v", err)
+			continue
+		}
+
+		if jwtProfile.Name == nil || jwtProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete jwtserverprofile data unmarshalled, %s", utils.Stringify(jwtProfile))
+			continue
+		}
+		jwtProfileCacheObj := AviJWTProfileCache{
+			Name:             *jwtProfile.Name,
+			Uuid:             *jwtProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviJWTProfileChecksum(&jwtProfile),
+		}
+		*jwtProfileData = append(*jwtProfileData, jwtProfileCacheObj)
+	}
+
+	return jwtProfileData, count, nil
+}
+
+// AviSSOPolicyChecksum computes the checksum of an SSO policy object,
+// it matches the checksum of the SSO policy nodes in the model
+func AviSSOPolicyChecksum(ssoPolicy *models.SSOPolicy) uint32 {
+	var ssoType, authProfileName string
+	var paths []string
+	if ssoPolicy.Type != nil {
+		ssoType = *ssoPolicy.Type
+	}
+	if authPolicy := ssoPolicy.AuthenticationPolicy; authPolicy != nil {
+		if authPolicy.DefaultAuthProfileRef != nil {
+			authProfileName = getRefName(*authPolicy.DefaultAuthProfileRef)
+		}
+		if len(authPolicy.AuthnRules) > 0 && authPolicy.AuthnRules[0].Match != nil &&
+			authPolicy.AuthnRules[0].Match.Path != nil {
+			paths = authPolicy.AuthnRules[0].Match.Path.MatchStr
+		}
+	}
+	return lib.SSOPolicyChecksum(*ssoPolicy.Name, ssoType, authProfileName, paths)
+}
+
+// AviAuthProfileChecksum computes the checksum of an authprofile created by AKO
+func AviAuthProfileChecksum(authProfile *models.AuthProfile) uint32 {
+	var jwtProfileName string
+	if authProfile.JwtProfileRef != nil {
+		jwtProfileName = getRefName(*authProfile.JwtProfileRef)
+	}
+	return lib.AuthProfileChecksum(*authProfile.Name, jwtProfileName)
+}
+
+// AviJWTProfileChecksum computes the checksum of a jwtserverprofile created by AKO
+func AviJWTProfileChecksum(jwtProfile *models.JWTServerProfile) uint32 {
+	var issuer, jwksKeys string
+	if jwtProfile.Issuer != nil {
+		issuer = *jwtProfile.Issuer
+	}
+	if jwtProfile.JwksKeys != nil {
+		jwksKeys = *jwtProfile.JwksKeys
+	}
+	return lib.JWTProfileChecksum(*jwtProfile.Name, issuer, jwksKeys)
+}
+
+// getRefName returns the name of the object referred by ref, when the ref carries it,
+// either as the #name suffix of include_name, or as the name query of AKO refs
+func getRefName(ref string) string {
+	if refTokens := strings.Split(ref, "#"); len(refTokens) > 1 {
+		return refTokens[1]
+	}
+	if refTokens := strings.Split(ref, "?name="); len(refTokens) > 1 {
+		return refTokens[1]
+	}
+	return ""
+}
+
+func (c *AviObjCache) AviPopulateAllPersistenceProfiles(client *clients.AviClient, persistenceProfileData *[]AviPersistenceProfileCache) (*[]AviPersistenceProfileCache, int, error) {
+	elems, count, err := c.cachePopulator(client).getNamePrefixCollection("applicationpersistenceprofile")
 	if err != nil {
 		return nil, 0, err
 	}
+	for i := 0; i < len(elems); i++ {
+		persistenceProfile := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistenceProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+
+		if persistenceProfile.Name == nil || persistenceProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationpersistenceprofile data unmarshalled, %s", utils.Stringify(persistenceProfile))
+			continue
+		}
+		persistenceProfileCacheObj := AviPersistenceProfileCache{
+			Name:             *persistenceProfile.Name,
+			Uuid:             *persistenceProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviPersistenceProfileChecksum(&persistenceProfile),
+		}
+		*persistenceProfileData = append(*persistenceProfileData, persistenceProfileCacheObj)
+	}
+
+	return persistenceProfileData, count, nil
+}
+
+// AviPersistenceProfileChecksum computes the checksum of an applicationpersistenceprofile created by AKO,
+// it matches the checksum of the persistence profile nodes in the model
+func AviPersistenceProfileChecksum(persistenceProfile *models.ApplicationPersistenceProfile) uint32 {
+	var persistenceType, cookieName, headerName string
+	var timeout int32
+	if persistenceProfile.PersistenceType != nil {
+		persistenceType = *persistenceProfile.PersistenceType
+	}
+	if cookieProfile := persistenceProfile.HTTPCookiePersistenceProfile; cookieProfile != nil {
+		if cookieProfile.CookieName != nil {
+			cookieName = *cookieProfile.CookieName
+		}
+		if cookieProfile.Timeout != nil {
+			timeout = *cookieProfile.Timeout
+		}
+	}
+	if hdrProfile := persistenceProfile.HdrPersistenceProfile; hdrProfile != nil && hdrProfile.PrstHdrName != nil {
+		headerName = *hdrProfile.PrstHdrName
+	}
+	if ipProfile := persistenceProfile.IPPersistenceProfile; ipProfile != nil && ipProfile.IPPersistentTimeout != nil {
+		timeout = *ipProfile.IPPersistentTimeout
+	}
+	return lib.PersistenceProfileChecksum(*persistenceProfile.Name, persistenceType, cookieName, headerName, timeout)
+}
+
+func (c *AviObjCache) AviPopulateAllPools(client *clients.AviClient, cloud string, poolData *[]AviPoolCache) (*[]AviPoolCache, int, error) {
+	akoUser := lib.AKOUser
+
+	uri := "/api/pool/?" + "&include_name=true&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100"
+
+	elems, count, err := c.cachePopulator(client).getCollection("pool", uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for pool %v", uri, err)
+		return nil, 0, err
+	}
 	for i := 0; i < len(elems); i++ {
 		pool := models.Pool{}
 		err = json.Unmarshal(elems[i], &pool)
@@ -538,60 +943,260 @@ func (c *AviObjCache) AviPopulateAllPools(client *clients.AviClient, cloud strin
 			}
 		}
 
+		var sslKey NamespaceName
+		if pool.SslKeyAndCertificateRef != nil {
+			sslUuid := ExtractUuid(*pool.SslKeyAndCertificateRef, "sslkeyandcertificate-.*.#")
+			sslName, foundSSL := c.SSLKeyCache.AviCacheGetNameByUuid(sslUuid)
+			if foundSSL {
+				sslKey = NamespaceName{Namespace: lib.GetTenant(), Name: sslName.(string)}
+			}
+		}
+
+		var persistenceKey NamespaceName
+		if pool.ApplicationPersistenceProfileRef != nil {
+			persistenceUuid := ExtractUuid(*pool.ApplicationPersistenceProfileRef, "applicationpersistenceprofile-.*.#")
+			persistenceName, foundPersistence := c.PersistenceProfileCache.AviCacheGetNameByUuid(persistenceUuid)
+			if foundPersistence {
+				persistenceKey = NamespaceName{Namespace: lib.GetTenant(), Name: persistenceName.(string)}
+			}
+		}
+
 		poolCacheObj := AviPoolCache{
-			Name:                 *pool.Name,
-			Uuid:                 *pool.UUID,
-			CloudConfigCksum:     *pool.CloudConfigCksum,
-			PkiProfileCollection: pkiKey,
-			ServiceMetadataObj:   svc_mdata_obj,
-			LastModified:         *pool.LastModified,
+			Name:                         *pool.Name,
+			Uuid:                         *pool.UUID,
+			CloudConfigCksum:             *pool.CloudConfigCksum,
+			PkiProfileCollection:         pkiKey,
+			SSLKeyCertCollection:         sslKey,
+			HealthMonitorCollection:      c.GetHealthMonitorCollection(pool.HealthMonitorRefs),
+			PersistenceProfileCollection: persistenceKey,
+			ServiceMetadataObj:           svc_mdata_obj,
+			LastModified:                 *pool.LastModified,
 		}
 		*poolData = append(*poolData, poolCacheObj)
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/pool")
-		if len(next_uri) > 1 {
-			override_uri := "/api/pool" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllPools(client, cloud, poolData, nextPage)
-			if err != nil {
-				return nil, 0, err
+
+	return poolData, count, nil
+}
+
+// populateProfilesToCache replaces the objects of a profile cache with the ones fetched from the controller,
+// the objects found invalid in the cache are kept invalid
+func populateProfilesToCache(aviCache *AviCache, objType string, cacheObjs []AviProfileCacheObj) {
+	cacheData := aviCache.ShallowCopy()
+	for _, cacheObj := range cacheObjs {
+		name, uuid, _, invalidData := cacheObj.GetCacheMeta()
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: name}
+		oldIntf, found := aviCache.AviCacheGet(k)
+		if found {
+			oldData, ok := oldIntf.(AviProfileCacheObj)
+			if ok {
+				if _, _, _, oldInvalidData := oldData.GetCacheMeta(); *oldInvalidData {
+					*invalidData = true
+					utils.AviLog.Infof("Invalid cache data for %s: %s", objType, k)
+				}
+			} else {
+				utils.AviLog.Infof("Wrong data type for %s: %s in cache", objType, k)
 			}
 		}
+		utils.AviLog.Infof("Adding key to %s cache :%s value :%s", objType, k, uuid)
+		aviCache.AviCacheAdd(k, cacheObj)
+		delete(cacheData, k)
+	}
+	// The data that is left in cacheData should be explicitly removed
+	for key := range cacheData {
+		utils.AviLog.Infof("Deleting key from %s cache :%s", objType, key)
+		aviCache.AviCacheDelete(key)
 	}
-
-	return poolData, result.Count, nil
 }
 
 func (c *AviObjCache) PopulatePkiProfilesToCache(client *clients.AviClient, override_uri ...NextPage) {
 	var pkiProfData []AviPkiProfileCache
 	c.AviPopulateAllPkiPRofiles(client, &pkiProfData)
 
-	pkiCacheData := c.PKIProfileCache.ShallowCopy()
-	for i, pkiCacheObj := range pkiProfData {
-		k := NamespaceName{Namespace: lib.GetTenant(), Name: pkiCacheObj.Name}
-		oldPkiIntf, found := c.PKIProfileCache.AviCacheGet(k)
-		if found {
-			oldPkiData, ok := oldPkiIntf.(*AviPkiProfileCache)
-			if ok {
-				if oldPkiData.InvalidData {
-					pkiProfData[i].InvalidData = true
-					utils.AviLog.Infof("Invalid cache data for pki: %s", k)
-				}
-			} else {
-				utils.AviLog.Infof("Wrong data type for pki: %s in cache", k)
-			}
+	cacheObjs := make([]AviProfileCacheObj, len(pkiProfData))
+	for i := range pkiProfData {
+		cacheObjs[i] = &pkiProfData[i]
+	}
+	populateProfilesToCache(c.PKIProfileCache, "pki", cacheObjs)
+}
+
+func (c *AviObjCache) PopulateHealthMonitorsToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var hmData []AviHealthMonitorCache
+	c.AviPopulateAllHealthMonitors(client, &hmData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(hmData))
+	for i := range hmData {
+		cacheObjs[i] = &hmData[i]
+	}
+	populateProfilesToCache(c.HealthMonitorCache, "healthmonitor", cacheObjs)
+}
+
+func (c *AviObjCache) PopulateAppProfilesToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var appProfileData []AviAppProfileCache
+	c.AviPopulateAllAppProfiles(client, &appProfileData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(appProfileData))
+	for i := range appProfileData {
+		cacheObjs[i] = &appProfileData[i]
+	}
+	populateProfilesToCache(c.AppProfileCache, "applicationprofile", cacheObjs)
+}
+
+func (c *AviObjCache) PopulateSSLProfilesToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var sslProfileData []AviSSLProfileCache
+	c.AviPopulateAllSSLProfiles(client, &sslProfileData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(sslProfileData))
+	for i := range sslProfileData {
+		cacheObjs[i] = &sslProfileData[i]
+	}
+	populateProfilesToCache(c.SSLProfileCache, "sslprofile", cacheObjs)
+}
+
+func (c *AviObjCache) PopulateSSOPoliciesToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var ssoPolicyData []AviSSOPolicyCache
+	c.AviPopulateAllSSOPolicies(client, &ssoPolicyData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(ssoPolicyData))
+	for i := range ssoPolicyData {
+		cacheObjs[i] = &ssoPolicyData[i]
+	}
+	populateProfilesToCache(c.SSOPolicyCache, "ssopolicy", cacheObjs)
+}
+
+func (c *AviObjCache) PopulateAuthProfilesToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var authProfileData []AviAuthProfileCache
+	c.AviPopulateAllAuthProfiles(client, &authProfileData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(authProfileData))
+	for i := range authProfileData {
+		cacheObjs[i] = &authProfileData[i]
+	}
+	populateProfilesToCache(c.AuthProfileCache, "authprofile", cacheObjs)
+}
+
+func (c *AviObjCache) PopulateJWTProfilesToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var jwtProfileData []AviJWTProfileCache
+	c.AviPopulateAllJWTProfiles(client, &jwtProfileData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(jwtProfileData))
+	for i := range jwtProfileData {
+		cacheObjs[i] = &jwtProfileData[i]
+	}
+	populateProfilesToCache(c.JWTProfileCache, "jwtserverprofile", cacheObjs)
+}
+
+func (c *AviObjCache) PopulatePersistenceProfilesToCache(client *clients.AviClient, override_uri ...NextPage) {
+	var persistenceProfileData []AviPersistenceProfileCache
+	c.AviPopulateAllPersistenceProfiles(client, &persistenceProfileData)
+
+	cacheObjs := make([]AviProfileCacheObj, len(persistenceProfileData))
+	for i := range persistenceProfileData {
+		cacheObjs[i] = &persistenceProfileData[i]
+	}
+	populateProfilesToCache(c.PersistenceProfileCache, "applicationpersistenceprofile", cacheObjs)
+}
+
+// GetSSLProfileCollection returns the key of the sslprofile referred by a virtualservice,
+// if the sslprofile was created by AKO
+func (c *AviObjCache) GetSSLProfileCollection(sslProfileRef interface{}) []NamespaceName {
+	ref, ok := sslProfileRef.(string)
+	if !ok {
+		return nil
+	}
+	sslProfileUuid := ExtractUuid(ref, "sslprofile-.*.#")
+	sslProfileName, found := c.SSLProfileCache.AviCacheGetNameByUuid(sslProfileUuid)
+	if sslProfileUuid == "" || !found {
+		return nil
+	}
+	return []NamespaceName{{Namespace: lib.GetTenant(), Name: sslProfileName.(string)}}
+}
+
+// GetSSOPolicyCollection returns the key of the SSO policy referred by a virtualservice,
+// if the SSO policy was created by AKO
+func (c *AviObjCache) GetSSOPolicyCollection(ssoPolicyRef interface{}) []NamespaceName {
+	ref, ok := ssoPolicyRef.(string)
+	if !ok {
+		return nil
+	}
+	ssoPolicyUuid := ExtractUuid(ref, "ssopolicy-.*.#")
+	ssoPolicyName, found := c.SSOPolicyCache.AviCacheGetNameByUuid(ssoPolicyUuid)
+	if ssoPolicyUuid == "" || !found {
+		return nil
+	}
+	return []NamespaceName{{Namespace: lib.GetTenant(), Name: ssoPolicyName.(string)}}
+}
+
+// GetAppProfileCollection returns the key of the application profile referred by a virtualservice,
+// if the application profile was created by AKO
+func (c *AviObjCache) GetAppProfileCollection(appProfileRef interface{}) []NamespaceName {
+	ref, ok := appProfileRef.(string)
+	if !ok {
+		return nil
+	}
+	appProfileUuid := ExtractUuid(ref, "applicationprofile-.*.#")
+	appProfileName, found := c.AppProfileCache.AviCacheGetNameByUuid(appProfileUuid)
+	if appProfileUuid == "" || !found {
+		return nil
+	}
+	return []NamespaceName{{Namespace: lib.GetTenant(), Name: appProfileName.(string)}}
+}
+
+// GetHealthMonitorCollection returns the keys of the health monitors created by AKO
+// among the health monitor refs of a pool, refs are matched by uuid or by name
+func (c *AviObjCache) GetHealthMonitorCollection(hmRefs []string) []NamespaceName {
+	var hmKeys []NamespaceName
+	for _, hmRef := range hmRefs {
+		hmUuid := ExtractUuid(hmRef, "healthmonitor-.*.#")
+		if hmName, found := c.HealthMonitorCache.AviCacheGetNameByUuid(hmUuid); hmUuid != "" && found {
+			hmKeys = append(hmKeys, NamespaceName{Namespace: lib.GetTenant(), Name: hmName.(string)})
+			continue
+		}
+		var hmName string
+		if refTokens := strings.Split(hmRef, "#"); len(refTokens) > 1 {
+			hmName = refTokens[1]
+		} else if refTokens := strings.Split(hmRef, "?name="); len(refTokens) > 1 {
+			hmName = refTokens[1]
+		}
+		hmKey := NamespaceName{Namespace: lib.GetTenant(), Name: hmName}
+		if _, found := c.HealthMonitorCache.AviCacheGet(hmKey); hmName != "" && found {
+			hmKeys = append(hmKeys, hmKey)
+		}
+	}
+	return hmKeys
+}
+
+// AviHealthMonitorChecksum computes the checksum of a health monitor object,
+// it matches the checksum of the health monitor nodes in the model
+func AviHealthMonitorChecksum(hm *models.HealthMonitor) uint32 {
+	var httpRequest string
+	var httpResponseCodes []string
+	httpMonitor := hm.HTTPMonitor
+	if hm.HTTPSMonitor != nil {
+		httpMonitor = hm.HTTPSMonitor
+	}
+	if httpMonitor != nil {
+		if httpMonitor.HTTPRequest != nil {
+			httpRequest = *httpMonitor.HTTPRequest
 		}
-		utils.AviLog.Infof("Adding key to pki cache :%s value :%s", k, pkiCacheObj.Uuid)
-		c.PKIProfileCache.AviCacheAdd(k, &pkiProfData[i])
-		delete(pkiCacheData, k)
+		httpResponseCodes = httpMonitor.HTTPResponseCode
 	}
-	// The data that is left in pkiCacheData should be explicitly removed
-	for key := range pkiCacheData {
-		utils.AviLog.Infof("Deleting key from pki cache :%s", key)
-		c.PKIProfileCache.AviCacheDelete(key)
+
+	int32Value := func(v *int32) int32 {
+		if v == nil {
+			return 0
+		}
+		return *v
 	}
+	var name, hmType string
+	if hm.Name != nil {
+		name = *hm.Name
+	}
+	if hm.Type != nil {
+		hmType = *hm.Type
+	}
+	return lib.HealthMonitorChecksum(name, hmType, httpRequest, httpResponseCodes,
+		int32Value(hm.MonitorPort), int32Value(hm.SendInterval), int32Value(hm.ReceiveTimeout),
+		int32Value(hm.SuccessfulChecks), int32Value(hm.FailedChecks))
 }
 
 func (c *AviObjCache) PopulatePoolsToCache(client *clients.AviClient, cloud string, override_uri ...NextPage) {
@@ -624,26 +1229,14 @@ func (c *AviObjCache) PopulatePoolsToCache(client *clients.AviClient, cloud stri
 	}
 }
 
-func (c *AviObjCache) AviPopulateAllVSVips(client *clients.AviClient, cloud string, vsVipData *[]AviVSVIPCache, nextPage ...NextPage) (*[]AviVSVIPCache, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllVSVips(client *clients.AviClient, cloud string, vsVipData *[]AviVSVIPCache) (*[]AviVSVIPCache, error) {
+	uri := "/api/vsvip/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&cloud_ref.name=" + cloud + "&page_size=100"
 
-	if len(nextPage) == 1 {
-		uri = nextPage[0].Next_uri
-	} else {
-		uri = "/api/vsvip/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&cloud_ref.name=" + cloud + "&page_size=100"
-	}
-
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, _, err := c.cachePopulator(client).getCollection("vsvip", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for vsvip %v", uri, err)
 		return nil, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		utils.AviLog.Warnf("Failed to unmarshal vsvip data, err: %v", err)
-		return nil, err
-	}
 	for i := 0; i < len(elems); i++ {
 		vsvip := models.VsVip{}
 		err = json.Unmarshal(elems[i], &vsvip)
@@ -662,10 +1255,15 @@ func (c *AviObjCache) AviPopulateAllVSVips(client *clients.AviClient, cloud stri
 		for _, dnsinfo := range vsvip.DNSInfo {
 			fqdns = append(fqdns, *dnsinfo.Fqdn)
 		}
-		var vips []string
+		var vips, v6vips []string
 		var networkName string
 		for _, vip := range vsvip.Vip {
-			vips = append(vips, *vip.IPAddress.Addr)
+			if vip.IPAddress != nil && vip.IPAddress.Addr != nil {
+				vips = append(vips, *vip.IPAddress.Addr)
+			}
+			if vip.Ip6Address != nil && vip.Ip6Address.Addr != nil {
+				v6vips = append(v6vips, *vip.Ip6Address.Addr)
+			}
 			if ipamNetworkSubnet := vip.IPAMNetworkSubnet; ipamNetworkSubnet != nil {
 				if networkRef := *ipamNetworkSubnet.NetworkRef; networkRef != "" {
 					if networRefName := strings.Split(networkRef, "#"); len(networRefName) == 2 {
@@ -686,22 +1284,11 @@ func (c *AviObjCache) AviPopulateAllVSVips(client *clients.AviClient, cloud stri
 			NetworkName:      networkName,
 			LastModified:     *vsvip.LastModified,
 			Vips:             vips,
+			V6Vips:           v6vips,
 			CloudConfigCksum: checksum,
 		}
 		*vsVipData = append(*vsVipData, vsVipCacheObj)
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/vsvip")
-		if len(next_uri) > 1 {
-			override_uri := "/api/vsvip" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, err := c.AviPopulateAllVSVips(client, cloud, vsVipData, nextPage)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
 	return vsVipData, nil
 }
 
@@ -735,27 +1322,16 @@ func (c *AviObjCache) PopulateVsVipDataToCache(client *clients.AviClient, cloud
 	}
 }
 
-func (c *AviObjCache) AviPopulateAllDSs(client *clients.AviClient, cloud string, DsData *[]AviDSCache, nextPage ...NextPage) (*[]AviDSCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllDSs(client *clients.AviClient, cloud string, DsData *[]AviDSCache) (*[]AviDSCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(nextPage) == 1 {
-		uri = nextPage[0].Next_uri
-	} else {
-		uri = "/api/vsdatascriptset/?" + "&include_name=true&created_by=" + akoUser
-	}
+	uri := "/api/vsdatascriptset/?" + "&include_name=true&created_by=" + akoUser
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, count, err := c.cachePopulator(client).getCollection("vsdatascriptset", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for datascript %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		utils.AviLog.Warnf("Failed to unmarshal datascript data, err: %v", err)
-		return nil, 0, err
-	}
 	for i := 0; i < len(elems); i++ {
 		ds := models.VSDataScriptSet{}
 		err = json.Unmarshal(elems[i], &ds)
@@ -784,26 +1360,20 @@ func (c *AviObjCache) AviPopulateAllDSs(client *clients.AviClient, cloud string,
 			Uuid:       *ds.UUID,
 			PoolGroups: pgs,
 		}
-		checksum := lib.DSChecksum(dsCacheObj.PoolGroups)
+		var scripts []string
+		for _, dsScript := range ds.Datascript {
+			if dsScript.Script != nil {
+				scripts = append(scripts, *dsScript.Script)
+			}
+		}
+		checksum := lib.DSChecksum(dsCacheObj.PoolGroups, scripts...)
 		if lib.GetEnableGRBAC() && ds.Labels != nil {
 			checksum += lib.ObjectLabelChecksum(ds.Labels)
 		}
 		dsCacheObj.CloudConfigCksum = checksum
 		*DsData = append(*DsData, dsCacheObj)
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/vsdatascriptset")
-		if len(next_uri) > 1 {
-			override_uri := "/api/vsdatascriptset" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllDSs(client, cloud, DsData, nextPage)
-			if err != nil {
-				return nil, 0, err
-			}
-		}
-	}
-	return DsData, result.Count, nil
+	return DsData, count, nil
 }
 
 func (c *AviObjCache) PopulateDSDataToCache(client *clients.AviClient, cloud string, override_uri ...NextPage) {
@@ -835,27 +1405,16 @@ func (c *AviObjCache) PopulateDSDataToCache(client *clients.AviClient, cloud str
 	}
 }
 
-func (c *AviObjCache) AviPopulateAllSSLKeys(client *clients.AviClient, cloud string, SslData *[]AviSSLCache, nextPage ...NextPage) (*[]AviSSLCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllSSLKeys(client *clients.AviClient, cloud string, SslData *[]AviSSLCache) (*[]AviSSLCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(nextPage) == 1 {
-		uri = nextPage[0].Next_uri
-	} else {
-		uri = "/api/sslkeyandcertificate/?" + "&created_by=" + akoUser + "&page_size=100"
-	}
+	uri := "/api/sslkeyandcertificate/?" + "&created_by=" + akoUser + "&page_size=100"
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, count, err := c.cachePopulator(client).getCollection("sslkeyandcertificate", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for sslkeyandcertificate %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		utils.AviLog.Warnf("Failed to unmarshal sslkeyandcertificate data, err: %v", err)
-		return nil, 0, err
-	}
 	for i := 0; i < len(elems); i++ {
 		sslkey := models.SSLKeyAndCertificate{}
 		err = json.Unmarshal(elems[i], &sslkey)
@@ -896,19 +1455,7 @@ func (c *AviObjCache) AviPopulateAllSSLKeys(client *clients.AviClient, cloud str
 		}
 		*SslData = append(*SslData, sslCacheObj)
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/sslkeyandcertificate")
-		if len(next_uri) > 1 {
-			override_uri := "/api/sslkeyandcertificate" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllSSLKeys(client, cloud, SslData, nextPage)
-			if err != nil {
-				return nil, 0, err
-			}
-		}
-	}
-	return SslData, result.Count, nil
+	return SslData, count, nil
 }
 
 func (c *AviObjCache) AviPopulateOneSSLCache(client *clients.AviClient,
@@ -1022,6 +1569,310 @@ func (c *AviObjCache) AviPopulateOnePKICache(client *clients.AviClient,
 	return nil
 }
 
+func (c *AviObjCache) AviPopulateOneHealthMonitorCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+
+	uri = "/api/healthmonitor?name=" + objName
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for healthmonitor %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		hm := models.HealthMonitor{}
+		err = json.Unmarshal(elems[i], &hm)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal healthmonitor data, err: %v", err)
+			continue
+		}
+		if hm.Name == nil || hm.UUID == nil || hm.Type == nil {
+			utils.AviLog.Warnf("Incomplete healthmonitor data unmarshalled, %s", utils.Stringify(hm))
+			continue
+		}
+		checksum := AviHealthMonitorChecksum(&hm)
+		hmCacheObj := AviHealthMonitorCache{
+			Name:             *hm.Name,
+			Uuid:             *hm.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: checksum,
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *hm.Name}
+		c.HealthMonitorCache.AviCacheAdd(k, &hmCacheObj)
+		utils.AviLog.Debugf("Adding healthmonitor to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneAppProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+	akoUser := lib.AKOUser
+
+	uri = "/api/applicationprofile?name=" + objName + "&created_by=" + akoUser
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationprofile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+		appProfileCacheObj := c.getAppProfileCacheObj(&appProfile)
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *appProfile.Name}
+		c.AppProfileCache.AviCacheAdd(k, &appProfileCacheObj)
+		utils.AviLog.Debugf("Adding applicationprofile to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneSSLProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+
+	uri = "/api/sslprofile?name=" + objName
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for sslprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal sslprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		sslProfile := models.SSLProfile{}
+		err = json.Unmarshal(elems[i], &sslProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal sslprofile data, err: %v", err)
+			continue
+		}
+		if sslProfile.Name == nil || sslProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete sslprofile data unmarshalled, %s", utils.Stringify(sslProfile))
+			continue
+		}
+		//Only cache an sslprofile that belongs to this AKO.
+		if !strings.HasPrefix(*sslProfile.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		sslProfileCacheObj := AviSSLProfileCache{
+			Name:             *sslProfile.Name,
+			Uuid:             *sslProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviSSLProfileChecksum(&sslProfile),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *sslProfile.Name}
+		c.SSLProfileCache.AviCacheAdd(k, &sslProfileCacheObj)
+		utils.AviLog.Debugf("Adding sslprofile to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneSSOPolicyCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+
+	uri = "/api/ssopolicy?name=" + objName + "&include_name=true"
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for ssopolicy %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal ssopolicy data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		ssoPolicy := models.SSOPolicy{}
+		err = json.Unmarshal(elems[i], &ssoPolicy)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal ssopolicy data, err: %v", err)
+			continue
+		}
+		if ssoPolicy.Name == nil || ssoPolicy.UUID == nil {
+			utils.AviLog.Warnf("Incomplete ssopolicy data unmarshalled, %s", utils.Stringify(ssoPolicy))
+			continue
+		}
+		//Only cache a ssopolicy that belongs to this AKO.
+		if !strings.HasPrefix(*ssoPolicy.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		ssoPolicyCacheObj := AviSSOPolicyCache{
+			Name:             *ssoPolicy.Name,
+			Uuid:             *ssoPolicy.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviSSOPolicyChecksum(&ssoPolicy),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *ssoPolicy.Name}
+		c.SSOPolicyCache.AviCacheAdd(k, &ssoPolicyCacheObj)
+		utils.AviLog.Debugf("Adding ssopolicy to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneAuthProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+
+	uri = "/api/authprofile?name=" + objName + "&include_name=true"
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for authprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal authprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		authProfile := models.AuthProfile{}
+		err = json.Unmarshal(elems[i], &authProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal authprofile data, err: %v", err)
+			continue
+		}
+		if authProfile.Name == nil || authProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete authprofile data unmarshalled, %s", utils.Stringify(authProfile))
+			continue
+		}
+		//Only cache a authprofile that belongs to this AKO.
+		if !strings.HasPrefix(*authProfile.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		authProfileCacheObj := AviAuthProfileCache{
+			Name:             *authProfile.Name,
+			Uuid:             *authProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviAuthProfileChecksum(&authProfile),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *authProfile.Name}
+		c.AuthProfileCache.AviCacheAdd(k, &authProfileCacheObj)
+		utils.AviLog.Debugf("Adding authprofile to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneJWTProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+
+	uri = "/api/jwtserverprofile?name=" + objName
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for jwtserverprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal jwtserverprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		jwtProfile := models.JWTServerProfile{}
+		err = json.Unmarshal(elems[i], &jwtProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal jwtserverprofile data, err: %v", err)
+			continue
+		}
+		if jwtProfile.Name == nil || jwtProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete jwtserverprofile data unmarshalled, %s", utils.Stringify(jwtProfile))
+			continue
+		}
+		//Only cache a jwtserverprofile that belongs to this AKO.
+		if !strings.HasPrefix(*jwtProfile.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		jwtProfileCacheObj := AviJWTProfileCache{
+			Name:             *jwtProfile.Name,
+			Uuid:             *jwtProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviJWTProfileChecksum(&jwtProfile),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *jwtProfile.Name}
+		c.JWTProfileCache.AviCacheAdd(k, &jwtProfileCacheObj)
+		utils.AviLog.Debugf("Adding jwtserverprofile to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOnePersistenceProfileCache(client *clients.AviClient,
+	cloud string, objName string) error {
+	var uri string
+
+	uri = "/api/applicationpersistenceprofile?name=" + objName
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationpersistenceprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		persistenceProfile := models.ApplicationPersistenceProfile{}
+		err = json.Unmarshal(elems[i], &persistenceProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationpersistenceprofile data, err: %v", err)
+			continue
+		}
+		if persistenceProfile.Name == nil || persistenceProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationpersistenceprofile data unmarshalled, %s", utils.Stringify(persistenceProfile))
+			continue
+		}
+		//Only cache an applicationpersistenceprofile that belongs to this AKO.
+		if !strings.HasPrefix(*persistenceProfile.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		persistenceProfileCacheObj := AviPersistenceProfileCache{
+			Name:             *persistenceProfile.Name,
+			Uuid:             *persistenceProfile.UUID,
+			Tenant:           lib.GetTenant(),
+			CloudConfigCksum: AviPersistenceProfileChecksum(&persistenceProfile),
+		}
+		k := NamespaceName{Namespace: lib.GetTenant(), Name: *persistenceProfile.Name}
+		c.PersistenceProfileCache.AviCacheAdd(k, &persistenceProfileCacheObj)
+		utils.AviLog.Debugf("Adding applicationpersistenceprofile to Cache during refresh %s\n", k)
+	}
+	return nil
+}
+
 func (c *AviObjCache) AviPopulateOnePoolCache(client *clients.AviClient,
 	cloud string, objName string) error {
 	var uri string
@@ -1072,13 +1923,34 @@ func (c *AviObjCache) AviPopulateOnePoolCache(client *clients.AviClient,
 			}
 		}
 
+		var sslKey NamespaceName
+		if pool.SslKeyAndCertificateRef != nil {
+			sslUuid := ExtractUuid(*pool.SslKeyAndCertificateRef, "sslkeyandcertificate-.*.#")
+			sslName, foundSSL := c.SSLKeyCache.AviCacheGetNameByUuid(sslUuid)
+			if foundSSL {
+				sslKey = NamespaceName{Namespace: lib.GetTenant(), Name: sslName.(string)}
+			}
+		}
+
+		var persistenceKey NamespaceName
+		if pool.ApplicationPersistenceProfileRef != nil {
+			persistenceUuid := ExtractUuid(*pool.ApplicationPersistenceProfileRef, "applicationpersistenceprofile-.*.#")
+			persistenceName, foundPersistence := c.PersistenceProfileCache.AviCacheGetNameByUuid(persistenceUuid)
+			if foundPersistence {
+				persistenceKey = NamespaceName{Namespace: lib.GetTenant(), Name: persistenceName.(string)}
+			}
+		}
+
 		poolCacheObj := AviPoolCache{
-			Name:                 *pool.Name,
-			Uuid:                 *pool.UUID,
-			CloudConfigCksum:     *pool.CloudConfigCksum,
-			PkiProfileCollection: pkiKey,
-			ServiceMetadataObj:   svc_mdata_obj,
-			LastModified:         *pool.LastModified,
+			Name:                         *pool.Name,
+			Uuid:                         *pool.UUID,
+			CloudConfigCksum:             *pool.CloudConfigCksum,
+			PkiProfileCollection:         pkiKey,
+			SSLKeyCertCollection:         sslKey,
+			HealthMonitorCollection:      c.GetHealthMonitorCollection(pool.HealthMonitorRefs),
+			PersistenceProfileCollection: persistenceKey,
+			ServiceMetadataObj:           svc_mdata_obj,
+			LastModified:                 *pool.LastModified,
 		}
 		k := NamespaceName{Namespace: lib.GetTenant(), Name: *pool.Name}
 		c.PoolCache.AviCacheAdd(k, &poolCacheObj)
@@ -1136,7 +2008,13 @@ func (c *AviObjCache) AviPopulateOneVsDSCache(client *clients.AviClient,
 			Uuid:       *ds.UUID,
 			PoolGroups: pgs,
 		}
-		checksum := lib.DSChecksum(dsCacheObj.PoolGroups)
+		var scripts []string
+		for _, dsScript := range ds.Datascript {
+			if dsScript.Script != nil {
+				scripts = append(scripts, *dsScript.Script)
+			}
+		}
+		checksum := lib.DSChecksum(dsCacheObj.PoolGroups, scripts...)
 		if lib.GetEnableGRBAC() && ds.Labels != nil {
 			checksum += lib.ObjectLabelChecksum(ds.Labels)
 		}
@@ -1245,10 +2123,15 @@ func (c *AviObjCache) AviPopulateOneVsVipCache(client *clients.AviClient,
 			fqdns = append(fqdns, *dnsinfo.Fqdn)
 		}
 
-		var vips []string
+		var vips, v6vips []string
 		var networkName string
 		for _, vip := range vsvip.Vip {
-			vips = append(vips, *vip.IPAddress.Addr)
+			if vip.IPAddress != nil && vip.IPAddress.Addr != nil {
+				vips = append(vips, *vip.IPAddress.Addr)
+			}
+			if vip.Ip6Address != nil && vip.Ip6Address.Addr != nil {
+				v6vips = append(v6vips, *vip.Ip6Address.Addr)
+			}
 			if ipamNetworkSubnet := vip.IPAMNetworkSubnet; ipamNetworkSubnet != nil {
 				if networkRef := *ipamNetworkSubnet.NetworkRef; networkRef != "" {
 					if networRefName := strings.Split(networkRef, "#"); len(networRefName) == 2 {
@@ -1267,6 +2150,7 @@ func (c *AviObjCache) AviPopulateOneVsVipCache(client *clients.AviClient,
 			FQDNs:            fqdns,
 			LastModified:     *vsvip.LastModified,
 			Vips:             vips,
+			V6Vips:           v6vips,
 			NetworkName:      networkName,
 			CloudConfigCksum: checksum,
 		}
@@ -1436,27 +2320,16 @@ func (c *AviObjCache) PopulateSSLKeyToCache(client *clients.AviClient, cloud str
 	}
 }
 
-func (c *AviObjCache) AviPopulateAllHttpPolicySets(client *clients.AviClient, cloud string, httpPolicyData *[]AviHTTPPolicyCache, nextPage ...NextPage) (*[]AviHTTPPolicyCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllHttpPolicySets(client *clients.AviClient, cloud string, httpPolicyData *[]AviHTTPPolicyCache) (*[]AviHTTPPolicyCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(nextPage) == 1 {
-		uri = nextPage[0].Next_uri
-	} else {
-		uri = "/api/httppolicyset/?" + "&include_name=true" + "&created_by=" + akoUser + "&page_size=100"
-	}
+	uri := "/api/httppolicyset/?" + "&include_name=true" + "&created_by=" + akoUser + "&page_size=100"
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, count, err := c.cachePopulator(client).getCollection("httppolicyset", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for httppolicyset %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		utils.AviLog.Warnf("Failed to unmarshal httppolicyset data, err: %v", err)
-		return nil, 0, err
-	}
 	for i := 0; i < len(elems); i++ {
 		httppol := models.HTTPPolicySet{}
 		err = json.Unmarshal(elems[i], &httppol)
@@ -1492,19 +2365,7 @@ func (c *AviObjCache) AviPopulateAllHttpPolicySets(client *clients.AviClient, cl
 		*httpPolicyData = append(*httpPolicyData, httpPolCacheObj)
 
 	}
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/httppolicyset")
-		if len(next_uri) > 1 {
-			override_uri := "/api/httppolicyset" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllHttpPolicySets(client, cloud, httpPolicyData, nextPage)
-			if err != nil {
-				return nil, 0, err
-			}
-		}
-	}
-	return httpPolicyData, result.Count, nil
+	return httpPolicyData, count, nil
 }
 
 func (c *AviObjCache) PopulateHttpPolicySetToCache(client *clients.AviClient, cloud string, override_uri ...NextPage) {
@@ -1539,27 +2400,16 @@ func (c *AviObjCache) PopulateHttpPolicySetToCache(client *clients.AviClient, cl
 	}
 }
 
-func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, cloud string, l4PolicyData *[]AviL4PolicyCache, nextPage ...NextPage) (*[]AviL4PolicyCache, int, error) {
-	var uri string
+func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, cloud string, l4PolicyData *[]AviL4PolicyCache) (*[]AviL4PolicyCache, int, error) {
 	akoUser := lib.AKOUser
 
-	if len(nextPage) == 1 {
-		uri = nextPage[0].Next_uri
-	} else {
-		uri = "/api/l4policyset/?" + "&include_name=true" + "&created_by=" + akoUser + "&page_size=100"
-	}
+	uri := "/api/l4policyset/?" + "&include_name=true" + "&created_by=" + akoUser + "&page_size=100"
 
-	result, err := lib.AviGetCollectionRaw(client, uri)
+	elems, count, err := c.cachePopulator(client).getCollection("l4policyset", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Get uri %v returned err for httppolicyset %v", uri, err)
 		return nil, 0, err
 	}
-	elems := make([]json.RawMessage, result.Count)
-	err = json.Unmarshal(result.Results, &elems)
-	if err != nil {
-		utils.AviLog.Warnf("Failed to unmarshal httppolicyset data, err: %v", err)
-		return nil, 0, err
-	}
 	for i := 0; i < len(elems); i++ {
 		l4pol := models.L4PolicySet{}
 		err = json.Unmarshal(elems[i], &l4pol)
@@ -1612,19 +2462,7 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 		*l4PolicyData = append(*l4PolicyData, l4PolCacheObj)
 	}
 
-	if result.Next != "" {
-		// It has a next page, let's recursively call the same method.
-		next_uri := strings.Split(result.Next, "/api/l4policyset")
-		if len(next_uri) > 1 {
-			override_uri := "/api/l4policyset" + next_uri[1]
-			nextPage := NextPage{Next_uri: override_uri}
-			_, _, err := c.AviPopulateAllL4PolicySets(client, cloud, l4PolicyData, nextPage)
-			if err != nil {
-				return nil, 0, err
-			}
-		}
-	}
-	return l4PolicyData, result.Count, nil
+	return l4PolicyData, count, nil
 }
 
 func (c *AviObjCache) PopulateL4PolicySetToCache(client *clients.AviClient, cloud string, override_uri ...NextPage) {
@@ -1693,33 +2531,22 @@ func (c *AviObjCache) AviObjVrfCachePopulate(client *clients.AviClient, cloud st
 	return nil
 }
 
-func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud string, vsCacheCopy *[]NamespaceName, override_uri ...NextPage) error {
-	var rest_response interface{}
+func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud string, vsCacheCopy *[]NamespaceName) error {
 	akoUser := lib.AKOUser
-	var uri string
-	httpCacheRefreshCount := 1 // Refresh count for http cache is attempted once per page
-	if len(override_uri) == 1 {
-		uri = override_uri[0].Next_uri
-	} else {
-		uri = "/api/virtualservice/?" + "include_name=true" + "&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100"
-	}
+	uri := "/api/virtualservice/?" + "include_name=true" + "&cloud_ref.name=" + cloud + "&created_by=" + akoUser + "&page_size=100"
 
-	err := lib.AviGet(client, uri, &rest_response)
+	pages, count, err := c.cachePopulator(client).getCollectionPages("virtualservice", uri)
 	if err != nil {
 		utils.AviLog.Warnf("Vs Get uri %v returned err %v", uri, err)
 		return err
-	} else {
-		resp, ok := rest_response.(map[string]interface{})
-		if !ok {
-			utils.AviLog.Warnf("Vs Get uri %v returned %v type %T", uri,
-				rest_response, rest_response)
-			return errors.New("VS type is wrong")
-		}
-		utils.AviLog.Debugf("Vs Get uri %v returned %v vses", uri,
-			resp["count"])
-		results, ok := resp["results"].([]interface{})
-		if !ok {
-			utils.AviLog.Warnf("results not of type []interface{} Instead of type %T", resp["results"])
+	}
+	utils.AviLog.Debugf("Vs Get uri %v returned %v vses", uri, count)
+	// the pages are processed in order, so that the parent VSes are cached before their SNI children
+	for _, page := range pages {
+		httpCacheRefreshCount := 1 // Refresh count for http cache is attempted once per page
+		var results []interface{}
+		if err := json.Unmarshal(page, &results); err != nil {
+			utils.AviLog.Warnf("results not of type []interface{}: %v", err)
 			return errors.New("Results are not of right type for VS")
 		}
 		for _, vs_intf := range results {
@@ -1760,7 +2587,7 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 			if vs["cloud_config_cksum"] != nil {
 				k := NamespaceName{Namespace: lib.GetTenant(), Name: vs["name"].(string)}
 				*vsCacheCopy = Remove(*vsCacheCopy, k)
-				var vip string
+				var vip, v6vip string
 				var vsVipKey []NamespaceName
 				var sslKeys []NamespaceName
 				var dsKeys []NamespaceName
@@ -1786,6 +2613,9 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 								if len(vsVipData.Vips) > 0 {
 									vip = vsVipData.Vips[0]
 								}
+								if len(vsVipData.V6Vips) > 0 {
+									v6vip = vsVipData.V6Vips[0]
+								}
 							}
 						}
 					}
@@ -1919,11 +2749,15 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 					PGKeyCollection:      poolgroupKeys,
 					PoolKeyCollection:    poolKeys,
 					Vip:                  vip,
+					V6Vip:                v6vip,
 					CloudConfigCksum:     vs["cloud_config_cksum"].(string),
 					SNIChildCollection:   sni_child_collection,
 					ParentVSRef:          parentVSKey,
 					ServiceMetadataObj:   svc_mdata_obj,
 					L4PolicyCollection:   l4Keys,
+					AppProfileCollection: c.GetAppProfileCollection(vs["application_profile_ref"]),
+					SSLProfileCollection: c.GetSSLProfileCollection(vs["ssl_profile_ref"]),
+					SSOPolicyCollection:  c.GetSSOPolicyCollection(vs["sso_policy_ref"]),
 					LastModified:         vs["_last_modified"].(string),
 				}
 				c.VsCacheLocal.AviCacheAdd(k, &vsMetaObj)
@@ -1931,17 +2765,6 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 
 			}
 		}
-		if resp["next"] != nil {
-			// It has a next page, let's recursively call the same method.
-			next_uri := strings.Split(resp["next"].(string), "/api/virtualservice")
-			utils.AviLog.Debugf("Found next page for vs, uri: %s", next_uri)
-			if len(next_uri) > 1 {
-				override_uri := "/api/virtualservice" + next_uri[1]
-				utils.AviLog.Debugf("Next page uri for vs: %s", override_uri)
-				nextPage := NextPage{Next_uri: override_uri}
-				c.AviObjVSCachePopulate(client, cloud, vsCacheCopy, nextPage)
-			}
-		}
 	}
 	return nil
 }
@@ -2012,7 +2835,7 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 
 			}
 			if vs["cloud_config_cksum"] != nil {
-				var vip string
+				var vip, v6vip string
 				var vsVipKey []NamespaceName
 				var sslKeys []NamespaceName
 				var dsKeys []NamespaceName
@@ -2035,6 +2858,9 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 							if len(vsVipData.Vips) > 0 {
 								vip = vsVipData.Vips[0]
 							}
+							if len(vsVipData.V6Vips) > 0 {
+								v6vip = vsVipData.V6Vips[0]
+							}
 						}
 					}
 				}
@@ -2155,12 +2981,17 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 					PGKeyCollection:      poolgroupKeys,
 					PoolKeyCollection:    poolKeys,
 					Vip:                  vip,
+					V6Vip:                v6vip,
 					CloudConfigCksum:     vs["cloud_config_cksum"].(string),
 					SNIChildCollection:   sni_child_collection,
 					ParentVSRef:          parentVSKey,
 					L4PolicyCollection:   l4Keys,
+					AppProfileCollection: c.GetAppProfileCollection(vs["application_profile_ref"]),
+					SSLProfileCollection: c.GetSSLProfileCollection(vs["ssl_profile_ref"]),
+					SSOPolicyCollection:  c.GetSSOPolicyCollection(vs["sso_policy_ref"]),
 					ServiceMetadataObj:   svc_mdata_obj,
 				}
+				vsMetaObj.LastModified, _ = vs["_last_modified"].(string)
 				c.VsCacheMeta.AviCacheAdd(k, &vsMetaObj)
 				vs_cache, found := c.VsCacheMeta.AviCacheGet(parentVSKey)
 				if found {
@@ -27,6 +27,8 @@ import (
 type AkoV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AviInfraSettingsGetter
+	BackendGrantsGetter
+	AuthRulesGetter
 	HTTPRulesGetter
 	HostRulesGetter
 }
@@ -40,6 +42,14 @@ func (c *AkoV1alpha1Client) AviInfraSettings() AviInfraSettingInterface {
 	return newAviInfraSettings(c)
 }
 
+func (c *AkoV1alpha1Client) BackendGrants(namespace string) BackendGrantInterface {
+	return newBackendGrants(c, namespace)
+}
+
+func (c *AkoV1alpha1Client) AuthRules(namespace string) AuthRuleInterface {
+	return newAuthRules(c, namespace)
+}
+
 func (c *AkoV1alpha1Client) HTTPRules(namespace string) HTTPRuleInterface {
 	return newHTTPRules(c, namespace)
 }
@@ -32,6 +32,14 @@ func (c *FakeAkoV1alpha1) AviInfraSettings() v1alpha1.AviInfraSettingInterface {
 	return &FakeAviInfraSettings{c}
 }
 
+func (c *FakeAkoV1alpha1) BackendGrants(namespace string) v1alpha1.BackendGrantInterface {
+	return &FakeBackendGrants{c, namespace}
+}
+
+func (c *FakeAkoV1alpha1) AuthRules(namespace string) v1alpha1.AuthRuleInterface {
+	return &FakeAuthRules{c, namespace}
+}
+
 func (c *FakeAkoV1alpha1) HTTPRules(namespace string) v1alpha1.HTTPRuleInterface {
 	return &FakeHTTPRules{c, namespace}
 }
@@ -20,6 +20,10 @@ package v1alpha1
 
 type AviInfraSettingExpansion interface{}
 
+type BackendGrantExpansion interface{}
+
+type AuthRuleExpansion interface{}
+
 type HTTPRuleExpansion interface{}
 
 type HostRuleExpansion interface{}
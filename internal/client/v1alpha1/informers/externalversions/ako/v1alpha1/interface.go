@@ -26,6 +26,10 @@ import (
 type Interface interface {
 	// AviInfraSettings returns a AviInfraSettingInformer.
 	AviInfraSettings() AviInfraSettingInformer
+	// BackendGrants returns a BackendGrantInformer.
+	BackendGrants() BackendGrantInformer
+	// AuthRules returns a AuthRuleInformer.
+	AuthRules() AuthRuleInformer
 	// HTTPRules returns a HTTPRuleInformer.
 	HTTPRules() HTTPRuleInformer
 	// HostRules returns a HostRuleInformer.
@@ -48,6 +52,16 @@ func (v *version) AviInfraSettings() AviInfraSettingInformer {
 	return &aviInfraSettingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// BackendGrants returns a BackendGrantInformer.
+func (v *version) BackendGrants() BackendGrantInformer {
+	return &backendGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// AuthRules returns a AuthRuleInformer.
+func (v *version) AuthRules() AuthRuleInformer {
+	return &authRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // HTTPRules returns a HTTPRuleInformer.
 func (v *version) HTTPRules() HTTPRuleInformer {
 	return &hTTPRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
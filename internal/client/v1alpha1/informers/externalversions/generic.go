@@ -55,6 +55,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=ako.vmware.com, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("aviinfrasettings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha1().AviInfraSettings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("backendgrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha1().BackendGrants().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("authrules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha1().AuthRules().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("httprules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ako().V1alpha1().HTTPRules().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("hostrules"):
@@ -22,6 +22,14 @@ package v1alpha1
 // AviInfraSettingLister.
 type AviInfraSettingListerExpansion interface{}
 
+// BackendGrantListerExpansion allows custom methods to be added to
+// BackendGrantLister.
+type BackendGrantListerExpansion interface{}
+
+// BackendGrantNamespaceListerExpansion allows custom methods to be added to
+// BackendGrantNamespaceLister.
+type BackendGrantNamespaceListerExpansion interface{}
+
 // HTTPRuleListerExpansion allows custom methods to be added to
 // HTTPRuleLister.
 type HTTPRuleListerExpansion interface{}
@@ -37,3 +45,11 @@ type HostRuleListerExpansion interface{}
 // HostRuleNamespaceListerExpansion allows custom methods to be added to
 // HostRuleNamespaceLister.
 type HostRuleNamespaceListerExpansion interface{}
+
+// AuthRuleListerExpansion allows custom methods to be added to
+// AuthRuleLister.
+type AuthRuleListerExpansion interface{}
+
+// AuthRuleNamespaceListerExpansion allows custom methods to be added to
+// AuthRuleNamespaceLister.
+type AuthRuleNamespaceListerExpansion interface{}
@@ -34,6 +34,7 @@ import (
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
+	"github.com/avinetworks/sdk/go/clients"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -50,12 +51,14 @@ func PopulateCache() error {
 	avi_obj_cache := avicache.SharedAviObjCache()
 	// Randomly pickup a client.
 	if avi_rest_client_pool != nil && len(avi_rest_client_pool.AviClient) > 0 {
-		_, _, err := avi_obj_cache.AviObjCachePopulate(avi_rest_client_pool.AviClient[0], utils.CtrlVersion, utils.CloudName)
-		if err != nil {
-			utils.AviLog.Warnf("failed to populate avi cache with error: %v", err.Error())
-			return err
+		if !restoreCacheSnapshot(avi_rest_client_pool.AviClient[0]) {
+			_, _, err := avi_obj_cache.AviObjCachePopulate(avi_rest_client_pool.AviClient[0], utils.CtrlVersion, utils.CloudName)
+			if err != nil {
+				utils.AviLog.Warnf("failed to populate avi cache with error: %v", err.Error())
+				return err
+			}
 		}
-		if err = avicache.SetControllerClusterUUID(avi_rest_client_pool); err != nil {
+		if err := avicache.SetControllerClusterUUID(avi_rest_client_pool); err != nil {
 			utils.AviLog.Warnf("Failed to set the controller cluster uuid with error: %v", err)
 		}
 		// once the l3 cache is populated, we can call the updatestatus functions from here
@@ -79,6 +82,45 @@ func PopulateCache() error {
 	return nil
 }
 
+// restoreCacheSnapshot populates the cache from its persisted snapshot, false if there is none or if
+// it does not match the objects on the controller, the cache then has to be populated in full.
+func restoreCacheSnapshot(client *clients.AviClient) bool {
+	if lib.GetCacheSnapshotStore() == "" {
+		return false
+	}
+	start := time.Now()
+	snapshot, err := avicache.LoadCacheSnapshot()
+	if err != nil {
+		utils.AviLog.Warnf("Unable to load the cache snapshot: %v", err)
+		return false
+	}
+	if snapshot == nil {
+		utils.AviLog.Infof("No cache snapshot found, populating the cache from the controller")
+		return false
+	}
+	err = avicache.SharedAviObjCache().AviObjCachePopulateFromSnapshot(client, utils.CtrlVersion, utils.CloudName, snapshot)
+	if err != nil {
+		utils.AviLog.Warnf("Cache snapshot of %s is not used, populating the cache from the controller: %v", snapshot.Created.UTC(), err)
+		return false
+	}
+	// the models which did not change while AKO was down are not pushed again
+	objects.SharedFullSyncLister().RestoreModelChecksums(snapshot.ModelChecksums)
+	utils.AviLog.Infof("Cache restored from its snapshot in %v", time.Since(start))
+	return true
+}
+
+// saveCacheSnapshot persists the cache with the checksums of the models last pushed to the controller.
+func saveCacheSnapshot() {
+	if lib.GetCacheSnapshotStore() == "" {
+		return
+	}
+	snapshot := avicache.SharedAviObjCache().Snapshot(utils.CloudName)
+	snapshot.ModelChecksums = objects.SharedFullSyncLister().GetModelChecksums()
+	if err := avicache.SaveCacheSnapshot(snapshot); err != nil {
+		utils.AviLog.Warnf("Unable to save the cache snapshot: %v", err)
+	}
+}
+
 func PopulateNodeCache(cs *kubernetes.Clientset) {
 	nodeCache := objects.SharedNodeLister()
 	nodeCache.PopulateAllNodes(cs)
@@ -299,6 +341,9 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(lib.SLOW_RETRY_LAYER)
 	slowRetryQueue.SyncFunc = SyncFromSlowRetryLayer
 	slowRetryQueue.Run(stopCh, slowretrywg)
+
+	// the expiry Events are raised again for the certificates which are not processed for a while
+	go nodes.RunCertExpiryScan(stopCh)
 LABEL:
 	for {
 		select {
@@ -319,6 +364,7 @@ LABEL:
 }
 
 func (c *AviController) FullSync() {
+	defer lib.ObserveFullSyncDuration(lib.FullSyncPhaseCache, time.Now())
 	avi_rest_client_pool := avicache.SharedAVIClients()
 	avi_obj_cache := avicache.SharedAviObjCache()
 	// Randomly pickup a client.
@@ -326,6 +372,9 @@ func (c *AviController) FullSync() {
 		avi_obj_cache.AviClusterStatusPopulate(avi_rest_client_pool.AviClient[0])
 		if !lib.GetAdvancedL4() {
 			avi_obj_cache.AviCacheRefresh(avi_rest_client_pool.AviClient[0], utils.CloudName)
+			if lib.IsIncrementalFullSync() {
+				publishDriftedModels(avi_rest_client_pool.AviClient[0])
+			}
 		} else {
 			// In this case we just sync the Gateway status to the LB status
 			restlayer := rest.NewRestOperations(avi_obj_cache, avi_rest_client_pool)
@@ -349,6 +398,7 @@ func (c *AviController) FullSync() {
 			// Not publishing the model anymore to layer since we don't want to support full sync for now.
 			//nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
 		}
+		saveCacheSnapshot()
 	}
 }
 
@@ -357,6 +407,8 @@ func (c *AviController) FullSyncK8s() error {
 		utils.AviLog.Infof("Sync disabled, skipping full sync")
 		return nil
 	}
+	defer lib.ObserveFullSyncDuration(lib.FullSyncPhaseK8s, time.Now())
+	objects.SharedFullSyncLister().BeginSync()
 	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
 	var vrfModelName string
 	if lib.GetDisableStaticRoute() && !lib.IsNodePortMode() {
@@ -366,7 +418,7 @@ func (c *AviController) FullSyncK8s() error {
 		nodeObjects, _ := utils.GetInformers().NodeInformer.Lister().List(labels.Set(nil).AsSelector())
 		for _, node := range nodeObjects {
 			key := utils.NodeObj + "/" + node.Name
-			nodes.DequeueIngestion(key, true)
+			dequeueForFullSync(key, node.ResourceVersion)
 		}
 		// Publish vrfcontext model now, this has to be processed first
 		vrfModelName = lib.GetModelName(lib.GetTenant(), lib.GetVrf())
@@ -401,11 +453,11 @@ func (c *AviController) FullSyncK8s() error {
 				}
 				key = utils.Service + "/" + utils.ObjKey(svcObj)
 			}
-			nodes.DequeueIngestion(key, true)
+			dequeueForFullSync(key, svcObj.ResourceVersion)
 		}
 	}
 
-	if lib.GetServiceType() == lib.NodePortLocal {
+	if lib.GetServiceType() == lib.NodePortLocal || lib.IsPodReadinessGateEnabled() {
 		podObjs, err := utils.GetInformers().PodInformer.Lister().Pods(metav1.NamespaceAll).List(labels.Everything())
 		if err != nil {
 			utils.AviLog.Errorf("Unable to retrieve the Pods during full sync: %s", err)
@@ -413,7 +465,7 @@ func (c *AviController) FullSyncK8s() error {
 		}
 		for _, podObj := range podObjs {
 			key := utils.Pod + "/" + utils.ObjKey(podObj)
-			nodes.DequeueIngestion(key, true)
+			dequeueForFullSync(key, podObj.ResourceVersion)
 		}
 	}
 
@@ -424,7 +476,7 @@ func (c *AviController) FullSyncK8s() error {
 		} else {
 			for _, hostRuleObj := range hostRuleObjs {
 				key := lib.HostRule + "/" + utils.ObjKey(hostRuleObj)
-				nodes.DequeueIngestion(key, true)
+				dequeueForFullSync(key, hostRuleObj.ResourceVersion)
 			}
 		}
 
@@ -434,7 +486,17 @@ func (c *AviController) FullSyncK8s() error {
 		} else {
 			for _, httpRuleObj := range httpRuleObjs {
 				key := lib.HTTPRule + "/" + utils.ObjKey(httpRuleObj)
-				nodes.DequeueIngestion(key, true)
+				dequeueForFullSync(key, httpRuleObj.ResourceVersion)
+			}
+		}
+
+		authRuleObjs, err := lib.GetCRDInformers().AuthRuleInformer.Lister().AuthRules("").List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the authrules during full sync: %s", err)
+		} else {
+			for _, authRuleObj := range authRuleObjs {
+				key := lib.AuthRule + "/" + utils.ObjKey(authRuleObj)
+				dequeueForFullSync(key, authRuleObj.ResourceVersion)
 			}
 		}
 
@@ -444,7 +506,7 @@ func (c *AviController) FullSyncK8s() error {
 		} else {
 			for _, albInfraObj := range albInfraObjs {
 				key := lib.AviInfraSetting + "/" + utils.ObjKey(albInfraObj)
-				nodes.DequeueIngestion(key, true)
+				dequeueForFullSync(key, albInfraObj.ResourceVersion)
 			}
 		}
 
@@ -460,7 +522,7 @@ func (c *AviController) FullSyncK8s() error {
 					if utils.CheckIfNamespaceAccepted(ns[0], utils.GetGlobalNSFilter(), nil, true) {
 						key := utils.Ingress + "/" + ingLabel
 						utils.AviLog.Debugf("Dequeue for ingress key: %v", key)
-						nodes.DequeueIngestion(key, true)
+						dequeueForFullSync(key, ingObj.ResourceVersion)
 					}
 
 				}
@@ -479,7 +541,7 @@ func (c *AviController) FullSyncK8s() error {
 					if utils.CheckIfNamespaceAccepted(ns[0], utils.GetGlobalNSFilter(), nil, true) {
 						key := utils.OshiftRoute + "/" + routeLabel
 						utils.AviLog.Debugf("Dequeue for route key: %v", key)
-						nodes.DequeueIngestion(key, true)
+						dequeueForFullSync(key, routeObj.ResourceVersion)
 					}
 				}
 			}
@@ -493,7 +555,7 @@ func (c *AviController) FullSyncK8s() error {
 				for _, gatewayObj := range gatewayObjs {
 					key := lib.Gateway + "/" + utils.ObjKey(gatewayObj)
 					InformerStatusUpdatesForSvcApiGateway(key, gatewayObj)
-					nodes.DequeueIngestion(key, true)
+					dequeueForFullSync(key, gatewayObj.ResourceVersion)
 				}
 			}
 
@@ -504,7 +566,7 @@ func (c *AviController) FullSyncK8s() error {
 			} else {
 				for _, gwClassObj := range gwClassObjs {
 					key := lib.GatewayClass + "/" + utils.ObjKey(gwClassObj)
-					nodes.DequeueIngestion(key, true)
+					dequeueForFullSync(key, gwClassObj.ResourceVersion)
 				}
 			}
 		}
@@ -519,7 +581,7 @@ func (c *AviController) FullSyncK8s() error {
 			for _, gatewayObj := range gatewayObjs {
 				key := lib.Gateway + "/" + utils.ObjKey(gatewayObj)
 				InformerStatusUpdatesForGateway(key, gatewayObj)
-				nodes.DequeueIngestion(key, true)
+				dequeueForFullSync(key, gatewayObj.ResourceVersion)
 			}
 		}
 
@@ -530,11 +592,19 @@ func (c *AviController) FullSyncK8s() error {
 		} else {
 			for _, gwClassObj := range gwClassObjs {
 				key := lib.GatewayClass + "/" + utils.ObjKey(gwClassObj)
-				nodes.DequeueIngestion(key, true)
+				dequeueForFullSync(key, gwClassObj.ResourceVersion)
 			}
 		}
 	}
 
+	// the objects which were removed without AKO noticing are processed as deleted
+	if lib.IsIncrementalFullSync() {
+		for _, key := range objects.SharedFullSyncLister().DeletedObjects() {
+			utils.AviLog.Infof("Dequeue for deleted object key: %v", key)
+			nodes.DequeueIngestion(key, true)
+		}
+	}
+
 	cache := avicache.SharedAviObjCache()
 	vsKeys := cache.VsCacheMeta.AviCacheGetAllParentVSKeys()
 	utils.AviLog.Debugf("Got the VS keys: %s", vsKeys)
@@ -558,7 +628,7 @@ func (c *AviController) FullSyncK8s() error {
 							allModels = utils.Remove(allModels, modelName)
 						}
 						utils.AviLog.Infof("Model published L7 VS during namespace based sync: %s", modelName)
-						nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+						publishModelForFullSync(modelName, sharedQueue)
 					}
 				}
 				// For namespace based syncs, the L4 VSes would be named: clusterName + "--" + namespace
@@ -568,7 +638,7 @@ func (c *AviController) FullSyncK8s() error {
 						allModels = utils.Remove(allModels, modelName)
 					}
 					utils.AviLog.Infof("Model published L4 VS during namespace based sync: %s", modelName)
-					nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+					publishModelForFullSync(modelName, sharedQueue)
 				}
 			} else {
 				modelName := vsCacheKey.Namespace + "/" + vsCacheKey.Name
@@ -576,7 +646,7 @@ func (c *AviController) FullSyncK8s() error {
 					allModels = utils.Remove(allModels, modelName)
 				}
 				utils.AviLog.Infof("Model published in full sync %s", modelName)
-				nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+				publishModelForFullSync(modelName, sharedQueue)
 			}
 		}
 	}
@@ -585,16 +655,74 @@ func (c *AviController) FullSyncK8s() error {
 	utils.AviLog.Debugf("Newly generated models that do not exist in cache %s", utils.Stringify(allModels))
 	if allModels != nil {
 		for _, modelName := range allModels {
-			nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+			publishModelForFullSync(modelName, sharedQueue)
 		}
 	}
 	return nil
 }
 
+// dequeueForFullSync evaluates an object in the full sync, with incremental full sync only
+// the objects whose resourceVersion changed since the previous full sync are evaluated.
+func dequeueForFullSync(key, resourceVersion string) {
+	if lib.IsIncrementalFullSync() && !objects.SharedFullSyncLister().ObjectChanged(key, resourceVersion) {
+		lib.CountFullSync(lib.FullSyncObject, lib.FullSyncSkipped)
+		return
+	}
+	lib.CountFullSync(lib.FullSyncObject, lib.FullSyncSynced)
+	nodes.DequeueIngestion(key, true)
+}
+
+// publishModelForFullSync pushes a model to the rest layer in the full sync, with incremental full sync
+// only the models which changed since they were last pushed, or whose VS is not in the cache, are pushed.
+func publishModelForFullSync(modelName string, sharedQueue *utils.WorkerQueue) {
+	if lib.IsIncrementalFullSync() && !modelChangedSincePush(modelName) {
+		lib.CountFullSync(lib.FullSyncModel, lib.FullSyncSkipped)
+		return
+	}
+	lib.CountFullSync(lib.FullSyncModel, lib.FullSyncSynced)
+	nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+}
+
+func modelChangedSincePush(modelName string) bool {
+	var vsKey avicache.NamespaceName
+	if parts := strings.SplitN(modelName, "/", 2); len(parts) == 2 {
+		vsKey = avicache.NamespaceName{Namespace: parts[0], Name: parts[1]}
+	}
+	_, inCache := avicache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	avimodel, ok := aviModel.(*nodes.AviObjectGraph)
+	if !ok || avimodel == nil {
+		// pushing a deleted model removes its VS, if it is still there
+		return inCache
+	}
+	checksum, pushed := objects.SharedFullSyncLister().GetModelChecksum(modelName)
+	return !pushed || checksum != avimodel.GetContentCheckSum() || !inCache
+}
+
+// publishDriftedModels refreshes the VS cache by last_modified and pushes the models
+// whose virtual services were modified on the controller, so that they are restored.
+func publishDriftedModels(client *clients.AviClient) {
+	driftedKeys, err := avicache.SharedAviObjCache().AviVsCacheRefreshIncremental(client, utils.CloudName)
+	if err != nil {
+		utils.AviLog.Warnf("Incremental refresh of the VS cache failed: %v", err)
+		return
+	}
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, vsKey := range driftedKeys {
+		modelName := vsKey.Namespace + "/" + vsKey.Name
+		utils.AviLog.Infof("Model published in full sync as its VS was modified on the controller: %s", modelName)
+		lib.CountFullSync(lib.FullSyncModel, lib.FullSyncDrifted)
+		nodes.PublishKeyToRestLayer(modelName, "fullsync", sharedQueue)
+	}
+}
+
 // DeleteModels : Delete models and add the model name in the queue.
 // The rest layer would pick up the model key and delete the objects in Avi
 func (c *AviController) DeleteModels() {
 	utils.AviLog.Infof("Deletion of all avi objects triggered")
+	// the next full sync has to evaluate everything again
+	objects.SharedFullSyncLister().Reset()
+	avicache.DeleteCacheSnapshot()
 	status.AddStatefulSetStatus(lib.ObjectDeletionStartStatus, corev1.ConditionTrue)
 	allModels := objects.SharedAviGraphLister().GetAll()
 	allModelsMap := allModels.(map[string]interface{})
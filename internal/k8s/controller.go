@@ -17,9 +17,11 @@ package k8s
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
@@ -32,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -83,7 +86,7 @@ func isNodeUpdated(oldNode, newNode *corev1.Node) bool {
 	if oldNode.ResourceVersion == newNode.ResourceVersion {
 		return false
 	}
-	var oldaddr, newaddr string
+	var oldaddrs, newaddrs []string
 
 	oldAddrs := oldNode.Status.Addresses
 	newAddrs := newNode.Status.Addresses
@@ -91,22 +94,21 @@ func isNodeUpdated(oldNode, newNode *corev1.Node) bool {
 		return true
 	}
 
+	// dual-stack nodes have an InternalIP per IP family
 	for _, addr := range oldAddrs {
 		if addr.Type == "InternalIP" {
-			oldaddr = addr.Address
-			break
+			oldaddrs = append(oldaddrs, addr.Address)
 		}
 	}
 	for _, addr := range newAddrs {
 		if addr.Type == "InternalIP" {
-			newaddr = addr.Address
-			break
+			newaddrs = append(newaddrs, addr.Address)
 		}
 	}
-	if oldaddr != newaddr {
+	if !reflect.DeepEqual(oldaddrs, newaddrs) {
 		return true
 	}
-	if oldNode.Spec.PodCIDR != newNode.Spec.PodCIDR {
+	if oldNode.Spec.PodCIDR != newNode.Spec.PodCIDR || !reflect.DeepEqual(oldNode.Spec.PodCIDRs, newNode.Spec.PodCIDRs) {
 		return true
 	}
 
@@ -267,6 +269,99 @@ func AddNamespaceEventHandler(numWorkers uint32, c *AviController) cache.Resourc
 	return namespaceEventHandler
 }
 
+// AddNamespaceDomainEventHandler re-evaluates the hostnames of all Ingresses/Routes when the
+// domains granted to a namespace via the ako.vmware.com/allowed-domains annotation change,
+// granting a domain to one namespace revokes it from the rest.
+func AddNamespaceDomainEventHandler(numWorkers uint32, c *AviController) cache.ResourceEventHandler {
+	nsDomainEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ns := obj.(*corev1.Namespace)
+			domains := lib.GetNamespaceAllowedDomains(ns)
+			objects.SharedNamespaceDomainLister().Save(ns.GetName(), domains)
+			if c.DisableSync {
+				return
+			}
+			if len(domains) > 0 {
+				addAllIngressesToIngestionQueue(numWorkers, c, ns.GetName())
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			ns, ok := obj.(*corev1.Namespace)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				ns, ok = tombstone.Obj.(*corev1.Namespace)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a Namespace: %#v", obj)
+					return
+				}
+			}
+			objects.SharedNamespaceDomainLister().Delete(ns.GetName())
+			if c.DisableSync {
+				return
+			}
+			if len(lib.GetNamespaceAllowedDomains(ns)) > 0 {
+				addAllIngressesToIngestionQueue(numWorkers, c, ns.GetName())
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			nsOld := old.(*corev1.Namespace)
+			nsCur := cur.(*corev1.Namespace)
+			if nsOld.ResourceVersion == nsCur.ResourceVersion {
+				return
+			}
+			domains := lib.GetNamespaceAllowedDomains(nsCur)
+			if reflect.DeepEqual(lib.GetNamespaceAllowedDomains(nsOld), domains) {
+				return
+			}
+			objects.SharedNamespaceDomainLister().Save(nsCur.GetName(), domains)
+			if c.DisableSync {
+				return
+			}
+			addAllIngressesToIngestionQueue(numWorkers, c, nsCur.GetName())
+		},
+	}
+	return nsDomainEventHandler
+}
+
+// addAllIngressesToIngestionQueue adds the Ingresses/Routes of all the accepted namespaces to the ingestion queue.
+func addAllIngressesToIngestionQueue(numWorkers uint32, c *AviController, namespace string) {
+	utils.AviLog.Infof("Allowed domains updated for namespace %s, re-evaluating hostnames of all ingresses/routes", namespace)
+	var keys []string
+	if utils.GetInformers().IngressInformer != nil {
+		ingObjs, err := utils.GetInformers().IngressInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Error occurred while retrieving ingresses: %v", err)
+			return
+		}
+		for _, ingObj := range ingObjs {
+			keys = append(keys, utils.Ingress+"/"+utils.ObjKey(ingObj))
+		}
+	} else if utils.GetInformers().RouteInformer != nil {
+		routeObjs, err := utils.GetInformers().RouteInformer.Lister().List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Error occurred while retrieving routes: %v", err)
+			return
+		}
+		for _, routeObj := range routeObjs {
+			keys = append(keys, utils.OshiftRoute+"/"+utils.ObjKey(routeObj))
+		}
+	}
+	nsFilterObj := utils.GetGlobalNSFilter()
+	for _, key := range keys {
+		objNamespace := strings.Split(key, "/")[1]
+		if !utils.CheckIfNamespaceAccepted(objNamespace, nsFilterObj, nil, true) {
+			continue
+		}
+		bkt := utils.Bkt(objNamespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: %s for namespace: %s", key, lib.NsDomainUpdate, namespace)
+	}
+}
+
 func AddRouteEventHandler(numWorkers uint32, c *AviController) cache.ResourceEventHandler {
 	routeEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -349,6 +444,9 @@ func AddPodEventHandler(numWorkers uint32, c *AviController) cache.ResourceEvent
 				return
 			}
 			pod := obj.(*corev1.Pod)
+			if !isPodOfInterest(pod) {
+				return
+			}
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(pod))
 			key := utils.Pod + "/" + utils.ObjKey(pod)
 			bkt := utils.Bkt(namespace, numWorkers)
@@ -384,6 +482,9 @@ func AddPodEventHandler(numWorkers uint32, c *AviController) cache.ResourceEvent
 			}
 			oldPod := old.(*corev1.Pod)
 			newPod := cur.(*corev1.Pod)
+			if !isPodOfInterest(newPod) {
+				return
+			}
 			if !reflect.DeepEqual(newPod, oldPod) {
 				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newPod))
 				key := utils.Pod + "/" + utils.ObjKey(oldPod)
@@ -396,12 +497,21 @@ func AddPodEventHandler(numWorkers uint32, c *AviController) cache.ResourceEvent
 	return podEventHandler
 }
 
+// isPodOfInterest returns true if AKO has to process the Pod, either for NPL or for the pool readiness gate.
+func isPodOfInterest(pod *corev1.Pod) bool {
+	if lib.GetServiceType() == lib.NodePortLocal {
+		return true
+	}
+	return lib.IsPodReadinessGateEnabled() && lib.HasPodReadinessGate(pod)
+}
+
 func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 	cs := k8sinfo.Cs
 	utils.AviLog.Debugf("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(utils.AviLog.Debugf)
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: cs.CoreV1().Events("")})
+	lib.SetAKOEventRecorder(eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: lib.AKOUser}))
 	mcpQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
 	c.workqueue = mcpQueue.Workqueue
 	numWorkers := mcpQueue.NumWorkers
@@ -926,8 +1036,12 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 		namespaceEventHandler := AddNamespaceEventHandler(numWorkers, c)
 		c.informers.NSInformer.Informer().AddEventHandler(namespaceEventHandler)
 	}
+	if c.informers.NSInformer != nil {
+		nsDomainEventHandler := AddNamespaceDomainEventHandler(numWorkers, c)
+		c.informers.NSInformer.Informer().AddEventHandler(nsDomainEventHandler)
+	}
 
-	if lib.GetServiceType() == lib.NodePortLocal {
+	if lib.GetServiceType() == lib.NodePortLocal || lib.IsPodReadinessGateEnabled() {
 		podEventHandler := AddPodEventHandler(numWorkers, c)
 		c.informers.PodInformer.Informer().AddEventHandler(podEventHandler)
 	}
@@ -967,7 +1081,7 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 		c.informers.SecretInformer.Informer().HasSynced,
 	}
 
-	if lib.GetServiceType() == lib.NodePortLocal {
+	if lib.GetServiceType() == lib.NodePortLocal || lib.IsPodReadinessGateEnabled() {
 		go c.informers.PodInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.informers.PodInformer.Informer().HasSynced)
 	}
@@ -1029,6 +1143,8 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 		go lib.GetCRDInformers().HostRuleInformer.Informer().Run(stopCh)
 		go lib.GetCRDInformers().HTTPRuleInformer.Informer().Run(stopCh)
 		go lib.GetCRDInformers().AviInfraSettingInformer.Informer().Run(stopCh)
+		go lib.GetCRDInformers().BackendGrantInformer.Informer().Run(stopCh)
+		go lib.GetCRDInformers().AuthRuleInformer.Informer().Run(stopCh)
 		if !cache.WaitForCacheSync(stopCh, lib.GetCRDInformers().AviInfraSettingInformer.Informer().HasSynced) {
 			runtime.HandleError(fmt.Errorf("Timed out waiting for AviInfraSettingInformer caches to sync"))
 		}
@@ -1040,6 +1156,12 @@ func (c *AviController) Start(stopCh <-chan struct{}) {
 		if !cache.WaitForCacheSync(stopCh, lib.GetCRDInformers().HTTPRuleInformer.Informer().HasSynced) {
 			runtime.HandleError(fmt.Errorf("Timed out waiting for HTTPRule caches to sync"))
 		}
+		if !cache.WaitForCacheSync(stopCh, lib.GetCRDInformers().BackendGrantInformer.Informer().HasSynced) {
+			runtime.HandleError(fmt.Errorf("Timed out waiting for BackendGrant caches to sync"))
+		}
+		if !cache.WaitForCacheSync(stopCh, lib.GetCRDInformers().AuthRuleInformer.Informer().HasSynced) {
+			runtime.HandleError(fmt.Errorf("Timed out waiting for AuthRule caches to sync"))
+		}
 		utils.AviLog.Info("CRD caches synced")
 	}
 
@@ -35,11 +35,15 @@ func NewCRDInformers(cs akocrd.Interface) {
 	hostRuleInformer := akoInformerFactory.Ako().V1alpha1().HostRules()
 	httpRuleInformer := akoInformerFactory.Ako().V1alpha1().HTTPRules()
 	albSettingsInformer := akoInformerFactory.Ako().V1alpha1().AviInfraSettings()
+	backendGrantInformer := akoInformerFactory.Ako().V1alpha1().BackendGrants()
+	authRuleInformer := akoInformerFactory.Ako().V1alpha1().AuthRules()
 
 	lib.SetCRDInformers(&lib.AKOCrdInformers{
 		HostRuleInformer:        hostRuleInformer,
 		HTTPRuleInformer:        httpRuleInformer,
 		AviInfraSettingInformer: albSettingsInformer,
+		BackendGrantInformer:    backendGrantInformer,
+		AuthRuleInformer:        authRuleInformer,
 	})
 }
 
@@ -190,8 +194,83 @@ func (c *AviController) SetupAKOCRDEventHandlers(numWorkers uint32) {
 		},
 	}
 
+	backendGrantEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			backendGrant := obj.(*akov1alpha1.BackendGrant)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(backendGrant))
+			key := lib.BackendGrant + "/" + utils.ObjKey(backendGrant)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldObj := old.(*akov1alpha1.BackendGrant)
+			backendGrant := new.(*akov1alpha1.BackendGrant)
+			if !reflect.DeepEqual(oldObj.Spec, backendGrant.Spec) {
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(backendGrant))
+				key := lib.BackendGrant + "/" + utils.ObjKey(backendGrant)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			backendGrant := obj.(*akov1alpha1.BackendGrant)
+			key := lib.BackendGrant + "/" + utils.ObjKey(backendGrant)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(backendGrant))
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+	}
+
+	authRuleEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			authrule := obj.(*akov1alpha1.AuthRule)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(authrule))
+			key := lib.AuthRule + "/" + utils.ObjKey(authrule)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldObj := old.(*akov1alpha1.AuthRule)
+			authrule := new.(*akov1alpha1.AuthRule)
+			if !reflect.DeepEqual(oldObj.Spec, authrule.Spec) {
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(authrule))
+				key := lib.AuthRule + "/" + utils.ObjKey(authrule)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			authrule := obj.(*akov1alpha1.AuthRule)
+			key := lib.AuthRule + "/" + utils.ObjKey(authrule)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(authrule))
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+		},
+	}
+
 	informer.HostRuleInformer.Informer().AddEventHandler(hostRuleEventHandler)
 	informer.HTTPRuleInformer.Informer().AddEventHandler(httpRuleEventHandler)
+	informer.AuthRuleInformer.Informer().AddEventHandler(authRuleEventHandler)
+
+	informer.BackendGrantInformer.Informer().AddEventHandler(backendGrantEventHandler)
 
 	informer.AviInfraSettingInformer.Informer().AddEventHandler(albInfraEventHandler)
 	informer.AviInfraSettingInformer.Informer().AddIndexers(
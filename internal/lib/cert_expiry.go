@@ -0,0 +1,191 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package lib
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ParseKeyCert parses a PEM encoded certificate and checks that the key belongs to it. Content which is
+// not PEM encoded is not inspected and is left to the controller, in which case no certificate is returned.
+func ParseKeyCert(cert, key []byte) (*x509.Certificate, error) {
+	if block, _ := pem.Decode(cert); block == nil {
+		return nil, nil
+	}
+	keyPair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(keyPair.Certificate[0])
+}
+
+// DaysToExpiry returns the number of whole days left before the certificate expires, negative once it has expired.
+func DaysToExpiry(cert *x509.Certificate) int {
+	return daysUntil(cert.NotAfter)
+}
+
+func daysUntil(t time.Time) int {
+	return int(math.Floor(time.Until(t).Hours() / 24))
+}
+
+// CertExpiryScanInterval is the interval at which the expiry Events of the tracked certificates are raised again,
+// the certificates are otherwise only checked when their Ingress/Route or Secret changes.
+const CertExpiryScanInterval = 12 * time.Hour
+
+// GetCertExpiryWarningDays returns the number of days before the expiry of a certificate
+// from which Events are raised on the Ingresses/Routes using it.
+func GetCertExpiryWarningDays() int {
+	if val := os.Getenv(CERT_EXPIRY_WARNING_DAYS); val != "" {
+		if days, err := strconv.Atoi(val); err == nil && days >= 0 {
+			return days
+		}
+		utils.AviLog.Warnf("Invalid value %s for certExpiryWarningDays, defaulting to %d", val, DefaultCertExpiryWarningDays)
+	}
+	return DefaultCertExpiryWarningDays
+}
+
+// CertExpiryInfo holds the validity of a certificate uploaded as an SSLKeyAndCertificate,
+// along with the Ingress/Route using it, on which the expiry Events are raised.
+type CertExpiryInfo struct {
+	Name         string    `json:"name"`
+	Secret       string    `json:"secret"`
+	Hosts        []string  `json:"hosts"`
+	Subject      string    `json:"subject"`
+	NotAfter     time.Time `json:"not_after"`
+	DaysToExpiry int       `json:"days_to_expiry"`
+	ObjType      string    `json:"object_type"`
+	Namespace    string    `json:"namespace"`
+	ObjName      string    `json:"object_name"`
+}
+
+var certDaysToExpiryDesc = prometheus.NewDesc("ako_certificate_days_to_expiry",
+	"Days left before the certificate of an SSLKeyAndCertificate created by AKO expires.",
+	[]string{"name", "secret"}, nil)
+
+// certExpiryCollector exports the days left for the certificates of the store, computed when the metrics are scraped.
+type certExpiryCollector struct{}
+
+func (c certExpiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- certDaysToExpiryDesc
+}
+
+func (c certExpiryCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, info := range SharedCertExpiryStore().List() {
+		ch <- prometheus.MustNewConstMetric(certDaysToExpiryDesc, prometheus.GaugeValue, float64(info.DaysToExpiry), info.Name, info.Secret)
+	}
+}
+
+func init() {
+	prometheus.MustRegister(certExpiryCollector{})
+}
+
+// CertExpiryStore tracks the certificates uploaded by AKO by SSLKeyAndCertificate name,
+// it backs the certificate metrics and the /api/certificates debug API.
+type CertExpiryStore struct {
+	certs map[string]CertExpiryInfo
+	lock  sync.RWMutex
+}
+
+var certExpiryStoreInstance *CertExpiryStore
+var certExpiryOnce sync.Once
+
+func SharedCertExpiryStore() *CertExpiryStore {
+	certExpiryOnce.Do(func() {
+		certExpiryStoreInstance = &CertExpiryStore{
+			certs: make(map[string]CertExpiryInfo),
+		}
+	})
+	return certExpiryStoreInstance
+}
+
+func (c *CertExpiryStore) Save(info CertExpiryInfo) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.certs[info.Name] = info
+}
+
+func (c *CertExpiryStore) Delete(name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.certs, name)
+}
+
+func (c *CertExpiryStore) Get(name string) (CertExpiryInfo, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	info, ok := c.certs[name]
+	return info, ok
+}
+
+// List returns the certificates ordered by their expiry, the ones expiring first come first.
+func (c *CertExpiryStore) List() []CertExpiryInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	certs := make([]CertExpiryInfo, 0, len(c.certs))
+	for _, info := range c.certs {
+		// the days left are computed again, the certificate might not have been processed for a while
+		info.DaysToExpiry = daysUntil(info.NotAfter)
+		certs = append(certs, info)
+	}
+	sort.Slice(certs, func(i, j int) bool {
+		if certs[i].NotAfter.Equal(certs[j].NotAfter) {
+			return certs[i].Name < certs[j].Name
+		}
+		return certs[i].NotAfter.Before(certs[j].NotAfter)
+	})
+	return certs
+}
+
+// CertificatesModel implements ApiModel, serving the certificates at /api/certificates and the metrics at /metrics.
+type CertificatesModel struct{}
+
+func (a *CertificatesModel) InitModel() {
+	SharedCertExpiryStore()
+}
+
+func (a *CertificatesModel) ApiOperationMap() []models.OperationMap {
+	var operationMapList []models.OperationMap
+
+	certificates := models.OperationMap{
+		Route:  "/api/certificates",
+		Method: "GET",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			utils.Respond(w, SharedCertExpiryStore().List())
+		},
+	}
+	metrics := models.OperationMap{
+		Route:   "/metrics",
+		Method:  "GET",
+		Handler: promhttp.Handler().ServeHTTP,
+	}
+
+	operationMapList = append(operationMapList, certificates, metrics)
+	return operationMapList
+}
@@ -176,6 +176,12 @@ func GetPodCIDR(node *v1.Node) ([]string, error) {
 		}
 
 		podCIDRs = append(podCIDRs, node.Spec.PodCIDR)
+		// PodCIDRs holds the IPv4 and IPv6 Pod CIDRs of dual-stack clusters.
+		for _, cidr := range node.Spec.PodCIDRs {
+			if !utils.HasElem(podCIDRs, cidr) {
+				podCIDRs = append(podCIDRs, cidr)
+			}
+		}
 	}
 
 	return podCIDRs, nil
@@ -15,21 +15,38 @@
 package lib
 
 const (
-	DISABLE_STATIC_ROUTE_SYNC = "DISABLE_STATIC_ROUTE_SYNC"
-	ENABLE_RHI                = "ENABLE_RHI"
-	ENABLE_EVH                = "ENABLE_EVH"
-	CNI_PLUGIN                = "CNI_PLUGIN"
-	CALICO_CNI                = "calico"
-	OPENSHIFT_CNI             = "openshift"
-	INGRESS_API               = "INGRESS_API"
-	AviConfigMap              = "avi-k8s-config"
-	AviSecret                 = "avi-secret"
-	AviNS                     = "avi-system"
-	VMwareNS                  = "vmware-system-ako"
+	DISABLE_STATIC_ROUTE_SYNC  = "DISABLE_STATIC_ROUTE_SYNC"
+	ENABLE_RHI                 = "ENABLE_RHI"
+	ENABLE_EVH                 = "ENABLE_EVH"
+	ENABLE_POD_READINESS_GATE  = "ENABLE_POD_READINESS_GATE"
+	READINESS_GATE_RUNTIME     = "POD_READINESS_GATE_RUNTIME_CHECK"
+	HOSTNAME_CONFLICT_POLICY   = "HOSTNAME_CONFLICT_POLICY"
+	DEFAULT_INGRESS_CERT       = "DEFAULT_INGRESS_CERT"
+	DEFAULT_CERT_DOMAIN        = "DEFAULT_CERT_DOMAIN"
+	CERT_EXPIRY_WARNING_DAYS   = "CERT_EXPIRY_WARNING_DAYS"
+	INCREMENTAL_FULL_SYNC      = "INCREMENTAL_FULL_SYNC"
+	CACHE_SNAPSHOT             = "CACHE_SNAPSHOT"
+	CACHE_POPULATE_CONCURRENCY = "CACHE_POPULATE_CONCURRENCY"
+	CACHE_POPULATE_TIMEOUT     = "CACHE_POPULATE_TIMEOUT"
+	CNI_PLUGIN                 = "CNI_PLUGIN"
+	CALICO_CNI                 = "calico"
+	OPENSHIFT_CNI              = "openshift"
+	INGRESS_API                = "INGRESS_API"
+	AviConfigMap               = "avi-k8s-config"
+	AviCacheSnapshot           = "avi-k8s-cache-snapshot"
+	AviSecret                  = "avi-secret"
+	AviNS                      = "avi-system"
+	VMwareNS                   = "vmware-system-ako"
 
 	AVI_INGRESS_CLASS                          = "avi"
 	SUBNET_IP                                  = "SUBNET_IP"
 	SUBNET_PREFIX                              = "SUBNET_PREFIX"
+	SUBNET6_IP                                 = "SUBNET6_IP"
+	SUBNET6_PREFIX                             = "SUBNET6_PREFIX"
+	VIP_IP_FAMILY                              = "VIP_IP_FAMILY"
+	IPFamilyV4                                 = "V4"
+	IPFamilyV6                                 = "V6"
+	IPFamilyV4V6                               = "V4_V6"
 	NETWORK_NAME                               = "NETWORK_NAME"
 	SEG_NAME                                   = "SEG_NAME"
 	DEFAULT_SE_GROUP                           = "Default-Group"
@@ -79,6 +96,8 @@ const (
 	HostRule                                   = "HostRule"
 	HTTPRule                                   = "HTTPRule"
 	AviInfraSetting                            = "AviInfraSetting"
+	BackendGrant                               = "BackendGrant"
+	AuthRule                                   = "AuthRule"
 	DummySecret                                = "@avisslkeycertrefdummy"
 	StatusRejected                             = "Rejected"
 	StatusAccepted                             = "Accepted"
@@ -120,10 +139,72 @@ const (
 	NPLPodAnnotation              = "nodeportlocal.antrea.io"
 	NPLSvcAnnotation              = "nodeportlocal.antrea.io/enabled"
 	InfraSettingNameAnnotation    = "aviinfrasetting.ako.vmware.com/name"
+	VipIPFamilyAnnotation         = "ako.vmware.com/vip-ip-family"
+	PodReadinessGateCondition     = "ako.vmware.com/pool-ready"
+	PodReadinessGateReason        = "PoolServerAdded"
+	WildcardHostPrefix            = "*."
+	WildcardHostNamePrefix        = "_wildcard"
+	WildcardDSScriptPrefix        = "-- wildcard hosts\n"
+	FqdnTypeExact                 = "Exact"
+	FqdnTypeWildcard              = "Wildcard"
+	FqdnTypeRegex                 = "Regex"
+	AllowedDomainsAnnotation      = "ako.vmware.com/allowed-domains"
+	HostnameConflictAnnotation    = "ako.vmware.com/hostname-conflicts"
+	HostnameConflictAllowMerge    = "allow-merge"
+	HostnameConflictFirstWins     = "first-wins"
+	HostnameConflictReject        = "reject"
+	HostnameConflictReason        = "HostnameConflict"
+	DefaultCertificateReason      = "DefaultCertificate"
+	InvalidCertificateReason      = "InvalidCertificate"
+	CertificateExpiringReason     = "CertificateExpiring"
+	DefaultCertExpiryWarningDays  = 30
+	BackendNamespacesAnnotation   = "ako.vmware.com/backend-namespaces"
+	PassthroughAnnotation         = "ako.vmware.com/enable-passthrough"
+	TLSTerminationPassthrough     = "passthrough"
+	ClientCertModeRequire         = "require"
+	ClientCertModeRequest         = "request"
+	CABundleKindSecret            = "Secret"
+	CABundleKindConfigMap         = "ConfigMap"
+	CABundleKey                   = "ca.crt"
+	RateLimitKeyClientIP          = "clientIP"
+	RateLimitKeyHeader            = "header"
+	RateLimitActionDrop           = "drop"
+	RateLimitActionReject         = "reject"
+	RateLimitActionRedirect       = "redirect"
+	HSTSDefaultMaxAge             = 31536000
+	JWKSKey                       = "jwks"
+	JWTTokenLocationHeader        = "header"
+	JWTTokenLocationQuery         = "query"
+	PersistenceTypeCookie         = "cookie"
+	PersistenceTypeHeader         = "header"
+	PersistenceTypeClientIP       = "clientIP"
+	// client IP persistence timeout is limited to 720 minutes by the Avi controller
+	MaxClientIPPersistenceTimeout = 720
+	// pool server, idle connection and retry timeouts are limited to 3600 seconds by the Avi controller
+	MaxPoolServerTimeout = 3600
+	BackendProtocolHTTP1 = "http1"
+	BackendProtocolH2C   = "h2c"
+	BackendProtocolGRPC  = "grpc"
+	AppProtocolH2C       = "kubernetes.io/h2c"
+	// stores of the snapshot of the controller cache
+	CacheSnapshotConfigMap = "configmap"
+	CacheSnapshotPVC       = "pvc"
+	// requests in flight and timeout in seconds of the requests populating the controller cache
+	DefaultCachePopulateConcurrency = 4
+	DefaultCachePopulateTimeout     = 60
 
 	// Specifies command used in namespace event handler
 	NsFilterAdd    = "ADD"
 	NsFilterDelete = "DELETE"
+	NsDomainUpdate = "DOMAIN_UPDATE"
+)
+
+// Defaults of the health monitors defined in HTTPRule, same as the ones set by the Avi controller.
+const (
+	DefaultHMSendInterval     int32 = 10
+	DefaultHMReceiveTimeout   int32 = 4
+	DefaultHMSuccessfulChecks int32 = 2
+	DefaultHMFailedChecks     int32 = 2
 )
 
 // Cache Indexer constants.
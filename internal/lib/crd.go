@@ -35,6 +35,8 @@ type AKOCrdInformers struct {
 	HostRuleInformer        akoinformer.HostRuleInformer
 	HTTPRuleInformer        akoinformer.HTTPRuleInformer
 	AviInfraSettingInformer akoinformer.AviInfraSettingInformer
+	BackendGrantInformer    akoinformer.BackendGrantInformer
+	AuthRuleInformer        akoinformer.AuthRuleInformer
 }
 
 func SetCRDInformers(c *AKOCrdInformers) {
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package lib
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// phases of the full sync, the refresh of the controller cache and the sync of the k8s objects
+	FullSyncPhaseCache = "cache"
+	FullSyncPhaseK8s   = "k8s"
+
+	FullSyncObject  = "object"
+	FullSyncModel   = "model"
+	FullSyncSynced  = "synced"
+	FullSyncSkipped = "skipped"
+	FullSyncDrifted = "drifted"
+)
+
+var fullSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "ako_full_sync_duration_seconds",
+	Help:    "Duration of the full syncs of AKO, by phase.",
+	Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+}, []string{"phase"})
+
+var fullSyncCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ako_full_sync_total",
+	Help: "Objects evaluated and models pushed by the full syncs of AKO, or skipped as they did not change.",
+}, []string{"type", "result"})
+
+func init() {
+	prometheus.MustRegister(fullSyncDuration, fullSyncCount)
+}
+
+// ObserveFullSyncDuration records the duration of a phase of the full sync which started at start.
+func ObserveFullSyncDuration(phase string, start time.Time) {
+	fullSyncDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+// CountFullSync counts an object or a model of the full sync, by whether it was synced, skipped or drifted.
+func CountFullSync(objType, result string) {
+	fullSyncCount.WithLabelValues(objType, result).Inc()
+}
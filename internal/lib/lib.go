@@ -19,10 +19,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 
@@ -32,8 +34,10 @@ import (
 	"github.com/avinetworks/sdk/go/models"
 	routev1 "github.com/openshift/api/route/v1"
 	oshiftclient "github.com/openshift/client-go/route/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 var ShardSchemeMap = map[string]string{
@@ -166,7 +170,7 @@ func GetL7SharedPGName(vsName string) string {
 }
 
 func GetL7PoolName(priorityLabel, namespace, ingName string, args ...string) string {
-	priorityLabel = strings.ReplaceAll(priorityLabel, "/", "_")
+	priorityLabel = strings.ReplaceAll(encodeHostName(priorityLabel), "/", "_")
 	poolName := NamePrefix + priorityLabel + "-" + namespace + "-" + ingName
 	if len(args) > 0 {
 		svcName := args[0]
@@ -175,20 +179,28 @@ func GetL7PoolName(priorityLabel, namespace, ingName string, args ...string) str
 	return poolName
 }
 
+// encodeHostName replaces the wildcard character in a hostname, so that the
+// wildcard hosts can be used as part of Avi object names. The replacement starts
+// with an underscore, which is not valid in a DNS label, so that it cannot collide
+// with the name of a real host.
+func encodeHostName(host string) string {
+	return strings.Replace(host, "*", WildcardHostNamePrefix, 1)
+}
+
 func GetL7HttpRedirPolicy(vsName string) string {
 	return vsName
 }
 
 func GetSniNodeName(ingName, namespace, secret string, sniHostName ...string) string {
 	if len(sniHostName) > 0 {
-		return NamePrefix + sniHostName[0]
+		return NamePrefix + encodeHostName(sniHostName[0])
 	}
 	return NamePrefix + ingName + "-" + namespace + "-" + secret
 }
 
 func GetSniPoolName(ingName, namespace, host, path string, args ...string) string {
 	path = strings.ReplaceAll(path, "/", "_")
-	poolName := NamePrefix + namespace + "-" + host + path + "-" + ingName
+	poolName := NamePrefix + namespace + "-" + encodeHostName(host) + path + "-" + ingName
 	if len(args) > 0 {
 		svcName := args[0]
 		poolName = poolName + "-" + svcName
@@ -198,18 +210,18 @@ func GetSniPoolName(ingName, namespace, host, path string, args ...string) strin
 
 func GetSniHttpPolName(ingName, namespace, host, path string) string {
 	path = strings.ReplaceAll(path, "/", "_")
-	return NamePrefix + namespace + "-" + host + path + "-" + ingName
+	return NamePrefix + namespace + "-" + encodeHostName(host) + path + "-" + ingName
 }
 
 func GetSniPGName(ingName, namespace, host, path string) string {
 	path = strings.ReplaceAll(path, "/", "_")
-	return NamePrefix + namespace + "-" + host + path + "-" + ingName
+	return NamePrefix + namespace + "-" + encodeHostName(host) + path + "-" + ingName
 }
 
 // evh child
 func GetEvhVsPoolNPgName(ingName, namespace, host, path string, args ...string) string {
 	path = strings.ReplaceAll(path, "/", "_")
-	poolName := NamePrefix + namespace + "-" + host + path + "-" + ingName
+	poolName := NamePrefix + namespace + "-" + encodeHostName(host) + path + "-" + ingName
 	if len(args) > 0 {
 		svcName := args[0]
 		poolName = poolName + "-" + svcName
@@ -218,21 +230,27 @@ func GetEvhVsPoolNPgName(ingName, namespace, host, path string, args ...string)
 }
 
 func GetEvhNodeName(ingName, namespace, host string) string {
-	return NamePrefix + namespace + "-" + host
+	return NamePrefix + namespace + "-" + encodeHostName(host)
 }
 
 func GetEvhPGName(ingName, namespace, host, path string) string {
 	path = strings.ReplaceAll(path, "/", "_")
-	return NamePrefix + namespace + "-" + host + path + "-" + ingName
+	return NamePrefix + namespace + "-" + encodeHostName(host) + path + "-" + ingName
 }
 
 func GetTLSKeyCertNodeName(namespace, secret string, sniHostName ...string) string {
 	if len(sniHostName) > 0 {
-		return NamePrefix + sniHostName[0]
+		return NamePrefix + encodeHostName(sniHostName[0])
 	}
 	return NamePrefix + namespace + "-" + secret
 }
 
+// GetAltTLSKeyCertNodeName returns the name of the alternate certificate of a host, which uses
+// another key algorithm than the certificate named by GetTLSKeyCertNodeName.
+func GetAltTLSKeyCertNodeName(sniHostName string) string {
+	return NamePrefix + encodeHostName(sniHostName) + "-alt"
+}
+
 func GetCACertNodeName(keycertname string) string {
 	return keycertname + "-cacert"
 }
@@ -241,6 +259,46 @@ func GetPoolPKIProfileName(poolName string) string {
 	return poolName + "-pkiprofile"
 }
 
+func GetPoolClientCertName(poolName string) string {
+	return poolName + "-clientcert"
+}
+
+func GetPoolHealthMonitorName(poolName, hmName string) string {
+	return poolName + "-hm-" + hmName
+}
+
+func GetPoolPersistenceProfileName(poolName string) string {
+	return poolName + "-persistence"
+}
+
+func GetVsAppProfileName(vsName string) string {
+	return vsName + "-appprofile"
+}
+
+func GetVsPKIProfileName(vsName string) string {
+	return vsName + "-clientca-pkiprofile"
+}
+
+func GetVsHostPolicyName(vsName string) string {
+	return vsName + "-hostpolicy"
+}
+
+func GetVsSSLProfileName(vsName string) string {
+	return vsName + "-sslprofile"
+}
+
+func GetVsSSOPolicyName(vsName string) string {
+	return vsName + "-ssopolicy"
+}
+
+func GetVsAuthProfileName(vsName string) string {
+	return vsName + "-authprofile"
+}
+
+func GetVsJWTProfileName(vsName string) string {
+	return vsName + "-jwtprofile"
+}
+
 var VRFContext string
 var VRFUuid string
 
@@ -368,6 +426,234 @@ func GetSubnetPrefixInt() int32 {
 	return int32(intCidr)
 }
 
+func GetSubnet6IP() string {
+	return os.Getenv(SUBNET6_IP)
+}
+
+func GetSubnet6Prefix() string {
+	return os.Getenv(SUBNET6_PREFIX)
+}
+
+func GetSubnet6PrefixInt() int32 {
+	// check if subnet6Prefix value is a valid integer value
+	defaultCidr := int32(64)
+	intCidr, err := strconv.ParseInt(GetSubnet6Prefix(), 10, 32)
+	if err != nil {
+		utils.AviLog.Warnf("The value of subnet6Prefix couldn't be converted to int32, defaulting to /64, %v", err)
+		return defaultCidr
+	}
+	return int32(intCidr)
+}
+
+func IsValidIPFamily(ipFamily string) bool {
+	return ipFamily == IPFamilyV4 || ipFamily == IPFamilyV6 || ipFamily == IPFamilyV4V6
+}
+
+// GetVipIPFamily returns the IP family of the VIPs requested by default, one of V4, V6 or V4_V6.
+func GetVipIPFamily() string {
+	ipFamily := strings.ToUpper(os.Getenv(VIP_IP_FAMILY))
+	if IsValidIPFamily(ipFamily) {
+		return ipFamily
+	}
+	if ipFamily != "" {
+		utils.AviLog.Warnf("Invalid value %s for vipIPFamily, defaulting to %s", ipFamily, IPFamilyV4)
+	}
+	return IPFamilyV4
+}
+
+// GetHostnameConflictPolicy returns how a hostname claimed by objects in different namespaces is handled,
+// one of allow-merge, first-wins or reject.
+func GetHostnameConflictPolicy() string {
+	policy := strings.ToLower(os.Getenv(HOSTNAME_CONFLICT_POLICY))
+	switch policy {
+	case HostnameConflictAllowMerge, HostnameConflictFirstWins, HostnameConflictReject:
+		return policy
+	case "":
+	default:
+		utils.AviLog.Warnf("Invalid value %s for hostnameConflictPolicy, defaulting to %s", policy, HostnameConflictAllowMerge)
+	}
+	return HostnameConflictAllowMerge
+}
+
+// IsIncrementalFullSync returns true if the full syncs only evaluate the objects and push the models
+// which changed since the previous full sync, or whose virtual services were modified on the controller.
+func IsIncrementalFullSync() bool {
+	incremental, _ := strconv.ParseBool(os.Getenv(INCREMENTAL_FULL_SYNC))
+	return incremental
+}
+
+// GetCacheSnapshotStore returns where the snapshot of the controller cache is persisted, in ConfigMaps in the
+// AKO namespace or in a file on the PVC of AKO, empty if the cache is not persisted.
+func GetCacheSnapshotStore() string {
+	switch store := os.Getenv(CACHE_SNAPSHOT); store {
+	case CacheSnapshotConfigMap, CacheSnapshotPVC:
+		return store
+	case "":
+	default:
+		utils.AviLog.Warnf("Invalid value %s for %s, the cache snapshot is disabled", store, CACHE_SNAPSHOT)
+	}
+	return ""
+}
+
+// GetCacheSnapshotPath returns the file of the cache snapshot, next to the log file on the PVC of AKO.
+func GetCacheSnapshotPath() string {
+	return filepath.Join(os.Getenv("LOG_FILE_PATH"), "ako-cache-snapshot.gz")
+}
+
+// GetCachePopulateConcurrency returns the maximum number of requests in flight while populating the controller cache.
+func GetCachePopulateConcurrency() int {
+	if val := os.Getenv(CACHE_POPULATE_CONCURRENCY); val != "" {
+		if concurrency, err := strconv.Atoi(val); err == nil && concurrency > 0 {
+			return concurrency
+		}
+		utils.AviLog.Warnf("Invalid value %s for cachePopulateConcurrency, defaulting to %d", val, DefaultCachePopulateConcurrency)
+	}
+	return DefaultCachePopulateConcurrency
+}
+
+// GetCachePopulateTimeout returns the timeout of each request populating the controller cache.
+func GetCachePopulateTimeout() time.Duration {
+	timeout := DefaultCachePopulateTimeout
+	if val := os.Getenv(CACHE_POPULATE_TIMEOUT); val != "" {
+		if seconds, err := strconv.Atoi(val); err == nil && seconds > 0 {
+			timeout = seconds
+		} else {
+			utils.AviLog.Warnf("Invalid value %s for cachePopulateTimeout, defaulting to %d", val, DefaultCachePopulateTimeout)
+		}
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// GetDefaultIngressCert returns the namespace and name of the secret used for the Ingress TLS hosts
+// without a usable secret, the secret is referred to as namespace/name or by name in the AKO namespace.
+func GetDefaultIngressCert() (string, string) {
+	return ParseSecretRef(os.Getenv(DEFAULT_INGRESS_CERT))
+}
+
+// ParseSecretRef splits a namespace/name secret reference, a reference without
+// a namespace refers to a secret in the AKO namespace.
+func ParseSecretRef(ref string) (string, string) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", ""
+	}
+	if arr := strings.Split(ref, "/"); len(arr) == 2 {
+		return arr[0], arr[1]
+	}
+	return utils.GetAKONamespace(), ref
+}
+
+// GetDefaultCertDomain returns the wildcard domain, like *.apps.example.com, whose hosts are
+// served with the default certificate when they are not part of any Ingress TLS entry.
+func GetDefaultCertDomain() string {
+	domain := strings.TrimSpace(os.Getenv(DEFAULT_CERT_DOMAIN))
+	if domain == "" {
+		return ""
+	}
+	if !IsWildcardHost(domain) || !IsValidWildcardHost(domain) {
+		utils.AviLog.Warnf("Invalid value %s for defaultCertificateDomain, it must be a wildcard domain like *.example.com", domain)
+		return ""
+	}
+	return domain
+}
+
+// GetNamespaceAllowedDomains returns the domain suffixes granted to a namespace
+// via the ako.vmware.com/allowed-domains annotation.
+func GetNamespaceAllowedDomains(ns *corev1.Namespace) []string {
+	var domains []string
+	value, ok := ns.GetAnnotations()[AllowedDomainsAnnotation]
+	if !ok {
+		return domains
+	}
+	for _, domain := range strings.Split(value, ",") {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), WildcardHostPrefix)
+		domain = strings.Trim(domain, ".")
+		if domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// GetIngressBackendNamespaces returns the namespaces of the backend Services of an Ingress, which are
+// set via the ako.vmware.com/backend-namespaces annotation as a JSON map of Service name to namespace.
+func GetIngressBackendNamespaces(annotations map[string]string) map[string]string {
+	backendNamespaces := make(map[string]string)
+	value, ok := annotations[BackendNamespacesAnnotation]
+	if !ok {
+		return backendNamespaces
+	}
+	if err := json.Unmarshal([]byte(value), &backendNamespaces); err != nil {
+		utils.AviLog.Warnf("Invalid value %s for annotation %s, ignoring backend namespaces: %v", value, BackendNamespacesAnnotation, err)
+		return make(map[string]string)
+	}
+	return backendNamespaces
+}
+
+var akoEventRecorder record.EventRecorder
+
+// SetAKOEventRecorder sets the recorder used to publish Events on the objects AKO processes.
+func SetAKOEventRecorder(recorder record.EventRecorder) {
+	akoEventRecorder = recorder
+}
+
+// AKOEventRecorder returns the recorder used to publish Events, nil until the event handlers are set up.
+func AKOEventRecorder() record.EventRecorder {
+	return akoEventRecorder
+}
+
+// GetServiceIPFamily returns the IP family of the VIP for a Service of type LoadBalancer.
+// The ako.vmware.com/vip-ip-family annotation takes precedence over the Service ipFamily.
+// Only the singular spec.ipFamily is available with this Kubernetes API version, and the API server
+// defaults it to the primary cluster family, so only IPv6 is taken as an explicit request which
+// overrides the AviInfraSetting and the global vipIPFamily.
+func GetServiceIPFamily(svc *corev1.Service, defaultFamily string) string {
+	if ipFamily, ok := svc.GetAnnotations()[VipIPFamilyAnnotation]; ok {
+		if ipFamily = strings.ToUpper(ipFamily); IsValidIPFamily(ipFamily) {
+			return ipFamily
+		}
+		utils.AviLog.Warnf("Invalid value %s for annotation %s on Service %s/%s", ipFamily, VipIPFamilyAnnotation, svc.Namespace, svc.Name)
+	}
+	if svc.Spec.IPFamily != nil && *svc.Spec.IPFamily == corev1.IPv6Protocol {
+		return IPFamilyV6
+	}
+	if svc.Spec.LoadBalancerIP != "" && !utils.IsV4(svc.Spec.LoadBalancerIP) {
+		return IPFamilyV6
+	}
+	return defaultFamily
+}
+
+// GetAutoAllocateIPType returns the Avi auto_allocate_ip_type for a VIP IP family.
+func GetAutoAllocateIPType(ipFamily string) string {
+	switch ipFamily {
+	case IPFamilyV6:
+		return "V6_ONLY"
+	case IPFamilyV4V6:
+		return "V4_V6"
+	}
+	return "V4_ONLY"
+}
+
+func IsIPv6Family(ipFamily string) bool {
+	return ipFamily == IPFamilyV6 || ipFamily == IPFamilyV4V6
+}
+
+func IsIPv4Family(ipFamily string) bool {
+	return ipFamily == "" || ipFamily == IPFamilyV4 || ipFamily == IPFamilyV4V6
+}
+
+// IsHTTP2AppProtocol checks the appProtocol of a Service port, the h2c and grpc ports are served over HTTP/2
+func IsHTTP2AppProtocol(appProtocol *string) bool {
+	if appProtocol == nil {
+		return false
+	}
+	switch strings.ToLower(*appProtocol) {
+	case AppProtocolH2C, BackendProtocolH2C, BackendProtocolGRPC:
+		return true
+	}
+	return false
+}
+
 func GetNetworkName() string {
 	networkName := os.Getenv(NETWORK_NAME)
 	if networkName != "" {
@@ -446,6 +732,27 @@ func IsEvhEnabled() bool {
 	return false
 }
 
+// IsPodReadinessGateEnabled returns true if AKO is configured to manage the
+// pool readiness gate condition on Pods which declare it.
+func IsPodReadinessGateEnabled() bool {
+	if ok, _ := strconv.ParseBool(os.Getenv(ENABLE_POD_READINESS_GATE)); ok {
+		return true
+	}
+	return false
+}
+
+// IsPodReadinessRuntimeCheckEnabled returns true if the pool readiness gate
+// condition should only be set once the pool server is reported up by the controller.
+func IsPodReadinessRuntimeCheckEnabled() bool {
+	if !IsPodReadinessGateEnabled() {
+		return false
+	}
+	if ok, _ := strconv.ParseBool(os.Getenv(READINESS_GATE_RUNTIME)); ok {
+		return true
+	}
+	return false
+}
+
 // If this flag is set to true, then AKO uses services API. Currently the support is limited for layer 4 Virtualservices
 func UseServicesAPI() bool {
 	if ok, _ := strconv.ParseBool(os.Getenv(SERVICES_API)); ok {
@@ -572,9 +879,15 @@ func VrfChecksum(vrfName string, staticRoutes []*models.StaticRoute) uint32 {
 	return utils.Hash(utils.Stringify(filteredStaticRoutes))
 }
 
-func DSChecksum(pgrefs []string) uint32 {
+func DSChecksum(pgrefs []string, scripts ...string) uint32 {
 	sort.Strings(pgrefs)
 	checksum := utils.Hash(utils.Stringify(pgrefs))
+	for _, script := range scripts {
+		// the default datascript is static, only the wildcard host datascript is generated
+		if strings.HasPrefix(script, WildcardDSScriptPrefix) {
+			checksum += utils.Hash(script)
+		}
+	}
 	return checksum
 }
 
@@ -613,6 +926,33 @@ func SSLKeyCertChecksum(sslName, certificate, cacert string) uint32 {
 	return utils.Hash(sslName + certificate + cacert)
 }
 
+func SSLProfileChecksum(sslProfileName string, acceptedVersions []string, acceptedCiphers, ciphersuites string) uint32 {
+	return utils.Hash(sslProfileName+acceptedCiphers+ciphersuites) + utils.Hash(utils.Stringify(acceptedVersions))
+}
+
+func SSOPolicyChecksum(ssoPolicyName, ssoType, authProfileName string, paths []string) uint32 {
+	return utils.Hash(ssoPolicyName+ssoType+authProfileName) + utils.Hash(utils.Stringify(paths))
+}
+
+func AuthProfileChecksum(authProfileName, jwtProfileName string) uint32 {
+	return utils.Hash(authProfileName + jwtProfileName)
+}
+
+func JWTProfileChecksum(jwtProfileName, issuer, jwksKeys string) uint32 {
+	return utils.Hash(jwtProfileName + issuer + jwksKeys)
+}
+
+func PersistenceProfileChecksum(profileName, persistenceType, cookieName, headerName string, timeout int32) uint32 {
+	return utils.Hash(profileName+persistenceType+cookieName+headerName) + utils.Hash(utils.Stringify(timeout))
+}
+
+func HealthMonitorChecksum(hmName, hmType, httpRequest string, httpResponseCodes []string, settings ...int32) uint32 {
+	codes := make([]string, len(httpResponseCodes))
+	copy(codes, httpResponseCodes)
+	sort.Strings(codes)
+	return utils.Hash(hmName+hmType+httpRequest) + utils.Hash(utils.Stringify(codes)) + utils.Hash(utils.Stringify(settings))
+}
+
 func L4PolicyChecksum(ports []int64, protocol string) uint32 {
 	var portsInt []int
 	for _, port := range ports {
@@ -702,7 +1042,7 @@ func GetPassthroughShardVSName(s string, key string) string {
 	return vsName
 }
 
-func VSVipChecksum(FQDNs []string, IPAddress string, networkName string) uint32 {
+func VSVipChecksum(FQDNs []string, IPAddress, IPv6Address, ipFamily string, networkName string) uint32 {
 	sort.Strings(FQDNs)
 	var checksum uint32
 	if len(FQDNs) != 0 {
@@ -711,6 +1051,12 @@ func VSVipChecksum(FQDNs []string, IPAddress string, networkName string) uint32
 	if IPAddress != "" {
 		checksum += utils.Hash(IPAddress)
 	}
+	if IPv6Address != "" {
+		checksum += utils.Hash(IPv6Address)
+	}
+	if ipFamily != "" && ipFamily != IPFamilyV4 {
+		checksum += utils.Hash(ipFamily)
+	}
 	if networkName != "" {
 		checksum += utils.Hash(networkName)
 	}
@@ -745,6 +1091,17 @@ func HasValidBackends(routeSpec routev1.RouteSpec, routeName, namespace, key str
 	return true
 }
 
+// GetRouteHostName returns the hostname with which a route is programmed, routes with
+// wildcardPolicy Subdomain are programmed as a wildcard host for the parent domain.
+func GetRouteHostName(routeSpec routev1.RouteSpec) string {
+	if routeSpec.WildcardPolicy == routev1.WildcardPolicySubdomain {
+		if domain := strings.SplitN(routeSpec.Host, ".", 2); len(domain) == 2 && domain[1] != "" {
+			return WildcardHostPrefix + domain[1]
+		}
+	}
+	return routeSpec.Host
+}
+
 func VSVipDelRequired() bool {
 	c, err := semver.NewConstraint(">= " + VSVIPDELCTRLVER)
 	if err == nil {
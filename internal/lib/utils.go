@@ -15,6 +15,7 @@
 package lib
 
 import (
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -145,6 +146,29 @@ func GetServicesForPod(pod *corev1.Pod) ([]string, []string) {
 	return svcList, lbList
 }
 
+// GetAllServicesForPod returns the Services of any type selecting the Pod,
+// along with the Services of type LoadBalancer among them.
+func GetAllServicesForPod(pod *corev1.Pod) ([]string, []string) {
+	var svcList, lbList []string
+	services, err := utils.GetInformers().ServiceInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("Got error while listing Services for Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return svcList, lbList
+	}
+
+	for _, svc := range services {
+		if !matchSvcSelectorPodLabels(svc.Spec.Selector, pod.GetLabels()) {
+			continue
+		}
+		svcKey := svc.Namespace + "/" + svc.Name
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			lbList = append(lbList, svcKey)
+		}
+		svcList = append(svcList, svcKey)
+	}
+	return svcList, lbList
+}
+
 func matchSvcSelectorPodLabels(svcSelector, podLabel map[string]string) bool {
 	if len(svcSelector) == 0 {
 		return false
@@ -172,3 +196,96 @@ func Difference(a, b []string) []string {
 	}
 	return diff
 }
+
+// HasPodReadinessGate returns true if the Pod declares the pool readiness gate managed by AKO.
+func HasPodReadinessGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == PodReadinessGateCondition {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPodReadinessGateSet returns true if the pool readiness condition is already true for the Pod.
+func IsPodReadinessGateSet(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == PodReadinessGateCondition {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// IsPodWaitingOnReadinessGate returns true if all containers of the Pod are ready and the
+// Pod is only waiting for the pool readiness gate condition to be set by AKO.
+func IsPodWaitingOnReadinessGate(pod *corev1.Pod) bool {
+	if !HasPodReadinessGate(pod) || IsPodReadinessGateSet(pod) {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.ContainersReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// IsWildcardHost returns true if the hostname is a wildcard host of the form *.example.com
+func IsWildcardHost(host string) bool {
+	return strings.HasPrefix(host, WildcardHostPrefix) && len(host) > len(WildcardHostPrefix)
+}
+
+// IsValidWildcardHost returns false if the wildcard character is used anywhere apart from
+// the complete left most label of the hostname.
+func IsValidWildcardHost(host string) bool {
+	if !strings.Contains(host, "*") {
+		return true
+	}
+	return IsWildcardHost(host) && !strings.Contains(strings.TrimPrefix(host, WildcardHostPrefix), "*")
+}
+
+// GetWildcardHostSuffix returns the domain suffix, along with the leading dot, that a wildcard host matches.
+func GetWildcardHostSuffix(host string) string {
+	return strings.TrimPrefix(host, "*")
+}
+
+// WildcardHostMatch returns true if the host is covered by the wildcard host.
+func WildcardHostMatch(wildcardHost, host string) bool {
+	if !IsWildcardHost(wildcardHost) || IsWildcardHost(host) {
+		return false
+	}
+	suffix := GetWildcardHostSuffix(wildcardHost)
+	return len(host) > len(suffix) && strings.HasSuffix(host, suffix)
+}
+
+// GetHTTPDataScript returns the datascript used by the shared L7 VS to select the pool for a request
+// based on the host/path priority labels. When wildcard hosts are present in the poolgroup, hosts that
+// do not have an exact match are mapped to the most specific wildcard host that covers them.
+func GetHTTPDataScript(pgName string, exactHosts, wildcardHosts []string) string {
+	script := strings.Replace(utils.HTTP_DS_SCRIPT, "POOLGROUP", pgName, 1)
+	if len(wildcardHosts) == 0 {
+		return script
+	}
+
+	sort.Strings(exactHosts)
+	sort.SliceStable(wildcardHosts, func(i, j int) bool {
+		if len(wildcardHosts[i]) != len(wildcardHosts[j]) {
+			return len(wildcardHosts[i]) > len(wildcardHosts[j])
+		}
+		return wildcardHosts[i] < wildcardHosts[j]
+	})
+
+	var exactEntries, wildcardEntries []string
+	for _, host := range exactHosts {
+		exactEntries = append(exactEntries, "[\""+host+"\"]=true")
+	}
+	for _, host := range wildcardHosts {
+		wildcardEntries = append(wildcardEntries, "\""+GetWildcardHostSuffix(host)+"\"")
+	}
+	wildcardScript := WildcardDSScriptPrefix +
+		"exact_hosts = {" + strings.Join(exactEntries, ", ") + "}\n" +
+		"wildcard_suffixes = {" + strings.Join(wildcardEntries, ", ") + "}\n" +
+		utils.HTTP_DS_WILDCARD_SCRIPT
+	return strings.Replace(wildcardScript, "POOLGROUP", pgName, 1)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// addAltSecret records the secret of an Ingress TLS entry listing a host which an earlier entry already
+// secures, as the alternate certificate of the host. Only one alternate certificate is supported per host.
+func addAltSecret(key string, tls *TlsSettings, host, secretName string) {
+	if secretName == "" || secretName == tls.SecretName {
+		return
+	}
+	if altSecret, ok := tls.altSecrets[host]; ok {
+		if altSecret != secretName {
+			utils.AviLog.Warnf("key: %s, msg: host %s already has the alternate certificate %s, ignoring secret %s",
+				key, host, altSecret, secretName)
+		}
+		return
+	}
+	if tls.altSecrets == nil {
+		tls.altSecrets = make(map[string]string)
+	}
+	tls.altSecrets[host] = secretName
+}
+
+// buildAltCertNode builds the alternate certificate of a host, nil if the host has none or it is not valid.
+// Its key must use another algorithm than the one of the primary certificate, so that the virtual service
+// can serve ECDSA certificates to the clients supporting them while keeping RSA for the others.
+func buildAltCertNode(key, namespace, objType, objName string, tlsData TlsSettings, certNode *AviTLSKeyCertNode, host string) *AviTLSKeyCertNode {
+	altSecret, ok := tlsData.altSecrets[host]
+	if !ok {
+		return nil
+	}
+	secretObj, err := utils.GetInformers().ClientSet.CoreV1().Secrets(namespace).Get(context.TODO(), altSecret, metav1.GetOptions{})
+	if err != nil || secretObj == nil {
+		utils.AviLog.Infof("key: %s, msg: alternate secret: %s of host %s not found, err: %v", key, altSecret, host, err)
+		return nil
+	}
+	altCertNode := &AviTLSKeyCertNode{
+		Name:   lib.GetAltTLSKeyCertNodeName(host),
+		Tenant: lib.GetTenant(),
+		Type:   lib.CertTypeVS,
+		Cert:   secretObj.Data[tlsCert],
+		Key:    secretObj.Data[utils.K8S_TLS_SECRET_KEY],
+	}
+	if len(altCertNode.Cert) == 0 || len(altCertNode.Key) == 0 {
+		utils.AviLog.Infof("key: %s, msg: key or certificate not found for alternate secret: %s", key, altSecret)
+		return nil
+	}
+	secret := namespace + "/" + altSecret
+	if !validateKeyCert(key, objType, namespace, objName, secret, altCertNode, []string{host}) {
+		return nil
+	}
+	if !validateCertAlgorithms(key, objType, namespace, objName, secret, certNode, altCertNode) {
+		lib.SharedCertExpiryStore().Delete(altCertNode.Name)
+		return nil
+	}
+	return altCertNode
+}
+
+// validateCertAlgorithms checks that the keys of the primary and the alternate certificates of a host use
+// different algorithms, the virtual service would otherwise always pick the same certificate.
+func validateCertAlgorithms(key, objType, namespace, objName, altSecret string, certNode, altCertNode *AviTLSKeyCertNode) bool {
+	cert, err := lib.ParseKeyCert(certNode.Cert, certNode.Key)
+	if err != nil || cert == nil {
+		return true
+	}
+	altCert, err := lib.ParseKeyCert(altCertNode.Cert, altCertNode.Key)
+	if err != nil || altCert == nil {
+		return true
+	}
+	if cert.PublicKeyAlgorithm != altCert.PublicKeyAlgorithm {
+		return true
+	}
+	msg := fmt.Sprintf("alternate certificate of %s uses the same key algorithm %s as the primary certificate, ignoring it",
+		altSecret, altCert.PublicKeyAlgorithm)
+	utils.AviLog.Warnf("key: %s, msg: %s", key, msg)
+	recordObjectEvent(objType, namespace, objName, corev1.EventTypeWarning, lib.InvalidCertificateReason, msg)
+	return false
+}
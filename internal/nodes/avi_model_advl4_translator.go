@@ -103,6 +103,7 @@ func (o *AviObjectGraph) ConstructAdvL4VsNode(gatewayName, namespace, key string
 			Name:       lib.GetL4VSVipName(gatewayName, namespace),
 			Tenant:     lib.GetTenant(),
 			EastWest:   false,
+			IPFamily:   lib.GetVipIPFamily(),
 			VrfContext: lib.GetVrf(),
 		}
 
@@ -110,9 +111,13 @@ func (o *AviObjectGraph) ConstructAdvL4VsNode(gatewayName, namespace, key string
 			vsVipNode.NetworkName = &networkName
 		}
 
-		if len(gw.Spec.Addresses) > 0 && gw.Spec.Addresses[0].Type == advl4v1alpha1pre1.IPAddressType {
-			vsVipNode.IPAddress = gw.Spec.Addresses[0].Value
+		var gwAddresses []string
+		for _, address := range gw.Spec.Addresses {
+			if address.Type == advl4v1alpha1pre1.IPAddressType {
+				gwAddresses = append(gwAddresses, address.Value)
+			}
 		}
+		setVsVipStaticAddresses(vsVipNode, gwAddresses, key)
 
 		avi_vs_meta.VSVIPRefs = append(avi_vs_meta.VSVIPRefs, vsVipNode)
 		utils.AviLog.Infof("key: %s, msg: created vs object: %s", key, utils.Stringify(avi_vs_meta))
@@ -176,6 +181,7 @@ func (o *AviObjectGraph) ConstructSvcApiL4VsNode(gatewayName, namespace, key str
 			Name:       lib.GetL4VSVipName(gatewayName, namespace),
 			Tenant:     lib.GetTenant(),
 			EastWest:   false,
+			IPFamily:   lib.GetVipIPFamily(),
 			VrfContext: lib.GetVrf(),
 		}
 
@@ -186,9 +192,13 @@ func (o *AviObjectGraph) ConstructSvcApiL4VsNode(gatewayName, namespace, key str
 		// configures VS and VsVip nodes using infraSetting object (via CRD).
 		buildL4InfraSetting(key, avi_vs_meta, vsVipNode, nil, &gw.Spec.GatewayClassName)
 
-		if len(gw.Spec.Addresses) > 0 && gw.Spec.Addresses[0].Type == svcapiv1alpha1.IPAddressType {
-			vsVipNode.IPAddress = gw.Spec.Addresses[0].Value
+		var gwAddresses []string
+		for _, address := range gw.Spec.Addresses {
+			if address.Type == svcapiv1alpha1.IPAddressType {
+				gwAddresses = append(gwAddresses, address.Value)
+			}
 		}
+		setVsVipStaticAddresses(vsVipNode, gwAddresses, key)
 
 		avi_vs_meta.VSVIPRefs = append(avi_vs_meta.VSVIPRefs, vsVipNode)
 		utils.AviLog.Infof("key: %s, msg: created vs object: %s", key, utils.Stringify(avi_vs_meta))
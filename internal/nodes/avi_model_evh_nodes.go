@@ -56,6 +56,9 @@ type AviVsEvhSniModel interface {
 	GetSSLKeyCertAviRef() string
 	SetSSLKeyCertAviRef(string)
 
+	GetAltSSLKeyCertAviRef() string
+	SetAltSSLKeyCertAviRef(string)
+
 	GetWafPolicyRef() string
 	SetWafPolicyRef(string)
 
@@ -65,6 +68,15 @@ type AviVsEvhSniModel interface {
 	GetAppProfileRef() string
 	SetAppProfileRef(string)
 
+	GetAppProfileNode() *AviAppProfileNode
+	SetAppProfileNode(*AviAppProfileNode)
+
+	GetSSLProfileNode() *AviSSLProfileNode
+	SetSSLProfileNode(*AviSSLProfileNode)
+
+	GetSSOPolicyNode() *AviSSOPolicyNode
+	SetSSOPolicyNode(*AviSSOPolicyNode)
+
 	GetAnalyticsProfileRef() string
 	SetAnalyticsProfileRef(string)
 
@@ -119,6 +131,10 @@ type AviEvhVsNode struct {
 	VsDatascriptRefs    []string
 	SSLProfileRef       string
 	SSLKeyCertAviRef    string
+	AltSSLKeyCertAviRef string
+	AppProfileNode      *AviAppProfileNode
+	SSLProfileNode      *AviSSLProfileNode
+	SSOPolicyNode       *AviSSOPolicyNode
 }
 
 // Implementing AviVsEvhSniModel
@@ -179,6 +195,14 @@ func (v *AviEvhVsNode) SetSSLKeyCertAviRef(sslKeyCertAviRef string) {
 	v.SSLKeyCertAviRef = sslKeyCertAviRef
 }
 
+func (v *AviEvhVsNode) GetAltSSLKeyCertAviRef() string {
+	return v.AltSSLKeyCertAviRef
+}
+
+func (v *AviEvhVsNode) SetAltSSLKeyCertAviRef(altSSLKeyCertAviRef string) {
+	v.AltSSLKeyCertAviRef = altSSLKeyCertAviRef
+}
+
 func (v *AviEvhVsNode) GetWafPolicyRef() string {
 	return v.WafPolicyRef
 }
@@ -203,6 +227,30 @@ func (v *AviEvhVsNode) SetAppProfileRef(appProfileRef string) {
 	v.AppProfileRef = appProfileRef
 }
 
+func (v *AviEvhVsNode) GetAppProfileNode() *AviAppProfileNode {
+	return v.AppProfileNode
+}
+
+func (v *AviEvhVsNode) SetAppProfileNode(appProfileNode *AviAppProfileNode) {
+	v.AppProfileNode = appProfileNode
+}
+
+func (v *AviEvhVsNode) GetSSLProfileNode() *AviSSLProfileNode {
+	return v.SSLProfileNode
+}
+
+func (v *AviEvhVsNode) SetSSLProfileNode(sslProfileNode *AviSSLProfileNode) {
+	v.SSLProfileNode = sslProfileNode
+}
+
+func (v *AviEvhVsNode) GetSSOPolicyNode() *AviSSOPolicyNode {
+	return v.SSOPolicyNode
+}
+
+func (v *AviEvhVsNode) SetSSOPolicyNode(ssoPolicyNode *AviSSOPolicyNode) {
+	v.SSOPolicyNode = ssoPolicyNode
+}
+
 func (v *AviEvhVsNode) GetAnalyticsProfileRef() string {
 	return v.AnalyticsProfileRef
 }
@@ -260,6 +308,33 @@ func (v *AviEvhVsNode) GetCheckSum() uint32 {
 	return v.CloudConfigCksum
 }
 
+func (v *AviEvhVsNode) getContentCheckSum() uint32 {
+	checksum := v.GetCheckSum()
+	for _, pool := range v.PoolRefs {
+		checksum += pool.GetCheckSum()
+	}
+	for _, pg := range v.PoolGroupRefs {
+		checksum += pg.GetCheckSum()
+	}
+	for _, evh := range v.EvhNodes {
+		checksum += evh.getContentCheckSum()
+	}
+	return checksum
+}
+
+// HasHTTP2Pools checks the pools of the EVH children, the SSL ports of the parent are
+// enabled for HTTP/2 when any of the pools speaks HTTP/2 to its servers
+func (v *AviEvhVsNode) HasHTTP2Pools() bool {
+	for _, evhNode := range v.EvhNodes {
+		for _, pool := range evhNode.PoolRefs {
+			if pool.EnableHTTP2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (v *AviEvhVsNode) GetEvhNodeForName(EVHNodeName string) *AviEvhVsNode {
 	for _, evhNode := range v.EvhNodes {
 		if evhNode.Name == EVHNodeName {
@@ -439,6 +514,21 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		vsvipChecksum += vsvipref.GetCheckSum()
 	}
 
+	if v.AppProfileNode != nil {
+		sslkeyChecksum += v.AppProfileNode.GetCheckSum()
+		if v.AppProfileNode.PkiProfile != nil {
+			sslkeyChecksum += v.AppProfileNode.PkiProfile.GetCheckSum()
+		}
+	}
+
+	if v.SSLProfileNode != nil {
+		sslkeyChecksum += v.SSLProfileNode.GetCheckSum()
+	}
+
+	if v.SSOPolicyNode != nil {
+		sslkeyChecksum += v.SSOPolicyNode.GetVsCheckSum()
+	}
+
 	// keep the order of these policies
 	policies := v.HttpPolicySetRefs
 	scripts := v.VsDatascriptRefs
@@ -454,6 +544,10 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		vsRefs += utils.Stringify(scripts)
 	}
 
+	if v.AltSSLKeyCertAviRef != "" {
+		vsRefs += v.AltSSLKeyCertAviRef
+	}
+
 	checksum := dsChecksum +
 		httppolChecksum +
 		evhChecksum +
@@ -475,6 +569,10 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		checksum += utils.Hash(utils.Stringify(*v.EnableRhi))
 	}
 
+	if v.HasHTTP2Pools() {
+		checksum += utils.Hash(utils.Stringify(v.HasHTTP2Pools()))
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -566,6 +664,7 @@ func (o *AviObjectGraph) ConstructAviL7SharedVsNodeForEvh(vsName string, key str
 		Tenant:     lib.GetTenant(),
 		FQDNs:      fqdns,
 		EastWest:   false,
+		IPFamily:   lib.GetVipIPFamily(),
 		VrfContext: vrfcontext,
 	}
 
@@ -579,6 +678,8 @@ func (o *AviObjectGraph) ConstructAviL7SharedVsNodeForEvh(vsName string, key str
 
 func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childNode *AviEvhVsNode, namespace string, ingName string, key string, isIngr bool, host string, paths []IngressHostPathSvc) {
 	localPGList := make(map[string]*AviPoolGroupNode)
+	localPolicyList := make(map[string]*AviHttpPolicySetNode)
+	var poolNames []string
 
 	// Update the VSVIP with the host information.
 	if !utils.HasElem(vsNode[0].VSVIPRefs[0].FQDNs, host) {
@@ -604,7 +705,15 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 			httpPGPath.Path = append(httpPGPath.Path, path.Path)
 		}
 
-		pgName := lib.GetEvhVsPoolNPgName(ingName, namespace, host, path.Path)
+		var poolName string
+		if path.httpRuleBackend {
+			poolName = lib.GetEvhVsPoolNPgName(ingName, namespace, host, path.Path, path.ServiceName)
+		} else {
+			poolName = lib.GetEvhVsPoolNPgName(ingName, namespace, host, path.Path)
+		}
+		poolNames = append(poolNames, poolName)
+
+		pgName := getPathPGName(lib.GetEvhVsPoolNPgName(ingName, namespace, host, path.Path), poolName, path)
 		var pgNode *AviPoolGroupNode
 		// There can be multiple services for the same path in case of alternate backend.
 		// In that case, make sure we are creating only one PG per path
@@ -617,14 +726,14 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 			httpPolicySet = append(httpPolicySet, httpPGPath)
 		}
 
-		var poolName string
-		poolName = lib.GetEvhVsPoolNPgName(ingName, namespace, host, path.Path)
 		hostSlice := []string{host}
 		poolNode := &AviPoolNode{
-			Name:       poolName,
-			PortName:   path.PortName,
-			Tenant:     lib.GetTenant(),
-			VrfContext: lib.GetVrf(),
+			Name:            poolName,
+			PortName:        path.PortName,
+			Tenant:          lib.GetTenant(),
+			VrfContext:      lib.GetVrf(),
+			HTTPRuleBackend: path.httpRuleBackend,
+			EnableHTTP2:     path.isHTTP2Backend(namespace),
 			ServiceMetadata: avicache.ServiceMetadataObj{
 				IngressName: ingName,
 				Namespace:   namespace,
@@ -634,15 +743,15 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePortLocal {
-			if servers := PopulateServersForNPL(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNPL(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else if serviceType == lib.NodePort {
-			if servers := PopulateServersForNodePort(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNodePort(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else {
-			if servers := PopulateServers(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+			if servers := PopulateServers(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		}
@@ -661,12 +770,26 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 		o.AddModelNode(poolNode)
 		if !pgfound {
 			httppolname := lib.GetSniHttpPolName(ingName, namespace, host, path.Path)
-			policyNode := &AviHttpPolicySetNode{Name: httppolname, HppMap: httpPolicySet, Tenant: lib.GetTenant()}
+			policyNode, policyFound := localPolicyList[httppolname]
+			if !policyFound {
+				policyNode = &AviHttpPolicySetNode{Name: httppolname, Tenant: lib.GetTenant()}
+				localPolicyList[httppolname] = policyNode
+			}
+			if path.canary != nil {
+				// the canary switching rule is evaluated ahead of the path switching rule
+				httpPGPath.Canary = path.canary
+				policyNode.HppMap = append([]AviHostPathPortPoolPG{httpPGPath}, policyNode.HppMap...)
+			} else {
+				policyNode.HppMap = append(policyNode.HppMap, httpPolicySet...)
+			}
 			if childNode.CheckHttpPolNameNChecksumForEvh(httppolname, policyNode.GetCheckSum()) {
 				childNode.ReplaceHTTPRefInNodeForEvh(policyNode, key)
 			}
 		}
 	}
+	if isIngr {
+		removeStaleHTTPRuleBackendPools(childNode, ingName, namespace, host, poolNames, key)
+	}
 	for _, path := range paths {
 		BuildPoolHTTPRule(host, path.Path, ingName, namespace, key, childNode, true)
 	}
@@ -688,7 +811,10 @@ func ProcessInsecureHostsForEVH(routeIgrObj RouteIngressModel, key string, parse
 				// Marking the entry as None to handle delete stale config
 				utils.AviLog.Debugf("key: %s, msg: Marking the entry as None to handle delete stale config %s", key, utils.Stringify(pathSvcDiff))
 				Storedhosts[host].InsecurePolicy = lib.PolicyNone
-				Storedhosts[host].SecurePolicy = lib.PolicyNone
+				// a passthrough host is not on the EVH shard, its passthrough objects are still to be removed
+				if hostData.SecurePolicy != lib.PolicyPass {
+					Storedhosts[host].SecurePolicy = lib.PolicyNone
+				}
 			} else {
 				hostData.PathSvc = pathSvcDiff
 			}
@@ -755,6 +881,7 @@ func ProcessInsecureHostsForEVH(routeIgrObj RouteIngressModel, key string, parse
 		}
 		// build host rule for insecure ingress in evh
 		BuildL7HostRule(host, namespace, ingName, key, evhNode)
+		BuildL7AuthRule(host, key, evhNode)
 		utils.AviLog.Debugf("key: %s, Saving Model in ProcessInsecureHostsForEVH : %v", key, utils.Stringify(vsNode))
 		changedModel := saveAviModel(modelName, aviModel.(*AviObjectGraph), key)
 		if !utils.HasElem(modelList, modelName) && changedModel {
@@ -784,7 +911,7 @@ func (o *AviObjectGraph) BuildCACertNodeForEvh(tlsNode *AviEvhVsNode, cacert, ke
 	return cacertNode.Name
 }
 
-func (o *AviObjectGraph) BuildTlsCertNodeForEvh(svcLister *objects.SvcLister, tlsNode *AviEvhVsNode, namespace string, tlsData TlsSettings, key string, host ...string) bool {
+func (o *AviObjectGraph) BuildTlsCertNodeForEvh(svcLister *objects.SvcLister, tlsNode *AviEvhVsNode, namespace, objType, objName string, tlsData TlsSettings, key string, host ...string) bool {
 	mClient := utils.GetInformers().ClientSet
 	secretName := tlsData.SecretName
 	secretNS := tlsData.SecretNS
@@ -850,11 +977,21 @@ func (o *AviObjectGraph) BuildTlsCertNodeForEvh(svcLister *objects.SvcLister, tl
 		}
 		utils.AviLog.Infof("key: %s, msg: Added the secret object to tlsnode: %s", key, secretObj.Name)
 	}
+	if !validateKeyCert(key, objType, namespace, objName, secretNS+"/"+secretName, certNode, getTLSHosts(tlsData, host)) {
+		return false
+	}
 	// If this SSLCertRef is already present don't add it.
 	if len(host) > 0 {
 		if tlsNode.CheckSSLCertNodeNameNChecksum(lib.GetTLSKeyCertNodeName(namespace, secretName, host[0]), certNode.GetCheckSum()) {
 			tlsNode.ReplaceEvhSSLRefInEVHNode(certNode, key)
 		}
+		if altCertNode := buildAltCertNode(key, namespace, objType, objName, tlsData, certNode, host[0]); altCertNode != nil {
+			if tlsNode.CheckSSLCertNodeNameNChecksum(altCertNode.Name, altCertNode.GetCheckSum()) {
+				tlsNode.ReplaceEvhSSLRefInEVHNode(altCertNode, key)
+			}
+		} else {
+			tlsNode.DeleteSSLRefInEVHNode(lib.GetAltTLSKeyCertNodeName(host[0]), key)
+		}
 	} else {
 		tlsNode.SSLKeyCertRefs = append(tlsNode.SSLKeyCertRefs, certNode)
 	}
@@ -891,7 +1028,7 @@ func ProcessSecureHostsForEVH(routeIgrObj RouteIngressModel, key string, parsedI
 				}
 			}
 			hostsMap[host].SecurePolicy = lib.PolicyEdgeTerm
-			if tlssetting.redirect == true {
+			if redirect, _ := getHostRuleRedirect(host, tlssetting.redirect); redirect {
 				hostsMap[host].InsecurePolicy = lib.PolicyRedirect
 			}
 			hostsMap[host].PathSvc = getPathSvc(newPathSvc)
@@ -977,7 +1114,7 @@ func evhNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 		}
 		evhNode.VrfContext = lib.GetVrf()
 		if !certsBuilt {
-			certsBuilt = aviModel.(*AviObjectGraph).BuildTlsCertNodeForEvh(routeIgrObj.GetSvcLister(), vsNode[0], namespace, tlssetting, key, host)
+			certsBuilt = aviModel.(*AviObjectGraph).BuildTlsCertNodeForEvh(routeIgrObj.GetSvcLister(), vsNode[0], namespace, routeIgrObj.GetType(), ingName, tlssetting, key, host)
 		}
 		if certsBuilt {
 			isIngr := routeIgrObj.GetType() == utils.Ingress
@@ -989,11 +1126,12 @@ func evhNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 
 			RemoveRedirectHTTPPolicyInModelForEvh(vsNode[0], host, key)
 
-			if tlssetting.redirect == true {
-				aviModel.(*AviObjectGraph).BuildPolicyRedirectForVSForEvh(vsNode, host, namespace, ingName, key)
+			if redirect, statusCode := getHostRuleRedirect(host, tlssetting.redirect); redirect {
+				aviModel.(*AviObjectGraph).BuildPolicyRedirectForVSForEvh(vsNode, host, namespace, ingName, key, statusCode)
 			}
 			// Enable host rule
 			BuildL7HostRule(host, namespace, ingName, key, evhNode)
+			BuildL7AuthRule(host, key, evhNode)
 		} else {
 			hostMapOk, ingressHostMap := SharedHostNameLister().Get(host)
 			if hostMapOk {
@@ -1005,6 +1143,7 @@ func evhNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 			// Since the cert couldn't be built, check if this EVH is affected by only in ingress if so remove the EVH node from the model
 			if len(ingressHostMap.GetIngressesForHostName(host)) == 0 {
 				vsNode[0].DeleteSSLRefInEVHNode(lib.GetTLSKeyCertNodeName(namespace, tlssetting.SecretName, host), key)
+				vsNode[0].DeleteSSLRefInEVHNode(lib.GetAltTLSKeyCertNodeName(host), key)
 				RemoveEvhInModel(evhNode.Name, vsNode, key)
 				RemoveRedirectHTTPPolicyInModelForEvh(vsNode[0], host, key)
 			}
@@ -1055,8 +1194,7 @@ func RemoveEvhInModel(currentEvhNodeName string, modelEvhNodes []*AviEvhVsNode,
 func FindAndReplaceRedirectHTTPPolicyInModelforEvh(vsNode *AviEvhVsNode, httpPolicy *AviHttpPolicySetNode, hostname, key string) bool {
 	for _, policy := range vsNode.HttpPolicyRefs {
 		if policy.Name == httpPolicy.Name && policy.CloudConfigCksum != httpPolicy.CloudConfigCksum {
-			if !utils.HasElem(policy.RedirectPorts[0].Hosts, hostname) {
-				policy.RedirectPorts[0].Hosts = append(policy.RedirectPorts[0].Hosts, hostname)
+			if addHostToRedirectPorts(policy, hostname, httpPolicy.RedirectPorts[0]) {
 				utils.AviLog.Infof("key: %s, msg: replaced host %s for policy %s in model", key, hostname, policy.Name)
 			}
 			return true
@@ -1067,20 +1205,16 @@ func FindAndReplaceRedirectHTTPPolicyInModelforEvh(vsNode *AviEvhVsNode, httpPol
 
 func RemoveRedirectHTTPPolicyInModelForEvh(vsNode *AviEvhVsNode, hostname, key string) {
 	policyName := lib.GetL7HttpRedirPolicy(vsNode.Name)
-	deletePolicy := false
 	for i, policy := range vsNode.HttpPolicyRefs {
 		if policy.Name == policyName {
 			// one redirect policy per shard vs
-			policy.RedirectPorts[0].Hosts = utils.Remove(policy.RedirectPorts[0].Hosts, hostname)
-			utils.AviLog.Infof("key: %s, msg: removed host %s from policy %s in model %v", key, hostname, policy.Name, policy.RedirectPorts[0].Hosts)
-			if len(policy.RedirectPorts[0].Hosts) == 0 {
-				deletePolicy = true
-			}
-
-			if deletePolicy {
+			removeHostFromRedirectPorts(policy, hostname)
+			utils.AviLog.Infof("key: %s, msg: removed host %s from policy %s in model %v", key, hostname, policy.Name, utils.Stringify(policy.RedirectPorts))
+			if len(policy.RedirectPorts) == 0 {
 				vsNode.HttpPolicyRefs = append(vsNode.HttpPolicyRefs[:i], vsNode.HttpPolicyRefs[i+1:]...)
 				utils.AviLog.Infof("key: %s, msg: removed policy %s in model", key, policy.Name)
 			}
+			return
 		}
 	}
 }
@@ -1104,7 +1238,9 @@ func DeleteStaleDataForEvh(routeIgrObj RouteIngressModel, key string, modelList
 	for host, hostData := range Storedhosts {
 		utils.AviLog.Debugf("host to del: %s, data : %s", host, utils.Stringify(hostData))
 		shardVsName := DeriveHostNameShardVSForEvh(host, key)
-
+		if hostData.SecurePolicy == lib.PolicyPass {
+			shardVsName = lib.GetPassthroughShardVSName(host, key)
+		}
 		if shardVsName == "" {
 			// If we aren't able to derive the ShardVS name, we should return
 			return
@@ -1131,8 +1267,11 @@ func DeleteStaleDataForEvh(routeIgrObj RouteIngressModel, key string, modelList
 		// Delete the pool corresponding to this host
 		if hostData.SecurePolicy == lib.PolicyEdgeTerm {
 			aviModel.(*AviObjectGraph).DeletePoolForHostnameForEvh(shardVsName, host, routeIgrObj, hostData.PathSvc, key, removeFqdn, removeRedir, true)
+		} else if hostData.SecurePolicy == lib.PolicyPass {
+			aviModel.(*AviObjectGraph).DeleteObjectsForPassthroughHost(shardVsName, host, routeIgrObj, hostData.PathSvc, key, removeFqdn, removeRedir, true)
 		}
-		if hostData.InsecurePolicy != lib.PolicyNone {
+		// the redirect of a passthrough host is removed along with its passthrough objects
+		if hostData.InsecurePolicy != lib.PolicyNone && hostData.SecurePolicy != lib.PolicyPass {
 			aviModel.(*AviObjectGraph).DeletePoolForHostnameForEvh(shardVsName, host, routeIgrObj, hostData.PathSvc, key, removeFqdn, removeRedir, false)
 
 		}
@@ -1206,13 +1345,22 @@ func (o *AviObjectGraph) ManipulateEvhNode(currentEvhNodeName, ingName, namespac
 			continue
 		}
 
-		for path := range pathSvc {
+		for path, services := range pathSvc {
 			pgName := lib.GetEvhVsPoolNPgName(ingName, namespace, hostname, path)
 			pgNode := modelEvhNode.GetPGForVSByName(pgName)
 			var evhPool string
 			evhPool = lib.GetEvhVsPoolNPgName(ingName, namespace, hostname, path)
 			o.RemovePoolNodeRefsFromEvh(evhPool, modelEvhNode)
 			o.RemovePoolRefsFromPG(evhPool, pgNode)
+			if isIngr {
+				// pools of the backends added via HTTPRule are named with the service
+				for _, svc := range services {
+					evhPool = lib.GetEvhVsPoolNPgName(ingName, namespace, hostname, path, svc)
+					o.RemovePoolNodeRefsFromEvh(evhPool, modelEvhNode)
+					o.RemovePoolRefsFromPG(evhPool, pgNode)
+					o.RemovePGNodeRefsForEvh(evhPool, modelEvhNode)
+				}
+			}
 			// Remove the EVH PG if it has no member
 			if pgNode != nil {
 				if len(pgNode.Members) == 0 {
@@ -1272,6 +1420,7 @@ func (o *AviObjectGraph) DeletePoolForHostnameForEvh(vsName, hostname string, ro
 	if !keepEvh {
 		// Delete the cert ref for the host
 		vsNode[0].DeleteSSLRefInEVHNode(lib.GetTLSKeyCertNodeName(namespace, lib.GetTLSKeyCertNodeName(namespace, "", hostname), hostname), key)
+		vsNode[0].DeleteSSLRefInEVHNode(lib.GetAltTLSKeyCertNodeName(hostname), key)
 	}
 	if removeFqdn && !keepEvh {
 		var hosts []string
@@ -1299,7 +1448,7 @@ func (o *AviObjectGraph) RemoveEvhVsNode(evhVsName string, vsNode []*AviEvhVsNod
 	return true
 }
 
-func (o *AviObjectGraph) BuildPolicyRedirectForVSForEvh(vsNode []*AviEvhVsNode, hostname string, namespace, ingName, key string) {
+func (o *AviObjectGraph) BuildPolicyRedirectForVSForEvh(vsNode []*AviEvhVsNode, hostname string, namespace, ingName, key string, statusCode ...string) {
 	policyname := lib.GetL7HttpRedirPolicy(vsNode[0].Name)
 	myHppMap := AviRedirectPort{
 		Hosts:        []string{hostname},
@@ -1307,6 +1456,9 @@ func (o *AviObjectGraph) BuildPolicyRedirectForVSForEvh(vsNode []*AviEvhVsNode,
 		StatusCode:   lib.STATUS_REDIRECT,
 		VsPort:       80,
 	}
+	if len(statusCode) > 0 && statusCode[0] != "" {
+		myHppMap.StatusCode = statusCode[0]
+	}
 
 	redirectPolicy := &AviHttpPolicySetNode{
 		Tenant:        lib.GetTenant(),
@@ -108,6 +108,7 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		Tenant:     lib.GetTenant(),
 		FQDNs:      fqdns,
 		EastWest:   false,
+		IPFamily:   lib.GetVipIPFamily(),
 		VrfContext: vrfcontext,
 	}
 
@@ -118,8 +119,9 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 	// configures VS and VsVip nodes using infraSetting object (via CRD).
 	buildL4InfraSetting(key, avi_vs_meta, vsVipNode, svcObj, nil)
 
+	vsVipNode.IPFamily = lib.GetServiceIPFamily(svcObj, vsVipNode.IPFamily)
 	if svcObj.Spec.LoadBalancerIP != "" {
-		vsVipNode.IPAddress = svcObj.Spec.LoadBalancerIP
+		setVsVipStaticAddresses(vsVipNode, []string{svcObj.Spec.LoadBalancerIP}, key)
 	}
 
 	avi_vs_meta.VSVIPRefs = append(avi_vs_meta.VSVIPRefs, vsVipNode)
@@ -200,6 +202,7 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 		targetPorts[port.TargetPort.IntValue()] = true
 	}
 
+	serverPods := make(objects.PoolServerPods)
 	for _, pod := range pods {
 		var annotations []lib.NPLAnnotation
 		found, obj := objects.SharedNPLLister().Get(ns + "/" + pod.Name)
@@ -221,8 +224,11 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 					Type: &atype,
 				}}
 			poolMeta = append(poolMeta, server)
+			serverKey := fmt.Sprintf("%s:%d", a.NodeIP, a.NodePort)
+			serverPods[serverKey] = append(serverPods[serverKey], pod)
 		}
 	}
+	savePoolServerPods(poolNode.Name, serverPods, key)
 	utils.AviLog.Infof("key: %s, msg: servers for port: %v, are: %v", key, poolNode.Port, utils.Stringify(poolMeta))
 	return poolMeta
 }
@@ -298,6 +304,11 @@ func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName st
 		}
 	}
 
+	if lib.IsPodReadinessGateEnabled() && len(poolMeta) > 0 {
+		// every node server fronts all the Pods of the Service in NodePort mode.
+		serverPods := objects.PoolServerPods{objects.AnyPoolServer: lib.GetPodsFromService(ns, serviceName)}
+		savePoolServerPods(poolNode.Name, serverPods, key)
+	}
 	return poolMeta
 }
 
@@ -317,6 +328,7 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 		return nil
 	}
 	var pool_meta []AviPoolMetaServer
+	serverPods := make(objects.PoolServerPods)
 	for _, ss := range epObj.Subsets {
 		port_match := false
 		for _, epp := range ss.Ports {
@@ -334,7 +346,13 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 		if port_match {
 			var atype string
 			utils.AviLog.Infof("key: %s, msg: found port match for port %v", key, poolNode.Port)
-			for _, addr := range ss.Addresses {
+			addresses := ss.Addresses
+			if lib.IsPodReadinessGateEnabled() {
+				// Pods waiting on the pool readiness gate are not ready yet, and would never
+				// be added to the pool unless picked up from the not ready addresses.
+				addresses = append(addresses, getReadinessGatePendingAddresses(ss.NotReadyAddresses)...)
+			}
+			for _, addr := range addresses {
 
 				ip := addr.IP
 				if utils.IsV4(addr.IP) {
@@ -348,13 +366,47 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 					server.ServerNode = *addr.NodeName
 				}
 				pool_meta = append(pool_meta, server)
+				if addr.TargetRef != nil && addr.TargetRef.Kind == utils.Pod {
+					serverKey := fmt.Sprintf("%s:%d", ip, poolNode.Port)
+					serverPods[serverKey] = append(serverPods[serverKey], utils.NamespaceName{Namespace: addr.TargetRef.Namespace, Name: addr.TargetRef.Name})
+				}
 			}
 		}
 	}
+	savePoolServerPods(poolNode.Name, serverPods, key)
 	utils.AviLog.Infof("key: %s, msg: servers for port: %v, are: %v", key, poolNode.Port, utils.Stringify(pool_meta))
 	return pool_meta
 }
 
+// getReadinessGatePendingAddresses returns the not ready endpoint addresses whose
+// Pods are only waiting on the pool readiness gate to be set by AKO.
+func getReadinessGatePendingAddresses(notReadyAddresses []corev1.EndpointAddress) []corev1.EndpointAddress {
+	var addresses []corev1.EndpointAddress
+	for _, addr := range notReadyAddresses {
+		if addr.TargetRef == nil || addr.TargetRef.Kind != utils.Pod {
+			continue
+		}
+		pod, err := utils.GetInformers().PodInformer.Lister().Pods(addr.TargetRef.Namespace).Get(addr.TargetRef.Name)
+		if err != nil {
+			continue
+		}
+		if lib.IsPodWaitingOnReadinessGate(pod) {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
+// savePoolServerPods records the Pods fronted by the servers of a pool, so that
+// the rest layer can set the pool readiness gate on them once the pool is pushed.
+func savePoolServerPods(poolName string, serverPods objects.PoolServerPods, key string) {
+	if !lib.IsPodReadinessGateEnabled() || poolName == "" {
+		return
+	}
+	utils.AviLog.Debugf("key: %s, msg: pods for pool %s servers: %v", key, poolName, utils.Stringify(serverPods))
+	objects.SharedPoolPodLister().Save(poolName, serverPods)
+}
+
 func (o *AviObjectGraph) BuildL4LBGraph(namespace string, svcName string, key string) {
 	o.Lock.Lock()
 	defer o.Lock.Unlock()
@@ -431,5 +483,34 @@ func buildL4InfraSetting(key string, vs *AviVsNode, vsvip *AviVSVIPNode, svc *co
 		}
 
 		vsvip.NetworkName = &infraSetting.Spec.Network.Name
+
+		if infraSetting.Spec.Network.IPFamily != "" {
+			vsvip.IPFamily = infraSetting.Spec.Network.IPFamily
+		}
+	}
+}
+
+// setVsVipStaticAddresses sets the static IPv4 and IPv6 VIP addresses, and requests a dual-stack
+// VIP when addresses of both families are provided. A lone IPv4 address is not used for an IPv6 VIP.
+func setVsVipStaticAddresses(vsvip *AviVSVIPNode, addresses []string, key string) {
+	var ipv4Address string
+	for _, address := range addresses {
+		if utils.IsV4(address) {
+			if ipv4Address == "" {
+				ipv4Address = address
+			}
+		} else if vsvip.IPv6Address == "" {
+			vsvip.IPv6Address = address
+		}
+	}
+	if ipv4Address != "" && vsvip.IPv6Address != "" {
+		vsvip.IPAddress = ipv4Address
+		vsvip.IPFamily = lib.IPFamilyV4V6
+	} else if vsvip.IPv6Address != "" && !lib.IsIPv6Family(vsvip.IPFamily) {
+		vsvip.IPFamily = lib.IPFamilyV6
+	} else if ipv4Address != "" && vsvip.IPFamily == lib.IPFamilyV6 {
+		utils.AviLog.Warnf("key: %s, msg: static IPv4 address %s not used for the IPv6 vip %s", key, ipv4Address, vsvip.Name)
+	} else if ipv4Address != "" {
+		vsvip.IPAddress = ipv4Address
 	}
 }
@@ -44,7 +44,9 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 	}
 	var priorityLabel string
 	var poolName string
+	var poolNames []string
 	utils.AviLog.Infof("key: %s, msg: The pathsvc mapping: %v", key, pathsvc)
+	pathsvc = filterHTTPRuleBackendsForInsecureHost(key, hostname, pathsvc)
 	for _, obj := range pathsvc {
 		if obj.Path != "" {
 			priorityLabel = hostname + obj.Path
@@ -54,11 +56,12 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 
 		// Using servciename in poolname for routes, but not in ingress for consistency with existing naming convention.
 		// If possible, we would make this uniform
-		if routeIgrObj.GetType() == utils.Ingress {
+		if routeIgrObj.GetType() == utils.Ingress && !obj.httpRuleBackend {
 			poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName)
 		} else {
 			poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, obj.ServiceName)
 		}
+		poolNames = append(poolNames, poolName)
 
 		// First check if there are pools related to this ingress present in the model already
 		poolNodes := o.GetAviPoolNodesByIngress(namespace, ingName)
@@ -81,13 +84,15 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 			}
 
 			poolNode := &AviPoolNode{
-				Name:          poolName,
-				IngressName:   ingName,
-				PortName:      obj.PortName,
-				Tenant:        lib.GetTenant(),
-				PriorityLabel: priorityLabel,
-				Port:          obj.Port,
-				TargetPort:    obj.TargetPort,
+				Name:            poolName,
+				IngressName:     ingName,
+				PortName:        obj.PortName,
+				Tenant:          lib.GetTenant(),
+				PriorityLabel:   priorityLabel,
+				Port:            obj.Port,
+				TargetPort:      obj.TargetPort,
+				HTTPRuleBackend: obj.httpRuleBackend,
+				EnableHTTP2:     obj.isHTTP2Backend(namespace),
 				ServiceMetadata: avicache.ServiceMetadataObj{
 					IngressName: ingName,
 					Namespace:   namespace,
@@ -98,15 +103,15 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 			poolNode.VrfContext = lib.GetVrf()
 			serviceType := lib.GetServiceType()
 			if serviceType == lib.NodePortLocal {
-				if servers := PopulateServersForNPL(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+				if servers := PopulateServersForNPL(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			} else if serviceType == lib.NodePort {
-				if servers := PopulateServersForNodePort(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+				if servers := PopulateServersForNodePort(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			} else {
-				if servers := PopulateServers(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+				if servers := PopulateServers(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			}
@@ -117,6 +122,9 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 		}
 
 	}
+	if routeIgrObj.GetType() == utils.Ingress {
+		removeStaleHTTPRuleBackendPools(vsNode[0], ingName, namespace, hostname, poolNames, key)
+	}
 	for _, obj := range pathsvc {
 		BuildPoolHTTPRule(hostname, obj.Path, ingName, namespace, key, vsNode[0], false)
 	}
@@ -129,6 +137,7 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 		pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
 
 	}
+	UpdateHTTPDataScriptForPG(vsNode[0], pgNode)
 }
 
 func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgrObj RouteIngressModel, pathSvc map[string][]string, key string, removeFqdn, removeRedir, secure bool) {
@@ -156,6 +165,10 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 				for _, svcName := range services {
 					if routeIgrObj.GetType() == utils.Ingress {
 						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName)
+						// pools of the backends added via HTTPRule are named with the service
+						if pool.HTTPRuleBackend && lib.GetL7PoolName(priorityLabel, namespace, ingName, svcName) == pool.Name {
+							o.RemovePoolNodeRefs(pool.Name)
+						}
 					} else {
 						poolName = lib.GetL7PoolName(priorityLabel, namespace, ingName, svcName)
 					}
@@ -174,6 +187,7 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 			pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 			pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
 		}
+		UpdateHTTPDataScriptForPG(vsNode[0], pgNode)
 	} else {
 		// Remove the ingress from the hostmap
 		hostMapOk, ingressHostMap := SharedHostNameLister().Get(hostname)
@@ -214,6 +228,11 @@ func (o *AviObjectGraph) ManipulateSniNode(currentSniNodeName, ingName, namespac
 				var sniPool string
 				if isIngr {
 					sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path)
+					// pools of the backends added via HTTPRule are named with the service
+					backendPool := lib.GetSniPoolName(ingName, namespace, hostname, path, svc)
+					o.RemovePoolNodeRefsFromSni(backendPool, modelSniNode)
+					o.RemovePoolRefsFromPG(backendPool, pgNode)
+					o.RemovePGNodeRefs(backendPool, modelSniNode)
 				} else {
 					sniPool = lib.GetSniPoolName(ingName, namespace, hostname, path, svc)
 				}
@@ -347,7 +366,7 @@ func sniNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 		sniNode.ServiceEngineGroup = lib.GetSEGName()
 		sniNode.VrfContext = lib.GetVrf()
 		if !certsBuilt {
-			certsBuilt = aviModel.(*AviObjectGraph).BuildTlsCertNode(routeIgrObj.GetSvcLister(), sniNode, namespace, tlssetting, key, sniHost)
+			certsBuilt = aviModel.(*AviObjectGraph).BuildTlsCertNode(routeIgrObj.GetSvcLister(), sniNode, namespace, routeIgrObj.GetType(), ingName, tlssetting, key, sniHost)
 		}
 		if certsBuilt {
 			isIngr := routeIgrObj.GetType() == utils.Ingress
@@ -357,10 +376,11 @@ func sniNodeHostName(routeIgrObj RouteIngressModel, tlssetting TlsSettings, ingN
 				vsNode[0].SniNodes = append(vsNode[0].SniNodes, sniNode)
 			}
 			RemoveRedirectHTTPPolicyInModel(vsNode[0], sniHost, key)
-			if tlssetting.redirect == true {
-				aviModel.(*AviObjectGraph).BuildPolicyRedirectForVS(vsNode, sniHost, namespace, ingName, key)
+			if redirect, statusCode := getHostRuleRedirect(sniHost, tlssetting.redirect); redirect {
+				aviModel.(*AviObjectGraph).BuildPolicyRedirectForVS(vsNode, sniHost, namespace, ingName, key, statusCode)
 			}
 			BuildL7HostRule(sniHost, namespace, ingName, key, sniNode)
+			BuildL7AuthRule(sniHost, key, sniNode)
 		} else {
 			hostMapOk, ingressHostMap := SharedHostNameLister().Get(sniHost)
 			if hostMapOk {
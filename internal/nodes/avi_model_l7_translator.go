@@ -128,6 +128,10 @@ func (o *AviObjectGraph) BuildL7VSGraph(vsName string, namespace string, ingName
 						vsNode[0].VSVIPRefs[0].FQDNs = append(vsNode[0].VSVIPRefs[0].FQDNs, host)
 					}
 					for _, obj := range val {
+						// httprules with backends are rejected with namespace sharding
+						if obj.httpRuleBackend {
+							continue
+						}
 						var priorityLabel string
 						var hostSlice []string
 						if obj.Path != "" {
@@ -138,17 +142,18 @@ func (o *AviObjectGraph) BuildL7VSGraph(vsName string, namespace string, ingName
 						hostSlice = append(hostSlice, host)
 						poolNode := &AviPoolNode{Name: lib.GetL7PoolName(priorityLabel, namespace, ingName), PortName: obj.PortName, IngressName: ingName, Tenant: lib.GetTenant(), PriorityLabel: priorityLabel, Port: obj.Port, ServiceMetadata: avicache.ServiceMetadataObj{IngressName: ingName, Namespace: namespace, HostNames: hostSlice}}
 						poolNode.VrfContext = lib.GetVrf()
+						poolNode.EnableHTTP2 = obj.isHTTP2Backend(namespace)
 						serviceType := lib.GetServiceType()
 						if serviceType == lib.NodePortLocal {
-							if servers := PopulateServersForNPL(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+							if servers := PopulateServersForNPL(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 								poolNode.Servers = servers
 							}
 						} else if serviceType == lib.NodePort {
-							if servers := PopulateServersForNodePort(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+							if servers := PopulateServersForNodePort(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 								poolNode.Servers = servers
 							}
 						} else {
-							if servers := PopulateServers(poolNode, namespace, obj.ServiceName, true, key); servers != nil {
+							if servers := PopulateServers(poolNode, obj.getServiceNamespace(namespace), obj.ServiceName, true, key); servers != nil {
 								poolNode.Servers = servers
 							}
 						}
@@ -176,7 +181,7 @@ func (o *AviObjectGraph) BuildL7VSGraph(vsName string, namespace string, ingName
 					}
 
 					sniNode.VrfContext = lib.GetVrf()
-					certsBuilt := o.BuildTlsCertNode(objects.SharedSvcLister(), sniNode, namespace, tlssetting, key)
+					certsBuilt := o.BuildTlsCertNode(objects.SharedSvcLister(), sniNode, namespace, utils.Ingress, ingName, tlssetting, key)
 					if certsBuilt {
 						o.BuildPolicyPGPoolsForSNI(vsNode, sniNode, namespace, ingName, tlssetting, tlssetting.SecretName, key, true)
 						foundSniModel := FindAndReplaceSniInModel(sniNode, vsNode, key)
@@ -185,7 +190,11 @@ func (o *AviObjectGraph) BuildL7VSGraph(vsName string, namespace string, ingName
 						}
 						sniNode.ServiceMetadata = avicache.ServiceMetadataObj{IngressName: ingName, Namespace: namespace, HostNames: sniNode.VHDomainNames}
 						for _, hostname := range sniNode.VHDomainNames {
-							o.BuildPolicyRedirectForVS(vsNode, hostname, namespace, ingName, key)
+							if redirect, statusCode := getHostRuleRedirect(hostname, true); redirect {
+								o.BuildPolicyRedirectForVS(vsNode, hostname, namespace, ingName, key, statusCode)
+							} else {
+								RemoveRedirectHTTPPolicyInModel(vsNode[0], hostname, key)
+							}
 						}
 					}
 
@@ -200,6 +209,7 @@ func (o *AviObjectGraph) BuildL7VSGraph(vsName string, namespace string, ingName
 		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 		pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel})
 	}
+	UpdateHTTPDataScriptForPG(vsNode[0], pgNode)
 }
 
 func (o *AviObjectGraph) DeletePoolForIngress(namespace, ingName, key string, vsNode []*AviVsNode) {
@@ -335,6 +345,7 @@ func (o *AviObjectGraph) ConstructAviL7VsNode(vsName string, key string) *AviVsN
 		Tenant:     lib.GetTenant(),
 		FQDNs:      fqdns,
 		EastWest:   false,
+		IPFamily:   lib.GetVipIPFamily(),
 		VrfContext: vrfcontext,
 	}
 
@@ -371,6 +382,46 @@ func (o *AviObjectGraph) ConstructHTTPDataScript(vsName string, key string, vsNo
 	return dsScriptNode
 }
 
+// UpdateHTTPDataScriptForPG regenerates the shared VS datascript based on the priority labels of the
+// poolgroup members, so that requests for hosts without an exact match fall back to wildcard hosts.
+func UpdateHTTPDataScriptForPG(vsNode *AviVsNode, pgNode *AviPoolGroupNode) {
+	if vsNode == nil || pgNode == nil {
+		return
+	}
+	var wildcardHosts, hosts []string
+	for _, member := range pgNode.Members {
+		if member.PriorityLabel == nil {
+			continue
+		}
+		host := strings.SplitN(*member.PriorityLabel, "/", 2)[0]
+		if lib.IsWildcardHost(host) {
+			if !utils.HasElem(wildcardHosts, host) {
+				wildcardHosts = append(wildcardHosts, host)
+			}
+		} else if !utils.HasElem(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+
+	// Only the exact hosts that are covered by a wildcard host need to be known to the datascript.
+	var exactHosts []string
+	for _, host := range hosts {
+		for _, wildcardHost := range wildcardHosts {
+			if lib.WildcardHostMatch(wildcardHost, host) {
+				exactHosts = append(exactHosts, host)
+				break
+			}
+		}
+	}
+
+	for _, dsNode := range vsNode.HTTPDSrefs {
+		if dsNode.DataScript == nil || len(dsNode.PoolGroupRefs) == 0 || dsNode.PoolGroupRefs[0] != pgNode.Name {
+			continue
+		}
+		dsNode.Script = lib.GetHTTPDataScript(pgNode.Name, exactHosts, wildcardHosts)
+	}
+}
+
 // BuildCACertNode : Build a new node to store CA cert, this would be referred by the corresponding keycert
 func (o *AviObjectGraph) BuildCACertNode(tlsNode *AviVsNode, cacert, keycertname, key string) string {
 	cacertNode := &AviTLSKeyCertNode{Name: lib.GetCACertNodeName(keycertname), Tenant: lib.GetTenant()}
@@ -388,7 +439,7 @@ func (o *AviObjectGraph) BuildCACertNode(tlsNode *AviVsNode, cacert, keycertname
 	return cacertNode.Name
 }
 
-func (o *AviObjectGraph) BuildTlsCertNode(svcLister *objects.SvcLister, tlsNode *AviVsNode, namespace string, tlsData TlsSettings, key string, sniHost ...string) bool {
+func (o *AviObjectGraph) BuildTlsCertNode(svcLister *objects.SvcLister, tlsNode *AviVsNode, namespace, objType, objName string, tlsData TlsSettings, key string, sniHost ...string) bool {
 	mClient := utils.GetInformers().ClientSet
 	secretName := tlsData.SecretName
 	secretNS := tlsData.SecretNS
@@ -454,6 +505,9 @@ func (o *AviObjectGraph) BuildTlsCertNode(svcLister *objects.SvcLister, tlsNode
 		}
 		utils.AviLog.Infof("key: %s, msg: Added the secret object to tlsnode: %s", key, secretObj.Name)
 	}
+	if !validateKeyCert(key, objType, namespace, objName, secretNS+"/"+secretName, certNode, getTLSHosts(tlsData, sniHost)) {
+		return false
+	}
 	// If this SSLCertRef is already present don't add it.
 	if len(sniHost) > 0 {
 		if tlsNode.CheckSSLCertNodeNameNChecksum(lib.GetTLSKeyCertNodeName(namespace, secretName, sniHost[0]), certNode.GetCheckSum()) {
@@ -465,6 +519,13 @@ func (o *AviObjectGraph) BuildTlsCertNode(svcLister *objects.SvcLister, tlsNode
 				tlsNode.ReplaceSniSSLRefInSNINode(certNode, key)
 			}
 		}
+		if altCertNode := buildAltCertNode(key, namespace, objType, objName, tlsData, certNode, sniHost[0]); altCertNode != nil {
+			if tlsNode.CheckSSLCertNodeNameNChecksum(altCertNode.Name, altCertNode.GetCheckSum()) {
+				tlsNode.ReplaceSniSSLRefInSNINode(altCertNode, key)
+			}
+		} else {
+			tlsNode.DeleteSSLRefInSNINode(lib.GetAltTLSKeyCertNodeName(sniHost[0]), key)
+		}
 	} else {
 		tlsNode.SSLKeyCertRefs = append(tlsNode.SSLKeyCertRefs, certNode)
 	}
@@ -473,6 +534,7 @@ func (o *AviObjectGraph) BuildTlsCertNode(svcLister *objects.SvcLister, tlsNode
 
 func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *AviVsNode, namespace string, ingName string, hostpath TlsSettings, secretName string, key string, isIngr bool, hostName ...string) {
 	localPGList := make(map[string]*AviPoolGroupNode)
+	localPolicyList := make(map[string]*AviHttpPolicySetNode)
 	for host, paths := range hostpath.Hosts {
 		if len(hostName) > 0 {
 			if hostName[0] != host {
@@ -487,6 +549,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 		if !utils.HasElem(tlsNode.VHDomainNames, host) {
 			tlsNode.VHDomainNames = append(tlsNode.VHDomainNames, host)
 		}
+		var poolNames []string
 		for _, path := range paths {
 			var httpPolicySet []AviHostPathPortPoolPG
 
@@ -504,7 +567,16 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 				httpPGPath.Path = append(httpPGPath.Path, path.Path)
 			}
 
-			pgName := lib.GetSniPGName(ingName, namespace, host, path.Path)
+			var poolName string
+			// Do not use serviceName in SNI Pool Name for ingress for backward compatibility
+			if isIngr && !path.httpRuleBackend {
+				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path)
+			} else {
+				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path, path.ServiceName)
+			}
+			poolNames = append(poolNames, poolName)
+
+			pgName := getPathPGName(lib.GetSniPGName(ingName, namespace, host, path.Path), poolName, path)
 			var pgNode *AviPoolGroupNode
 			// There can be multiple services for the same path in case of alternate backend.
 			// In that case, make sure we are creating only one PG per path
@@ -517,19 +589,14 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 				httpPolicySet = append(httpPolicySet, httpPGPath)
 			}
 
-			var poolName string
-			// Do not use serviceName in SNI Pool Name for ingress for backward compatibility
-			if isIngr {
-				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path)
-			} else {
-				poolName = lib.GetSniPoolName(ingName, namespace, host, path.Path, path.ServiceName)
-			}
 			hostSlice := []string{host}
 			poolNode := &AviPoolNode{
-				Name:       poolName,
-				PortName:   path.PortName,
-				Tenant:     lib.GetTenant(),
-				VrfContext: lib.GetVrf(),
+				Name:            poolName,
+				PortName:        path.PortName,
+				Tenant:          lib.GetTenant(),
+				VrfContext:      lib.GetVrf(),
+				HTTPRuleBackend: path.httpRuleBackend,
+				EnableHTTP2:     path.isHTTP2Backend(namespace),
 				ServiceMetadata: avicache.ServiceMetadataObj{
 					IngressName: ingName,
 					Namespace:   namespace,
@@ -542,15 +609,15 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			}
 			serviceType := lib.GetServiceType()
 			if serviceType == lib.NodePortLocal {
-				if servers := PopulateServersForNPL(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+				if servers := PopulateServersForNPL(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			} else if serviceType == lib.NodePort {
-				if servers := PopulateServersForNodePort(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+				if servers := PopulateServersForNodePort(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			} else {
-				if servers := PopulateServers(poolNode, namespace, path.ServiceName, true, key); servers != nil {
+				if servers := PopulateServers(poolNode, path.getServiceNamespace(namespace), path.ServiceName, true, key); servers != nil {
 					poolNode.Servers = servers
 				}
 			}
@@ -569,12 +636,26 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			o.AddModelNode(poolNode)
 			if !pgfound {
 				httppolname := lib.GetSniHttpPolName(ingName, namespace, host, path.Path)
-				policyNode := &AviHttpPolicySetNode{Name: httppolname, HppMap: httpPolicySet, Tenant: lib.GetTenant()}
+				policyNode, policyFound := localPolicyList[httppolname]
+				if !policyFound {
+					policyNode = &AviHttpPolicySetNode{Name: httppolname, Tenant: lib.GetTenant()}
+					localPolicyList[httppolname] = policyNode
+				}
+				if path.canary != nil {
+					// the canary switching rule is evaluated ahead of the path switching rule
+					httpPGPath.Canary = path.canary
+					policyNode.HppMap = append([]AviHostPathPortPoolPG{httpPGPath}, policyNode.HppMap...)
+				} else {
+					policyNode.HppMap = append(policyNode.HppMap, httpPolicySet...)
+				}
 				if tlsNode.CheckHttpPolNameNChecksum(httppolname, policyNode.GetCheckSum()) {
 					tlsNode.ReplaceSniHTTPRefInSNINode(policyNode, key)
 				}
 			}
 		}
+		if isIngr {
+			removeStaleHTTPRuleBackendPools(tlsNode, ingName, namespace, host, poolNames, key)
+		}
 		for _, path := range paths {
 			BuildPoolHTTPRule(host, path.Path, ingName, namespace, key, tlsNode, true)
 		}
@@ -600,7 +681,7 @@ func (o *AviObjectGraph) BuildPoolSecurity(poolNode *AviPoolNode, tlsData TlsSet
 	poolNode.PkiProfile = &pkiProfile
 }
 
-func (o *AviObjectGraph) BuildPolicyRedirectForVS(vsNode []*AviVsNode, hostname string, namespace, ingName, key string) {
+func (o *AviObjectGraph) BuildPolicyRedirectForVS(vsNode []*AviVsNode, hostname string, namespace, ingName, key string, statusCode ...string) {
 	policyname := lib.GetL7HttpRedirPolicy(vsNode[0].Name)
 	myHppMap := AviRedirectPort{
 		Hosts:        []string{hostname},
@@ -608,6 +689,9 @@ func (o *AviObjectGraph) BuildPolicyRedirectForVS(vsNode []*AviVsNode, hostname
 		StatusCode:   lib.STATUS_REDIRECT,
 		VsPort:       80,
 	}
+	if len(statusCode) > 0 && statusCode[0] != "" {
+		myHppMap.StatusCode = statusCode[0]
+	}
 
 	redirectPolicy := &AviHttpPolicySetNode{
 		Tenant:        lib.GetTenant(),
@@ -625,8 +709,7 @@ func (o *AviObjectGraph) BuildPolicyRedirectForVS(vsNode []*AviVsNode, hostname
 func FindAndReplaceRedirectHTTPPolicyInModel(vsNode *AviVsNode, httpPolicy *AviHttpPolicySetNode, hostname, key string) bool {
 	for _, policy := range vsNode.HttpPolicyRefs {
 		if policy.Name == httpPolicy.Name && policy.CloudConfigCksum != httpPolicy.CloudConfigCksum {
-			if !utils.HasElem(policy.RedirectPorts[0].Hosts, hostname) {
-				policy.RedirectPorts[0].Hosts = append(policy.RedirectPorts[0].Hosts, hostname)
+			if addHostToRedirectPorts(policy, hostname, httpPolicy.RedirectPorts[0]) {
 				utils.AviLog.Infof("key: %s, msg: replaced host %s for policy %s in model", key, hostname, policy.Name)
 			}
 			return true
@@ -637,20 +720,49 @@ func FindAndReplaceRedirectHTTPPolicyInModel(vsNode *AviVsNode, httpPolicy *AviH
 
 func RemoveRedirectHTTPPolicyInModel(vsNode *AviVsNode, hostname, key string) {
 	policyName := lib.GetL7HttpRedirPolicy(vsNode.Name)
-	deletePolicy := false
 	for i, policy := range vsNode.HttpPolicyRefs {
 		if policy.Name == policyName {
 			// one redirect policy per shard vs
-			policy.RedirectPorts[0].Hosts = utils.Remove(policy.RedirectPorts[0].Hosts, hostname)
-			utils.AviLog.Infof("key: %s, msg: removed host %s from policy %s in model %v", key, hostname, policy.Name, policy.RedirectPorts[0].Hosts)
-			if len(policy.RedirectPorts[0].Hosts) == 0 {
-				deletePolicy = true
-			}
-
-			if deletePolicy {
+			removeHostFromRedirectPorts(policy, hostname)
+			utils.AviLog.Infof("key: %s, msg: removed host %s from policy %s in model %v", key, hostname, policy.Name, utils.Stringify(policy.RedirectPorts))
+			if len(policy.RedirectPorts) == 0 {
 				vsNode.HttpPolicyRefs = append(vsNode.HttpPolicyRefs[:i], vsNode.HttpPolicyRefs[i+1:]...)
 				utils.AviLog.Infof("key: %s, msg: removed policy %s in model", key, policy.Name)
 			}
+			return
+		}
+	}
+}
+
+// addHostToRedirectPorts adds the host to the redirect of the policy with the same status code, the hosts
+// redirected with different status codes are kept in separate redirects. Returns true if the policy changed.
+func addHostToRedirectPorts(policy *AviHttpPolicySetNode, hostname string, redirectPort AviRedirectPort) bool {
+	for _, port := range policy.RedirectPorts {
+		if port.StatusCode == redirectPort.StatusCode && utils.HasElem(port.Hosts, hostname) {
+			return false
+		}
+	}
+	removeHostFromRedirectPorts(policy, hostname)
+	for i := range policy.RedirectPorts {
+		if policy.RedirectPorts[i].StatusCode == redirectPort.StatusCode {
+			policy.RedirectPorts[i].Hosts = append(policy.RedirectPorts[i].Hosts, hostname)
+			return true
+		}
+	}
+	redirectPort.Hosts = []string{hostname}
+	policy.RedirectPorts = append(policy.RedirectPorts, redirectPort)
+	return true
+}
+
+// removeHostFromRedirectPorts removes the host from the redirects of the policy, along with the
+// redirects which are left without any hosts.
+func removeHostFromRedirectPorts(policy *AviHttpPolicySetNode, hostname string) {
+	var redirectPorts []AviRedirectPort
+	for _, redirectPort := range policy.RedirectPorts {
+		redirectPort.Hosts = utils.Remove(redirectPort.Hosts, hostname)
+		if len(redirectPort.Hosts) > 0 {
+			redirectPorts = append(redirectPorts, redirectPort)
 		}
 	}
+	policy.RedirectPorts = redirectPorts
 }
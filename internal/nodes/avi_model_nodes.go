@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -100,6 +101,20 @@ func (v *AviObjectGraph) GetCheckSum() uint32 {
 	return v.GraphChecksum
 }
 
+// GetContentCheckSum returns the checksum of the virtual services of the model, with their pools and pool
+// groups. Unlike the graph checksum it does not cover the pool nodes the graph keeps once they are no longer
+// referred to, so it does not change when the model is built again from the same objects.
+func (v *AviObjectGraph) GetContentCheckSum() uint32 {
+	var checksum uint32
+	for _, vs := range v.GetAviVS() {
+		checksum += vs.getContentCheckSum()
+	}
+	for _, vs := range v.GetAviEvhVS() {
+		checksum += vs.getContentCheckSum()
+	}
+	return checksum
+}
+
 func (v *AviObjectGraph) SetRetryCounter(num ...int) {
 	// Overwrite the retry counter value.
 	v.Lock.RLock()
@@ -187,6 +202,43 @@ func (o *AviObjectGraph) RemoveHTTPRefsFromSni(httpPol string, sniNode *AviVsNod
 
 }
 
+// getPathPGName returns the poolgroup of an ingress path, the canary backend added via HTTPRule has a poolgroup
+// of its own, named after its pool so that the poolgroup is removed along with a stale canary pool.
+func getPathPGName(pathPGName, poolName string, path IngressHostPathSvc) string {
+	if path.canary != nil {
+		return poolName
+	}
+	return pathPGName
+}
+
+// removeStaleHTTPRuleBackendPools removes the pools of the backends which were added to the paths of an ingress
+// via HTTPRule and are no longer present, along with the canary poolgroups which are named after their pool.
+func removeStaleHTTPRuleBackendPools(vsNode AviVsEvhSniModel, ingName, namespace, host string, poolNames []string, key string) {
+	var poolRefs []*AviPoolNode
+	var stalePools []string
+	for _, pool := range vsNode.GetPoolRefs() {
+		if pool.HTTPRuleBackend && pool.ServiceMetadata.IngressName == ingName && pool.ServiceMetadata.Namespace == namespace &&
+			utils.HasElem(pool.ServiceMetadata.HostNames, host) && !utils.HasElem(poolNames, pool.Name) {
+			stalePools = append(stalePools, pool.Name)
+			continue
+		}
+		poolRefs = append(poolRefs, pool)
+	}
+	if len(stalePools) == 0 {
+		return
+	}
+	vsNode.SetPoolRefs(poolRefs)
+
+	var pgRefs []*AviPoolGroupNode
+	for _, pg := range vsNode.GetPoolGroupRefs() {
+		if !utils.HasElem(stalePools, pg.Name) {
+			pgRefs = append(pgRefs, pg)
+		}
+	}
+	vsNode.SetPoolGroupRefs(pgRefs)
+	utils.AviLog.Infof("key: %s, msg: removed stale httprule backend pools %v from %s", key, stalePools, vsNode.GetName())
+}
+
 func (o *AviObjectGraph) RemovePoolNodeRefsFromSni(poolName string, sniNode *AviVsNode) {
 
 	for i, pool := range sniNode.PoolRefs {
@@ -306,6 +358,10 @@ type AviVsNode struct {
 	SSLProfileRef         string
 	VsDatascriptRefs      []string
 	SSLKeyCertAviRef      string
+	AltSSLKeyCertAviRef   string
+	AppProfileNode        *AviAppProfileNode
+	SSLProfileNode        *AviSSLProfileNode
+	SSOPolicyNode         *AviSSOPolicyNode
 }
 
 // Implementing AviVsEvhSniModel
@@ -366,6 +422,14 @@ func (v *AviVsNode) SetSSLKeyCertAviRef(sslKeyCertAviRef string) {
 	v.SSLKeyCertAviRef = sslKeyCertAviRef
 }
 
+func (v *AviVsNode) GetAltSSLKeyCertAviRef() string {
+	return v.AltSSLKeyCertAviRef
+}
+
+func (v *AviVsNode) SetAltSSLKeyCertAviRef(altSSLKeyCertAviRef string) {
+	v.AltSSLKeyCertAviRef = altSSLKeyCertAviRef
+}
+
 func (v *AviVsNode) GetWafPolicyRef() string {
 	return v.WafPolicyRef
 }
@@ -390,6 +454,30 @@ func (v *AviVsNode) SetAppProfileRef(appProfileRef string) {
 	v.AppProfileRef = appProfileRef
 }
 
+func (v *AviVsNode) GetAppProfileNode() *AviAppProfileNode {
+	return v.AppProfileNode
+}
+
+func (v *AviVsNode) SetAppProfileNode(appProfileNode *AviAppProfileNode) {
+	v.AppProfileNode = appProfileNode
+}
+
+func (v *AviVsNode) GetSSLProfileNode() *AviSSLProfileNode {
+	return v.SSLProfileNode
+}
+
+func (v *AviVsNode) SetSSLProfileNode(sslProfileNode *AviSSLProfileNode) {
+	v.SSLProfileNode = sslProfileNode
+}
+
+func (v *AviVsNode) GetSSOPolicyNode() *AviSSOPolicyNode {
+	return v.SSOPolicyNode
+}
+
+func (v *AviVsNode) SetSSOPolicyNode(ssoPolicyNode *AviSSOPolicyNode) {
+	v.SSOPolicyNode = ssoPolicyNode
+}
+
 func (v *AviVsNode) GetAnalyticsProfileRef() string {
 	return v.AnalyticsProfileRef
 }
@@ -447,6 +535,36 @@ func (v *AviVsNode) GetCheckSum() uint32 {
 	return v.CloudConfigCksum
 }
 
+func (v *AviVsNode) getContentCheckSum() uint32 {
+	checksum := v.GetCheckSum()
+	for _, pool := range v.PoolRefs {
+		checksum += pool.GetCheckSum()
+	}
+	for _, pg := range v.PoolGroupRefs {
+		checksum += pg.GetCheckSum()
+	}
+	for _, sni := range v.SniNodes {
+		checksum += sni.getContentCheckSum()
+	}
+	for _, passthroughChild := range v.PassthroughChildNodes {
+		checksum += passthroughChild.getContentCheckSum()
+	}
+	return checksum
+}
+
+// HasHTTP2Pools checks the pools of the SNI children, the SSL ports of the parent are
+// enabled for HTTP/2 when any of the pools speaks HTTP/2 to its servers
+func (v *AviVsNode) HasHTTP2Pools() bool {
+	for _, sni := range v.SniNodes {
+		for _, pool := range sni.PoolRefs {
+			if pool.EnableHTTP2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (v *AviVsNode) GetSniNodeForName(sniNodeName string) *AviVsNode {
 	for _, sni := range v.SniNodes {
 		if sni.Name == sniNodeName {
@@ -594,6 +712,16 @@ func (o *AviVsNode) ReplaceSniSSLRefInSNINode(newSslNode *AviTLSKeyCertNode, key
 	return
 }
 
+func (o *AviVsNode) DeleteSSLRefInSNINode(sslKeyCertName, key string) {
+	for i, ssl := range o.SSLKeyCertRefs {
+		if ssl.Name == sslKeyCertName {
+			o.SSLKeyCertRefs = append(o.SSLKeyCertRefs[:i], o.SSLKeyCertRefs[i+1:]...)
+			utils.AviLog.Debugf("key: %s, msg: deleted sni ssl in model: %s sslKeyCertRefs name: %s", key, o.Name, ssl.Name)
+			return
+		}
+	}
+}
+
 func (o *AviVsNode) CheckHttpPolNameNChecksum(httpNodeName string, checksum uint32) bool {
 	for _, http := range o.HttpPolicyRefs {
 		if http.Name == httpNodeName {
@@ -651,6 +779,21 @@ func (v *AviVsNode) CalculateCheckSum() {
 		passthroughChecksum += passthroughChild.GetCheckSum()
 	}
 
+	if v.AppProfileNode != nil {
+		sslkeyChecksum += v.AppProfileNode.GetCheckSum()
+		if v.AppProfileNode.PkiProfile != nil {
+			sslkeyChecksum += v.AppProfileNode.PkiProfile.GetCheckSum()
+		}
+	}
+
+	if v.SSLProfileNode != nil {
+		sslkeyChecksum += v.SSLProfileNode.GetCheckSum()
+	}
+
+	if v.SSOPolicyNode != nil {
+		sslkeyChecksum += v.SSOPolicyNode.GetVsCheckSum()
+	}
+
 	// keep the order of these policies
 	policies := v.HttpPolicySetRefs
 	scripts := v.VsDatascriptRefs
@@ -666,6 +809,10 @@ func (v *AviVsNode) CalculateCheckSum() {
 		vsRefs += utils.Stringify(scripts)
 	}
 
+	if v.AltSSLKeyCertAviRef != "" {
+		vsRefs += v.AltSSLKeyCertAviRef
+	}
+
 	checksum := dsChecksum +
 		httppolChecksum +
 		sniChecksum +
@@ -688,6 +835,10 @@ func (v *AviVsNode) CalculateCheckSum() {
 		checksum += utils.Hash(utils.Stringify(*v.EnableRhi))
 	}
 
+	if v.HasHTTP2Pools() {
+		checksum += utils.Hash(utils.Stringify(v.HasHTTP2Pools()))
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -755,6 +906,9 @@ type AviHttpPolicySetNode struct {
 	CloudConfigCksum uint32
 	HppMap           []AviHostPathPortPoolPG
 	RedirectPorts    []AviRedirectPort
+	// AccessControl and HSTS are set on the httppolicyset created for the HostRule of a virtualhost
+	AccessControl *akov1alpha1.AccessControl
+	HSTS          *akov1alpha1.HostRuleHSTS
 }
 
 func (v *AviHttpPolicySetNode) GetCheckSum() uint32 {
@@ -773,6 +927,15 @@ func (v *AviHttpPolicySetNode) CalculateCheckSum() {
 	for _, redir := range v.RedirectPorts {
 		sort.Strings(redir.Hosts)
 		checksum = checksum + utils.Hash(utils.Stringify(redir.Hosts))
+		if redir.StatusCode != lib.STATUS_REDIRECT {
+			checksum += utils.Hash(redir.StatusCode)
+		}
+	}
+	if v.AccessControl != nil {
+		checksum += utils.Hash(utils.Stringify(v.AccessControl))
+	}
+	if v.HSTS != nil {
+		checksum += utils.Hash(utils.Stringify(v.HSTS))
 	}
 	checksum += lib.GetClusterLabelChecksum()
 	v.CloudConfigCksum = checksum
@@ -804,6 +967,20 @@ type AviHostPathPortPoolPG struct {
 	PoolGroup     string
 	MatchCriteria string
 	Protocol      string
+	PathActions   *AviHTTPPathActions
+	Canary        *akov1alpha1.HTTPRuleCanary
+}
+
+// AviHTTPPathActions holds the header, URL rewrite, redirect, rate limit and access control actions of a path set via HTTPRule,
+// Target is the HTTPRule path whose prefix is rewritten.
+type AviHTTPPathActions struct {
+	Target          string
+	RequestHeaders  akov1alpha1.HTTPRuleHeaders
+	ResponseHeaders akov1alpha1.HTTPRuleHeaders
+	Rewrite         akov1alpha1.HTTPRuleRewrite
+	Redirect        akov1alpha1.HTTPRuleRedirect
+	RateLimit       akov1alpha1.RateLimit
+	AccessControl   akov1alpha1.AccessControl
 }
 
 type AviRedirectPort struct {
@@ -874,6 +1051,8 @@ type AviVSVIPNode struct {
 	EastWest                bool
 	VrfContext              string
 	IPAddress               string
+	IPv6Address             string
+	IPFamily                string
 	NetworkName             *string
 	SecurePassthroughNode   *AviVsNode
 	InsecurePassthroughNode *AviVsNode
@@ -890,7 +1069,7 @@ func (v *AviVSVIPNode) CalculateCheckSum() {
 	if v.NetworkName != nil {
 		networkName = *v.NetworkName
 	}
-	checksum := lib.VSVipChecksum(v.FQDNs, v.IPAddress, networkName)
+	checksum := lib.VSVipChecksum(v.FQDNs, v.IPAddress, v.IPv6Address, v.IPFamily, networkName)
 	checksum += lib.GetClusterLabelChecksum()
 	v.CloudConfigCksum = checksum
 }
@@ -985,7 +1164,11 @@ func (v *AviHTTPDataScriptNode) GetCheckSum() uint32 {
 
 func (v *AviHTTPDataScriptNode) CalculateCheckSum() {
 	// A sum of fields for this VS.
-	checksum := lib.DSChecksum(v.PoolGroupRefs)
+	var scripts []string
+	if v.DataScript != nil {
+		scripts = append(scripts, v.Script)
+	}
+	checksum := lib.DSChecksum(v.PoolGroupRefs, scripts...)
 	checksum += lib.GetClusterLabelChecksum()
 	v.CloudConfigCksum = checksum
 }
@@ -1042,6 +1225,170 @@ func (v *AviPkiProfileNode) CalculateCheckSum() {
 	v.CloudConfigCksum = checksum
 }
 
+// AviAppProfileNode is the application profile created by AKO for a virtualhost,
+// to validate the client certificates against the CA bundle of the PkiProfile
+// and to rate limit the requests to the virtualhost
+type AviAppProfileNode struct {
+	Name              string
+	Tenant            string
+	CloudConfigCksum  uint32
+	PkiProfile        *AviPkiProfileNode
+	ClientCertMode    string
+	ClientCertHeaders []*avimodels.SSLClientRequestHeader
+	RateLimit         *akov1alpha1.RateLimit
+}
+
+func (v *AviAppProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviAppProfileNode) CalculateCheckSum() {
+	var pkiProfileName string
+	if v.PkiProfile != nil {
+		pkiProfileName = v.PkiProfile.Name
+	}
+	chksumStr := fmt.Sprint(strings.Join([]string{
+		v.Name,
+		pkiProfileName,
+		v.ClientCertMode,
+		utils.Stringify(v.ClientCertHeaders),
+		utils.Stringify(v.RateLimit),
+	}[:], delim))
+	// application profiles do not carry labels, so the cluster label checksum is not added
+	v.CloudConfigCksum = utils.Hash(chksumStr)
+}
+
+// AviSSLProfileNode is the SSL profile created by AKO for a virtualhost,
+// to restrict the TLS versions and ciphers accepted for the virtualhost
+type AviSSLProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	AcceptedVersions []string
+	AcceptedCiphers  string
+	Ciphersuites     string
+}
+
+func (v *AviSSLProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviSSLProfileNode) CalculateCheckSum() {
+	// SSL profiles do not carry labels, the checksum is computed from the fields set by AKO
+	v.CloudConfigCksum = lib.SSLProfileChecksum(v.Name, v.AcceptedVersions, v.AcceptedCiphers, v.Ciphersuites)
+}
+
+// AviSSOPolicyNode is the SSO policy created by AKO for the AuthRule of a virtualhost, for JWT validation
+// it refers to an auth profile and a JWT server profile which are created by AKO as well
+type AviSSOPolicyNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	Type             string
+	AuthProfile      string
+	Paths            []string
+	JWTProfile       *AviJWTProfileNode
+	// JwtConfig and SamlSpConfig are set on the virtualservice
+	JwtConfig    *avimodels.JWTValidationVsConfig
+	SamlSpConfig *avimodels.SAMLSPConfig
+}
+
+func (v *AviSSOPolicyNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviSSOPolicyNode) CalculateCheckSum() {
+	// SSO policies do not carry labels, the checksum is computed from the fields set by AKO
+	v.CloudConfigCksum = lib.SSOPolicyChecksum(v.Name, v.Type, v.AuthProfile, v.Paths)
+}
+
+// GetVsCheckSum returns the checksum of the SSO policy along with the objects it refers to,
+// and the authentication settings of the virtualservice
+func (v *AviSSOPolicyNode) GetVsCheckSum() uint32 {
+	checksum := v.GetCheckSum() +
+		utils.Hash(utils.Stringify(v.JwtConfig)) +
+		utils.Hash(utils.Stringify(v.SamlSpConfig))
+	if v.JWTProfile != nil {
+		checksum += v.JWTProfile.GetCheckSum()
+	}
+	return checksum
+}
+
+// AviJWTProfileNode is the JWT server profile created by AKO for the AuthRule of a virtualhost,
+// along with the auth profile of type JWT which refers to it
+type AviJWTProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	AuthProfile      string
+	Issuer           string
+	JwksKeys         string
+}
+
+func (v *AviJWTProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviJWTProfileNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.JWTProfileChecksum(v.Name, v.Issuer, v.JwksKeys)
+}
+
+type AviHealthMonitorNode struct {
+	Name              string
+	Tenant            string
+	CloudConfigCksum  uint32
+	Type              string
+	HTTPRequest       string
+	HTTPResponseCodes []string
+	MonitorPort       int32
+	SendInterval      int32
+	ReceiveTimeout    int32
+	SuccessfulChecks  int32
+	FailedChecks      int32
+}
+
+func (v *AviHealthMonitorNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviHealthMonitorNode) CalculateCheckSum() {
+	// health monitors do not carry labels, so the cluster label checksum is not added
+	v.CloudConfigCksum = lib.HealthMonitorChecksum(v.Name, v.Type, v.HTTPRequest, v.HTTPResponseCodes,
+		v.MonitorPort, v.SendInterval, v.ReceiveTimeout, v.SuccessfulChecks, v.FailedChecks)
+}
+
+// AviPersistenceProfileNode is the application persistence profile of a pool,
+// created by AKO from the persistence settings of an HTTPRule path
+type AviPersistenceProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	PersistenceType  string
+	CookieName       string
+	HeaderName       string
+	Timeout          int32
+}
+
+func (v *AviPersistenceProfileNode) GetCheckSum() uint32 {
+	// Calculate checksum and return
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviPersistenceProfileNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.PersistenceProfileChecksum(v.Name, v.PersistenceType, v.CookieName, v.HeaderName, v.Timeout)
+}
+
 type AviPoolNode struct {
 	Name             string
 	Tenant           string
@@ -1060,8 +1407,25 @@ type AviPoolNode struct {
 	SniEnabled       bool
 	SslProfileRef    string
 	PkiProfile       *AviPkiProfileNode
-	HealthMonitors   []string
-	VrfContext       string
+	// client key/cert presented by the pool to the backends
+	SSLKeyCert                        *AviTLSKeyCertNode
+	MaxConcurrentConnectionsPerServer int32
+	HealthMonitors                    []string
+	// health monitors defined in HTTPRule, created and managed by AKO
+	HealthMonitorNodes []*AviHealthMonitorNode
+	// persistence profile referred from the controller, or created by AKO
+	ApplicationPersistenceProfileRef string
+	PersistenceProfile               *AviPersistenceProfileNode
+	VrfContext                       string
+	HTTPRuleBackend                  bool //set for the pools of the backends added to an ingress path via HTTPRule
+	// server connection settings from HTTPRule, the timeouts are in milliseconds
+	ServerTimeout   int32
+	ConnIdleTimeout int32
+	ConnMaxReuse    int32
+	RetryCount      int32
+	RetryTimeout    int32
+	// set for the appProtocol h2c and grpc service ports, or by HTTPRule
+	EnableHTTP2 bool
 }
 
 func (v *AviPoolNode) GetCheckSum() uint32 {
@@ -1091,6 +1455,7 @@ func (v *AviPoolNode) CalculateCheckSum() {
 		v.SslProfileRef,
 		v.PriorityLabel,
 		utils.Stringify(nodeNetworkMap),
+		strconv.Itoa(int(v.MaxConcurrentConnectionsPerServer)),
 	}[:], delim))
 
 	checksum := utils.Hash(chksumStr)
@@ -1102,6 +1467,30 @@ func (v *AviPoolNode) CalculateCheckSum() {
 	if v.PkiProfile != nil {
 		checksum += v.PkiProfile.GetCheckSum()
 	}
+
+	if v.SSLKeyCert != nil {
+		checksum += v.SSLKeyCert.GetCheckSum()
+	}
+
+	for _, hm := range v.HealthMonitorNodes {
+		checksum += hm.GetCheckSum()
+	}
+
+	if v.ApplicationPersistenceProfileRef != "" {
+		checksum += utils.Hash(v.ApplicationPersistenceProfileRef)
+	}
+
+	if v.PersistenceProfile != nil {
+		checksum += v.PersistenceProfile.GetCheckSum()
+	}
+
+	if v.ServerTimeout != 0 || v.ConnIdleTimeout != 0 || v.ConnMaxReuse != 0 || v.RetryCount != 0 {
+		checksum += utils.Hash(utils.Stringify([]int32{v.ServerTimeout, v.ConnIdleTimeout, v.ConnMaxReuse, v.RetryCount, v.RetryTimeout}))
+	}
+
+	if v.EnableHTTP2 {
+		checksum += utils.Hash(utils.Stringify(v.EnableHTTP2))
+	}
 	checksum += lib.GetClusterLabelChecksum()
 	v.CloudConfigCksum = checksum
 }
@@ -1170,13 +1559,42 @@ type AviPoolMetaServer struct {
 }
 
 type IngressHostPathSvc struct {
-	ServiceName string
-	Path        string
-	PathType    networkingv1beta1.PathType
-	Port        int32
-	weight      int32 //required for alternate backends in openshift route
-	PortName    string
-	TargetPort  int32
+	ServiceName      string
+	ServiceNamespace string //set only for backends in a namespace other than the ingress
+	Path             string
+	PathType         networkingv1beta1.PathType
+	Port             int32
+	weight           int32 //required for alternate backends in openshift route
+	PortName         string
+	TargetPort       int32
+	httpRuleBackend  bool                        //set for the backends added to an ingress path via HTTPRule
+	canary           *akov1alpha1.HTTPRuleCanary //set for the canary backend added to an ingress path via HTTPRule
+	httpRule         string                      //namespace/name of the HTTPRule which added the backend
+}
+
+// getServiceNamespace returns the namespace of the backend service, which defaults to the namespace of the ingress/route.
+func (obj IngressHostPathSvc) getServiceNamespace(namespace string) string {
+	if obj.ServiceNamespace != "" {
+		return obj.ServiceNamespace
+	}
+	return namespace
+}
+
+// isHTTP2Backend checks the appProtocol of the service port serving the path
+func (obj IngressHostPathSvc) isHTTP2Backend(namespace string) bool {
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(obj.getServiceNamespace(namespace)).Get(obj.ServiceName)
+	if err != nil {
+		return false
+	}
+	for _, svcPort := range svcObj.Spec.Ports {
+		if len(svcObj.Spec.Ports) == 1 ||
+			(obj.PortName != "" && (svcPort.Name == obj.PortName || svcPort.TargetPort.StrVal == obj.PortName)) ||
+			(obj.Port != 0 && svcPort.Port == obj.Port) ||
+			(obj.TargetPort != 0 && svcPort.TargetPort.IntVal == obj.TargetPort) {
+			return lib.IsHTTP2AppProtocol(svcPort.AppProtocol)
+		}
+	}
+	return false
 }
 
 type IngressHostMap map[string][]IngressHostPathSvc
@@ -1191,6 +1609,8 @@ type TlsSettings struct {
 	destCA     string //for reencrypt
 	reencrypt  bool
 	redirect   bool
+	// altSecrets maps hosts to the secret of their alternate certificate, used in hostname sharding and EVH
+	altSecrets map[string]string
 	//tlstype    string
 }
 
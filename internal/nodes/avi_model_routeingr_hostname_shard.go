@@ -244,6 +244,8 @@ func HostNameShardAndPublishV2(objType, objname, namespace, key string, fullsync
 		ProcessInsecureHostsForEVH(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap)
 		// process secure hosts
 		ProcessSecureHostsForEVH(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap, fullsync, sharedQueue)
+		// passthrough hosts are placed on the passthrough shard VSs, which are not EVH
+		ProcessPassthroughHosts(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap)
 		// delete stale data
 		DeleteStaleDataForEvh(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
 		// hostNamePathStore cache operation
@@ -365,7 +367,7 @@ func ProcessSecureHosts(routeIgrObj RouteIngressModel, key string, parsedIng Ing
 				}
 			}
 			hostsMap[host].SecurePolicy = lib.PolicyEdgeTerm
-			if tlssetting.redirect == true {
+			if redirect, _ := getHostRuleRedirect(host, tlssetting.redirect); redirect {
 				hostsMap[host].InsecurePolicy = lib.PolicyRedirect
 			}
 			hostsMap[host].PathSvc = getPathSvc(newPathSvc)
@@ -390,6 +392,7 @@ func ProcessPassthroughHosts(routeIgrObj RouteIngressModel, key string, parsedIn
 			}
 		}
 		hostsMap[host].SecurePolicy = lib.PolicyPass
+		hostsMap[host].PathSvc = getPathSvc(pass.PathSvc)
 		redirect := false
 		if pass.redirect == true {
 			redirect = true
@@ -449,7 +452,8 @@ func DeleteStaleData(routeIgrObj RouteIngressModel, key string, modelList *[]str
 		} else if hostData.SecurePolicy == lib.PolicyPass {
 			aviModel.(*AviObjectGraph).DeleteObjectsForPassthroughHost(shardVsName, host, routeIgrObj, hostData.PathSvc, key, removeFqdn, removeRedir, true)
 		}
-		if hostData.InsecurePolicy != lib.PolicyNone {
+		// the redirect of a passthrough host is removed along with its passthrough objects
+		if hostData.InsecurePolicy != lib.PolicyNone && hostData.SecurePolicy != lib.PolicyPass {
 			aviModel.(*AviObjectGraph).DeletePoolForHostname(shardVsName, host, routeIgrObj, hostData.PathSvc, key, removeFqdn, removeRedir, false)
 
 		}
@@ -62,6 +62,7 @@ func (o *AviObjectGraph) BuildVSForPassthrough(vsName, namespace, hostname, key
 		Tenant:     lib.GetTenant(),
 		FQDNs:      fqdns,
 		EastWest:   false,
+		IPFamily:   lib.GetVipIPFamily(),
 		VrfContext: vrfcontext,
 	}
 
@@ -135,11 +136,11 @@ func (o *AviObjectGraph) BuildGraphForPassthrough(svclist []IngressHostPathSvc,
 
 		poolNode.Servers = []AviPoolMetaServer{}
 		if !lib.IsNodePortMode() {
-			if servers := PopulateServers(poolNode, namesapce, obj.ServiceName, true, key); servers != nil {
+			if servers := PopulateServers(poolNode, obj.getServiceNamespace(namesapce), obj.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		} else {
-			if servers := PopulateServersForNodePort(poolNode, namesapce, obj.ServiceName, true, key); servers != nil {
+			if servers := PopulateServersForNodePort(poolNode, obj.getServiceNamespace(namesapce), obj.ServiceName, true, key); servers != nil {
 				poolNode.Servers = servers
 			}
 		}
@@ -79,17 +79,22 @@ func findRoutePrefix(nodeRoutes, aviRoutes []*models.StaticRoute, key string) bo
 }
 
 func (o *AviObjectGraph) addRouteForNode(node *v1.Node, vrfName string, routeid int) ([]*models.StaticRoute, error) {
-	var nodeIP string
+	var nodeIP, nodeIP6 string
 	var nodeRoutes []*models.StaticRoute
 
 	nodeAddrs := node.Status.Addresses
 	for _, addr := range nodeAddrs {
 		if addr.Type == "InternalIP" {
-			nodeIP = addr.Address
-			break
+			if utils.IsV4(addr.Address) {
+				if nodeIP == "" {
+					nodeIP = addr.Address
+				}
+			} else if nodeIP6 == "" {
+				nodeIP6 = addr.Address
+			}
 		}
 	}
-	if nodeIP == "" {
+	if nodeIP == "" && nodeIP6 == "" {
 		utils.AviLog.Errorf("Error in fetching nodeIP for %v", node.ObjectMeta.Name)
 		return nil, errors.New("nodeip not found")
 	}
@@ -99,7 +104,6 @@ func (o *AviObjectGraph) addRouteForNode(node *v1.Node, vrfName string, routeid
 		utils.AviLog.Errorf("Error in fetching Pod CIDR for %v", node.ObjectMeta.Name)
 		return nil, errors.New("podcidr not found")
 	}
-	nodeipType := "V4"
 
 	for _, podCIDR := range podCIDRs {
 		s := strings.Split(podCIDR, "/")
@@ -114,9 +118,19 @@ func (o *AviObjectGraph) addRouteForNode(node *v1.Node, vrfName string, routeid
 			return nil, err
 		}
 
+		// The next hop for an IPv6 Pod CIDR is the IPv6 address of the node.
+		nextHop, ipType := nodeIP, "V4"
+		if !utils.IsV4(s[0]) {
+			nextHop, ipType = nodeIP6, "V6"
+		}
+		if nextHop == "" {
+			utils.AviLog.Warnf("Node %v has no %s InternalIP, skipping static route for Pod CIDR %s", node.ObjectMeta.Name, ipType, podCIDR)
+			continue
+		}
+
 		clusterName := lib.GetClusterName()
 		labels := lib.GetLabels()
-		prefixipType := "V4"
+		prefixipType, nodeipType := ipType, ipType
 		mask := int32(m)
 		routeIDString := clusterName + "-" + strconv.Itoa(routeid)
 		nodeRoute := models.StaticRoute{
@@ -129,7 +143,7 @@ func (o *AviObjectGraph) addRouteForNode(node *v1.Node, vrfName string, routeid
 				Mask: &mask,
 			},
 			NextHop: &models.IPAddr{
-				Addr: &nodeIP,
+				Addr: &nextHop,
 				Type: &nodeipType,
 			},
 			Labels: labels,
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// validateKeyCert checks the key/cert of a TLS setting before it is uploaded, a key which does not belong
+// to the certificate or a certificate which does not cover the hosts is rejected. The expiry of an accepted
+// certificate is tracked, and Events are raised on the Ingress/Route as it approaches.
+func validateKeyCert(key, objType, namespace, objName, secret string, certNode *AviTLSKeyCertNode, hosts []string) bool {
+	cert, err := lib.ParseKeyCert(certNode.Cert, certNode.Key)
+	if err == nil && cert != nil {
+		for _, host := range hosts {
+			if err = verifyCertHost(key, cert, host); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		msg := fmt.Sprintf("certificate of %s is not valid: %v", secret, err)
+		utils.AviLog.Warnf("key: %s, msg: %s", key, msg)
+		if objType == utils.OshiftRoute {
+			status.UpdateRouteStatusWithErrReason(key, objName, namespace, lib.InvalidCertificateReason, msg)
+		}
+		recordObjectEvent(objType, namespace, objName, corev1.EventTypeWarning, lib.InvalidCertificateReason, msg)
+		lib.SharedCertExpiryStore().Delete(certNode.Name)
+		return false
+	}
+	if cert == nil {
+		return true
+	}
+
+	certInfo := lib.CertExpiryInfo{
+		Name:         certNode.Name,
+		Secret:       secret,
+		Hosts:        hosts,
+		Subject:      cert.Subject.String(),
+		NotAfter:     cert.NotAfter,
+		DaysToExpiry: lib.DaysToExpiry(cert),
+		ObjType:      objType,
+		Namespace:    namespace,
+		ObjName:      objName,
+	}
+	lib.SharedCertExpiryStore().Save(certInfo)
+	recordCertExpiryEvent(key, certInfo)
+	return true
+}
+
+// verifyCertHost checks that the certificate covers the host. VerifyHostname ignores the CN, so the legacy
+// certificates without SANs, which the controller still accepts, are matched by their CN with a warning.
+func verifyCertHost(key string, cert *x509.Certificate, host string) error {
+	err := cert.VerifyHostname(host)
+	if err == nil || len(cert.DNSNames) > 0 || len(cert.IPAddresses) > 0 {
+		return err
+	}
+	if matchCertName(cert.Subject.CommonName, host) {
+		utils.AviLog.Warnf("key: %s, msg: certificate %s has no SAN, host %s is matched by its CN", key, cert.Subject, host)
+		return nil
+	}
+	return err
+}
+
+// matchCertName matches a host with a certificate name, a wildcard name covers a single label
+func matchCertName(name, host string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if strings.HasPrefix(name, "*.") {
+		i := strings.Index(host, ".")
+		return i > 0 && host[i:] == name[1:]
+	}
+	return name != "" && name == host
+}
+
+// recordCertExpiryEvent raises an Event on the Ingress/Route using a certificate which expires within
+// the warning days, or has expired.
+func recordCertExpiryEvent(key string, certInfo lib.CertExpiryInfo) {
+	if certInfo.DaysToExpiry > lib.GetCertExpiryWarningDays() {
+		return
+	}
+	msg := fmt.Sprintf("certificate of %s expires in %d days, on %s", certInfo.Secret, certInfo.DaysToExpiry, certInfo.NotAfter.UTC())
+	if certInfo.DaysToExpiry < 0 {
+		msg = fmt.Sprintf("certificate of %s expired on %s", certInfo.Secret, certInfo.NotAfter.UTC())
+	}
+	utils.AviLog.Warnf("key: %s, msg: %s", key, msg)
+	recordObjectEvent(certInfo.ObjType, certInfo.Namespace, certInfo.ObjName, corev1.EventTypeWarning, lib.CertificateExpiringReason, msg)
+}
+
+// ScanCertExpiry raises the expiry Events of all the tracked certificates.
+func ScanCertExpiry() {
+	for _, certInfo := range lib.SharedCertExpiryStore().List() {
+		recordCertExpiryEvent(certInfo.ObjType+"/"+certInfo.Namespace+"/"+certInfo.ObjName, certInfo)
+	}
+}
+
+// RunCertExpiryScan scans the tracked certificates every CertExpiryScanInterval, so that the expiry
+// Events are raised for the certificates which are not processed again, until stopCh is closed.
+func RunCertExpiryScan(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(lib.CertExpiryScanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			ScanCertExpiry()
+		}
+	}
+}
+
+// getTLSHosts returns the hosts a certificate is used for, the host of the SNI/EVH child in hostname
+// sharding, otherwise all the hosts of the TLS setting.
+func getTLSHosts(tlsData TlsSettings, sniHost []string) []string {
+	if len(sniHost) > 0 {
+		return sniHost
+	}
+	var hosts []string
+	for host := range tlsData.Hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
@@ -17,6 +17,7 @@ package nodes
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
 	"strings"
@@ -27,11 +28,13 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/avinetworks/sdk/go/models"
 )
 
 func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniModel) {
 	// use host to find out HostRule CRD if it exists
-	found, hrNamespaceName := objects.SharedCRDLister().GetFQDNToHostruleMapping(host)
+	found, hrNamespaceName := GetHostruleForFqdn(host)
 	deleteCase := false
 	if !found {
 		utils.AviLog.Debugf("key: %s, msg: No HostRule found for virtualhost: %s in Cache", key, host)
@@ -54,8 +57,12 @@ func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniMod
 	}
 
 	// host specific
-	var vsWafPolicy, vsAppProfile, vsSslKeyCertificate, vsErrorPageProfile, vsAnalyticsProfile, vsSslProfile string
+	var vsWafPolicy, vsAppProfile, vsSslKeyCertificate, vsAltSslKeyCertificate, vsErrorPageProfile, vsAnalyticsProfile, vsSslProfile string
 	var vsEnabled *bool
+	var vsAppProfileNode *AviAppProfileNode
+	var vsSslProfileNode *AviSSLProfileNode
+	var vsAccessControl *akov1alpha1.AccessControl
+	var vsHSTS *akov1alpha1.HostRuleHSTS
 	var crdStatus cache.CRDMetadata
 
 	// Initializing the values of vsHTTPPolicySets and vsDatascripts, using a nil value would impact the value of VS checksum
@@ -68,10 +75,19 @@ func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniMod
 			vsNode.SetSSLKeyCertRefs([]*AviTLSKeyCertNode{})
 		}
 
+		if altCert := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate; altCert.Name != "" {
+			vsAltSslKeyCertificate = fmt.Sprintf("/api/sslkeyandcertificate?name=%s", altCert.Name)
+		}
+
 		if hostrule.Spec.VirtualHost.TLS.SSLProfile != "" {
 			vsSslProfile = fmt.Sprintf("/api/sslprofile?name=%s", hostrule.Spec.VirtualHost.TLS.SSLProfile)
 		}
 
+		if hasHostRuleTLSVersionsOrCiphers(hostrule.Spec.VirtualHost.TLS) {
+			vsSslProfileNode = buildHostRuleSSLProfileNode(vsNode.GetName(), hostrule.Spec.VirtualHost.TLS)
+			vsSslProfile = fmt.Sprintf("/api/sslprofile?name=%s", vsSslProfileNode.Name)
+		}
+
 		if hostrule.Spec.VirtualHost.WAFPolicy != "" {
 			vsWafPolicy = fmt.Sprintf("/api/wafpolicy?name=%s", hostrule.Spec.VirtualHost.WAFPolicy)
 		}
@@ -80,6 +96,23 @@ func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniMod
 			vsAppProfile = fmt.Sprintf("/api/applicationprofile?name=%s", hostrule.Spec.VirtualHost.ApplicationProfile)
 		}
 
+		if hostrule.Spec.VirtualHost.TLS.ClientCertificate.CABundle.Name != "" ||
+			hostrule.Spec.VirtualHost.RateLimit.RequestsPerSecond > 0 {
+			vsAppProfileNode = buildHostRuleAppProfileNode(vsNode.GetName(), hostrule, key)
+			if vsAppProfileNode != nil {
+				vsAppProfile = fmt.Sprintf("/api/applicationprofile?name=%s", vsAppProfileNode.Name)
+			}
+		}
+
+		// the connection limit of the host applies to the pools which do not set one via httprule
+		if maxConnections := hostrule.Spec.VirtualHost.MaxConnectionsPerServer; maxConnections > 0 {
+			for _, pool := range vsNode.GetPoolRefs() {
+				if pool.MaxConcurrentConnectionsPerServer == 0 {
+					pool.MaxConcurrentConnectionsPerServer = maxConnections
+				}
+			}
+		}
+
 		if hostrule.Spec.VirtualHost.ErrorPageProfile != "" {
 			vsErrorPageProfile = fmt.Sprintf("/api/errorpageprofile?name=%s", hostrule.Spec.VirtualHost.ErrorPageProfile)
 		}
@@ -99,6 +132,14 @@ func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniMod
 			vsNode.SetHttpPolicyRefs([]*AviHttpPolicySetNode{})
 		}
 
+		if !isAccessControlEmpty(hostrule.Spec.VirtualHost.AccessControl) {
+			vsAccessControl = hostrule.Spec.VirtualHost.AccessControl.DeepCopy()
+		}
+
+		if hostrule.Spec.VirtualHost.TLS.HSTS.Enabled {
+			vsHSTS = hostrule.Spec.VirtualHost.TLS.HSTS.DeepCopy()
+		}
+
 		for _, script := range hostrule.Spec.VirtualHost.Datascripts {
 			if !utils.HasElem(vsDatascripts, fmt.Sprintf("/api/vsdatascriptset?name=%s", script)) {
 				vsDatascripts = append(vsDatascripts, fmt.Sprintf("/api/vsdatascriptset?name=%s", script))
@@ -118,13 +159,17 @@ func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniMod
 		}
 	}
 
+	setHostPolicyNode(vsNode, vsAccessControl, vsHSTS)
 	vsNode.SetSSLKeyCertAviRef(vsSslKeyCertificate)
+	vsNode.SetAltSSLKeyCertAviRef(vsAltSslKeyCertificate)
 	vsNode.SetWafPolicyRef(vsWafPolicy)
 	vsNode.SetHttpPolicySetRefs(vsHTTPPolicySets)
 	vsNode.SetAppProfileRef(vsAppProfile)
+	vsNode.SetAppProfileNode(vsAppProfileNode)
 	vsNode.SetAnalyticsProfileRef(vsAnalyticsProfile)
 	vsNode.SetErrorPageProfileRef(vsErrorPageProfile)
 	vsNode.SetSSLProfileRef(vsSslProfile)
+	vsNode.SetSSLProfileNode(vsSslProfileNode)
 	vsNode.SetVsDatascriptRefs(vsDatascripts)
 	vsNode.SetEnabled(vsEnabled)
 
@@ -135,6 +180,416 @@ func BuildL7HostRule(host, namespace, ingName, key string, vsNode AviVsEvhSniMod
 	utils.AviLog.Infof("key: %s, Attached hostrule %s on vsNode %s", key, hrNamespaceName, vsNode.GetName())
 }
 
+// BuildL7AuthRule attaches the authentication of the AuthRule for the host to the virtualhost,
+// the SSO policy created by AKO is removed when no AuthRule is found for the host
+func BuildL7AuthRule(host, key string, vsNode AviVsEvhSniModel) {
+	found, arNamespaceName := objects.SharedCRDLister().GetFQDNToAuthRuleMapping(host)
+	if !found {
+		utils.AviLog.Debugf("key: %s, msg: No AuthRule found for virtualhost: %s in Cache", key, host)
+		vsNode.SetSSOPolicyNode(nil)
+		return
+	}
+
+	arNSName := strings.Split(arNamespaceName, "/")
+	authrule, err := lib.GetCRDInformers().AuthRuleInformer.Lister().AuthRules(arNSName[0]).Get(arNSName[1])
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: No AuthRule found for virtualhost: %s msg: %v", key, host, err)
+		vsNode.SetSSOPolicyNode(nil)
+		return
+	} else if authrule.Status.Status == lib.StatusRejected {
+		// do not apply a rejected authrule, this way the VS would retain
+		return
+	}
+
+	ssoPolicyNode, err := buildAuthRuleSSOPolicyNode(vsNode.GetName(), authrule)
+	if err != nil {
+		// the authentication of the VS is left as is, and not removed, till the authrule is valid again
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		status.UpdateAuthRuleStatus(key, authrule.DeepCopy(), status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		return
+	}
+	vsNode.SetSSOPolicyNode(ssoPolicyNode)
+	utils.AviLog.Infof("key: %s, Attached authrule %s on vsNode %s", key, arNamespaceName, vsNode.GetName())
+}
+
+// buildAuthRuleSSOPolicyNode builds the SSO policy for the AuthRule of a virtualhost, an error is returned
+// when the JSON Web Key Set of the AuthRule is not available
+func buildAuthRuleSSOPolicyNode(vsName string, authrule *akov1alpha1.AuthRule) (*AviSSOPolicyNode, error) {
+	ssoPolicyNode := &AviSSOPolicyNode{
+		Name:   lib.GetVsSSOPolicyName(vsName),
+		Tenant: lib.GetTenant(),
+		Paths:  append([]string{}, authrule.Spec.Paths...),
+	}
+
+	if authProfile := authrule.Spec.AuthProfile; authProfile.Name != "" {
+		entityID, singleSignonURL := authProfile.EntityID, authProfile.SingleSignonURL
+		ssoPolicyNode.Type = "SSO_TYPE_SAML"
+		ssoPolicyNode.AuthProfile = authProfile.Name
+		ssoPolicyNode.SamlSpConfig = &avimodels.SAMLSPConfig{
+			EntityID:        &entityID,
+			SingleSignonURL: &singleSignonURL,
+		}
+		return ssoPolicyNode, nil
+	}
+
+	jwt := authrule.Spec.JWT
+	jwks, err := getAuthRuleJWKS(authrule.Namespace, jwt.JWKS)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt jwks: %v", err)
+	}
+
+	ssoPolicyNode.Type = "SSO_TYPE_JWT"
+	ssoPolicyNode.AuthProfile = lib.GetVsAuthProfileName(vsName)
+	ssoPolicyNode.JWTProfile = &AviJWTProfileNode{
+		Name:        lib.GetVsJWTProfileName(vsName),
+		Tenant:      lib.GetTenant(),
+		AuthProfile: ssoPolicyNode.AuthProfile,
+		Issuer:      jwt.Issuer,
+		JwksKeys:    jwks,
+	}
+
+	audience, jwtLocation := jwt.Audience, "JWT_LOCATION_AUTHORIZATION_HEADER"
+	ssoPolicyNode.JwtConfig = &avimodels.JWTValidationVsConfig{
+		Audience:    &audience,
+		JwtLocation: &jwtLocation,
+	}
+	if jwt.TokenLocation == lib.JWTTokenLocationQuery {
+		jwtLocation, jwtName := "JWT_LOCATION_QUERY_PARAM", jwt.TokenName
+		ssoPolicyNode.JwtConfig.JwtLocation = &jwtLocation
+		ssoPolicyNode.JwtConfig.JwtName = &jwtName
+	}
+	return ssoPolicyNode, nil
+}
+
+// getAuthRuleJWKS reads the JSON Web Key Set from the Secret referred in the AuthRule
+func getAuthRuleJWKS(namespace, secretName string) (string, error) {
+	secret, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(secretName)
+	if err != nil {
+		return "", err
+	}
+	if jwks := string(secret.Data[lib.JWKSKey]); jwks != "" {
+		return jwks, nil
+	}
+	return "", fmt.Errorf("%s not found in Secret %s/%s", lib.JWKSKey, namespace, secretName)
+}
+
+// getHostRuleRedirect returns whether the insecure requests of the host are redirected to HTTPS along with the
+// redirect status code, the HostRule of the host overrides the redirect derived from the Ingress/Route
+func getHostRuleRedirect(host string, redirect bool) (bool, string) {
+	statusCode := lib.STATUS_REDIRECT
+	found, hrNamespaceName := GetHostruleForFqdn(host)
+	if !found {
+		return redirect, statusCode
+	}
+	hrNSName := strings.Split(hrNamespaceName, "/")
+	hostrule, err := lib.GetCRDInformers().HostRuleInformer.Lister().HostRules(hrNSName[0]).Get(hrNSName[1])
+	if err != nil || hostrule.Status.Status == lib.StatusRejected {
+		return redirect, statusCode
+	}
+	tlsRedirect := hostrule.Spec.VirtualHost.TLS.Redirect
+	if tlsRedirect.Enabled != nil {
+		redirect = *tlsRedirect.Enabled
+	}
+	if tlsRedirect.StatusCode != 0 {
+		statusCode = fmt.Sprintf("HTTP_REDIRECT_STATUS_CODE_%d", tlsRedirect.StatusCode)
+	}
+	return redirect, statusCode
+}
+
+// setHostPolicyNode replaces the httppolicyset holding the access control and HSTS settings of the HostRule
+// on the virtualhost, which is removed when the HostRule sets neither
+func setHostPolicyNode(vsNode AviVsEvhSniModel, accessControl *akov1alpha1.AccessControl, hsts *akov1alpha1.HostRuleHSTS) {
+	hostPolicyName := lib.GetVsHostPolicyName(vsNode.GetName())
+	httpPolicyRefs := []*AviHttpPolicySetNode{}
+	changed := false
+	for _, policy := range vsNode.GetHttpPolicyRefs() {
+		if policy.Name == hostPolicyName {
+			changed = true
+			continue
+		}
+		httpPolicyRefs = append(httpPolicyRefs, policy)
+	}
+	if accessControl != nil || hsts != nil {
+		httpPolicyRefs = append(httpPolicyRefs, &AviHttpPolicySetNode{
+			Name:          hostPolicyName,
+			Tenant:        lib.GetTenant(),
+			AccessControl: accessControl,
+			HSTS:          hsts,
+		})
+		changed = true
+	}
+	if changed {
+		vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+	}
+}
+
+func isAccessControlEmpty(accessControl akov1alpha1.AccessControl) bool {
+	return len(accessControl.AllowCIDRs) == 0 && len(accessControl.DenyCIDRs) == 0 &&
+		len(accessControl.AllowIPGroups) == 0 && len(accessControl.DenyIPGroups) == 0
+}
+
+// validateAccessControl checks the CIDRs and IP groups of the access control set for a host or a path
+func validateAccessControl(accessControl akov1alpha1.AccessControl) error {
+	for _, cidrs := range [][]string{accessControl.AllowCIDRs, accessControl.DenyCIDRs} {
+		for _, cidr := range cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid accessControl CIDR %s", cidr)
+			}
+		}
+	}
+	for _, group := range getAccessControlIPGroups(accessControl) {
+		if group == "" {
+			return fmt.Errorf("accessControl IP group name not provided")
+		}
+	}
+	return nil
+}
+
+func getAccessControlIPGroups(accessControl akov1alpha1.AccessControl) []string {
+	groups := make([]string, 0, len(accessControl.AllowIPGroups)+len(accessControl.DenyIPGroups))
+	groups = append(groups, accessControl.AllowIPGroups...)
+	return append(groups, accessControl.DenyIPGroups...)
+}
+
+// clientCertHeaderValues maps the client certificate properties in HostRule to the Avi header values
+var clientCertHeaderValues = map[string]string{
+	"raw":         "HTTP_POLICY_VAR_SSL_CLIENT_RAW",
+	"subject":     "HTTP_POLICY_VAR_SSL_CLIENT_SUBJECT",
+	"issuer":      "HTTP_POLICY_VAR_SSL_CLIENT_ISSUER",
+	"serial":      "HTTP_POLICY_VAR_SSL_CLIENT_SERIAL",
+	"fingerprint": "HTTP_POLICY_VAR_SSL_CLIENT_FINGERPRINT",
+}
+
+// buildHostRuleAppProfileNode builds the application profile validating the client certificates
+// and rate limiting the requests of a virtualhost, nil is returned when the CA bundle of the HostRule
+// is not available
+func buildHostRuleAppProfileNode(vsName string, hostrule *akov1alpha1.HostRule, key string) *AviAppProfileNode {
+	appProfileNode := &AviAppProfileNode{
+		Name:   lib.GetVsAppProfileName(vsName),
+		Tenant: lib.GetTenant(),
+	}
+	if rateLimit := hostrule.Spec.VirtualHost.RateLimit; rateLimit.RequestsPerSecond > 0 {
+		appProfileNode.RateLimit = &rateLimit
+	}
+
+	clientCert := hostrule.Spec.VirtualHost.TLS.ClientCertificate
+	if clientCert.CABundle.Name == "" {
+		return appProfileNode
+	}
+	caBundle, err := getHostRuleCABundle(hostrule.Namespace, clientCert.CABundle)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to read the client CA bundle of hostrule %s/%s: %v", key, hostrule.Namespace, hostrule.Name, err)
+		return nil
+	}
+
+	clientCertMode := "SSL_CLIENT_CERTIFICATE_REQUIRE"
+	if clientCert.Mode == lib.ClientCertModeRequest {
+		clientCertMode = "SSL_CLIENT_CERTIFICATE_REQUEST"
+	}
+
+	var headers []*avimodels.SSLClientRequestHeader
+	for _, header := range clientCert.Headers {
+		headerName := header.Name
+		headerValue := clientCertHeaderValues[header.Value]
+		headers = append(headers, &avimodels.SSLClientRequestHeader{
+			RequestHeader:      &headerName,
+			RequestHeaderValue: &headerValue,
+		})
+	}
+
+	appProfileNode.PkiProfile = &AviPkiProfileNode{
+		Name:   lib.GetVsPKIProfileName(vsName),
+		Tenant: lib.GetTenant(),
+		CACert: caBundle,
+	}
+	appProfileNode.ClientCertMode = clientCertMode
+	appProfileNode.ClientCertHeaders = headers
+	return appProfileNode
+}
+
+// getHostRuleCABundle reads the CA bundle from the Secret or ConfigMap referred in the HostRule,
+// ConfigMaps are watched only in the AKO namespace
+func getHostRuleCABundle(namespace string, caBundle akov1alpha1.HostRuleCABundle) (string, error) {
+	switch caBundle.Kind {
+	case lib.CABundleKindSecret:
+		secret, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(caBundle.Name)
+		if err != nil {
+			return "", err
+		}
+		if ca := string(secret.Data[lib.CABundleKey]); ca != "" {
+			return ca, nil
+		}
+	case lib.CABundleKindConfigMap:
+		if namespace != utils.GetAKONamespace() {
+			return "", fmt.Errorf("caBundle ConfigMap is supported only in namespace %s", utils.GetAKONamespace())
+		}
+		configMap, err := utils.GetInformers().ConfigMapInformer.Lister().ConfigMaps(namespace).Get(caBundle.Name)
+		if err != nil {
+			return "", err
+		}
+		if ca := configMap.Data[lib.CABundleKey]; ca != "" {
+			return ca, nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported caBundle kind %s", caBundle.Kind)
+	}
+	return "", fmt.Errorf("%s not found in %s %s/%s", lib.CABundleKey, caBundle.Kind, namespace, caBundle.Name)
+}
+
+// validateHostRuleClientCertificate checks the client certificate settings of the HostRule
+func validateHostRuleClientCertificate(hostrule *akov1alpha1.HostRule) error {
+	clientCert := hostrule.Spec.VirtualHost.TLS.ClientCertificate
+	if clientCert.CABundle.Name == "" {
+		return nil
+	}
+	if hostrule.Spec.VirtualHost.ApplicationProfile != "" {
+		return fmt.Errorf("applicationProfile %s cannot be used along with clientCertificate", hostrule.Spec.VirtualHost.ApplicationProfile)
+	}
+	if clientCert.Mode != "" && clientCert.Mode != lib.ClientCertModeRequire && clientCert.Mode != lib.ClientCertModeRequest {
+		return fmt.Errorf("invalid clientCertificate mode %s", clientCert.Mode)
+	}
+	for _, header := range clientCert.Headers {
+		if _, ok := clientCertHeaderValues[header.Value]; !ok || header.Name == "" {
+			return fmt.Errorf("invalid clientCertificate header %s: %s", header.Name, header.Value)
+		}
+	}
+	if _, err := getHostRuleCABundle(hostrule.Namespace, clientCert.CABundle); err != nil {
+		return fmt.Errorf("invalid clientCertificate caBundle: %v", err)
+	}
+	return nil
+}
+
+// validateHostRuleRateLimit checks the rate limit of the HostRule, which is set on the application profile created by AKO
+func validateHostRuleRateLimit(hostrule *akov1alpha1.HostRule) error {
+	rateLimit := hostrule.Spec.VirtualHost.RateLimit
+	if rateLimit == (akov1alpha1.RateLimit{}) {
+		return nil
+	}
+	if hostrule.Spec.VirtualHost.ApplicationProfile != "" {
+		return fmt.Errorf("applicationProfile %s cannot be used along with rateLimit", hostrule.Spec.VirtualHost.ApplicationProfile)
+	}
+	return validateRateLimit(rateLimit)
+}
+
+// tlsVersionTypes maps the TLS versions in HostRule to the Avi SSL versions, in increasing order
+var tlsVersionTypes = []struct{ version, versionType string }{
+	{"1.0", "SSL_VERSION_TLS1"},
+	{"1.1", "SSL_VERSION_TLS1_1"},
+	{"1.2", "SSL_VERSION_TLS1_2"},
+	{"1.3", "SSL_VERSION_TLS1_3"},
+}
+
+// getTLSVersionIndex returns the position of the TLS version in tlsVersionTypes, -1 if the version is not supported
+func getTLSVersionIndex(version string) int {
+	for i, tlsVersion := range tlsVersionTypes {
+		if tlsVersion.version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasHostRuleTLSVersionsOrCiphers(tls akov1alpha1.HostRuleTLS) bool {
+	return tls.MinVersion != "" || tls.MaxVersion != "" || tls.Ciphers != "" || tls.Ciphersuites != ""
+}
+
+// buildHostRuleSSLProfileNode builds the SSL profile accepting the TLS versions between minVersion and maxVersion,
+// and the ciphers set in the HostRule, the versions default to 1.2 and 1.3
+func buildHostRuleSSLProfileNode(vsName string, tls akov1alpha1.HostRuleTLS) *AviSSLProfileNode {
+	minIndex, maxIndex := getTLSVersionIndex("1.2"), len(tlsVersionTypes)-1
+	if tls.MinVersion != "" {
+		minIndex = getTLSVersionIndex(tls.MinVersion)
+	}
+	if tls.MaxVersion != "" {
+		maxIndex = getTLSVersionIndex(tls.MaxVersion)
+	}
+	if maxIndex < minIndex {
+		minIndex = maxIndex
+	}
+	var acceptedVersions []string
+	for _, tlsVersion := range tlsVersionTypes[minIndex : maxIndex+1] {
+		acceptedVersions = append(acceptedVersions, tlsVersion.versionType)
+	}
+	return &AviSSLProfileNode{
+		Name:             lib.GetVsSSLProfileName(vsName),
+		Tenant:           lib.GetTenant(),
+		AcceptedVersions: acceptedVersions,
+		AcceptedCiphers:  tls.Ciphers,
+		Ciphersuites:     tls.Ciphersuites,
+	}
+}
+
+// validateHostRuleTLS checks the redirect, HSTS, TLS versions and ciphers set in the HostRule
+func validateHostRuleTLS(hostrule *akov1alpha1.HostRule) error {
+	tls := hostrule.Spec.VirtualHost.TLS
+	switch tls.Redirect.StatusCode {
+	case 0, 301, 302, 307:
+	default:
+		return fmt.Errorf("unsupported redirect statusCode %d", tls.Redirect.StatusCode)
+	}
+	if tls.HSTS.MaxAge < 0 {
+		return fmt.Errorf("hsts maxAge %d must not be negative", tls.HSTS.MaxAge)
+	}
+	if tls.Termination == lib.TLSTerminationPassthrough && (tls.SSLKeyCertificate.Name != "" || tls.SSLProfile != "") {
+		return fmt.Errorf("sslKeyCertificate and sslProfile cannot be used with termination %s", lib.TLSTerminationPassthrough)
+	}
+	if altCert := tls.SSLKeyCertificate.AlternateCertificate; altCert.Name != "" {
+		if tls.SSLKeyCertificate.Name == "" {
+			return fmt.Errorf("alternateCertificate %s requires an sslKeyCertificate", altCert.Name)
+		}
+		if altCert.Name == tls.SSLKeyCertificate.Name {
+			return fmt.Errorf("alternateCertificate %s must differ from the sslKeyCertificate", altCert.Name)
+		}
+	}
+	if !hasHostRuleTLSVersionsOrCiphers(tls) {
+		return nil
+	}
+	if tls.SSLProfile != "" {
+		return fmt.Errorf("sslProfile %s cannot be used along with TLS versions or ciphers", tls.SSLProfile)
+	}
+	for _, version := range []string{tls.MinVersion, tls.MaxVersion} {
+		if version != "" && getTLSVersionIndex(version) < 0 {
+			return fmt.Errorf("unsupported TLS version %s", version)
+		}
+	}
+	if tls.MinVersion != "" && tls.MaxVersion != "" && getTLSVersionIndex(tls.MinVersion) > getTLSVersionIndex(tls.MaxVersion) {
+		return fmt.Errorf("minVersion %s cannot be greater than maxVersion %s", tls.MinVersion, tls.MaxVersion)
+	}
+	return nil
+}
+
+// buildPoolClientCertNode builds the key/cert node presented by the pool to its backends,
+// from the tls secret referred in the httprule
+func buildPoolClientCertNode(poolName, namespace, secretName, key string) *AviTLSKeyCertNode {
+	cert, tlsKey, err := getPoolClientCertKeyPair(namespace, secretName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to read client certificate for pool %s: %v", key, poolName, err)
+		return nil
+	}
+	return &AviTLSKeyCertNode{
+		Name:   lib.GetPoolClientCertName(poolName),
+		Tenant: lib.GetTenant(),
+		Type:   lib.CertTypeVS,
+		Cert:   cert,
+		Key:    tlsKey,
+	}
+}
+
+func getPoolClientCertKeyPair(namespace, secretName string) ([]byte, []byte, error) {
+	secret, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(secretName)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, tlsKey := secret.Data[tlsCert], secret.Data[utils.K8S_TLS_SECRET_KEY]
+	if len(cert) == 0 || len(tlsKey) == 0 {
+		return nil, nil, fmt.Errorf("%s or %s not found in secret %s/%s", tlsCert, utils.K8S_TLS_SECRET_KEY, namespace, secretName)
+	}
+	return cert, tlsKey, nil
+}
+
 // BuildPoolHTTPRule notes
 // when we get an ingress update and we are building the corresponding pools of that ingress
 // we need to get all httprules which match ingress's host/path
@@ -155,15 +610,17 @@ func BuildPoolHTTPRule(host, path, ingName, namespace, key string, vsNode AviVsE
 
 	// maintains map of rrname+path: rrobj.spec.paths, prefetched for compute ahead
 	httpruleNameObjMap := make(map[string]akov1alpha1.HTTPRulePaths)
+	httpRuleObjs := make(map[string]*akov1alpha1.HTTPRule)
 	for _, httprule := range getHTTPRules {
 		pathNSName := strings.Split(httprule, "/")
 		httpRuleObj, err := lib.GetCRDInformers().HTTPRuleInformer.Lister().HTTPRules(pathNSName[0]).Get(pathNSName[1])
 		if err != nil {
 			utils.AviLog.Debugf("key: %s, msg: httprule not found err: %+v", key, err)
 			continue
-		} else if httpRuleObj.Status.Status == lib.StatusRejected {
+		} else if httpRuleObj.Status.Status == lib.StatusRejected && !(isSNI && isHTTPRuleRejectedForInsecureHost(httpRuleObj, host)) {
 			continue
 		}
+		httpRuleObjs[httprule] = httpRuleObj
 		for _, path := range httpRuleObj.Spec.Paths {
 			httpruleNameObjMap[httprule+path.Target] = path
 		}
@@ -171,6 +628,7 @@ func BuildPoolHTTPRule(host, path, ingName, namespace, key string, vsNode AviVsE
 
 	// iterate through httpRule which we get from GetFqdnHTTPRulesMapping
 	// must contain fqdn.com: {path1: rr1, path2: rr1, path3: rr2}
+	rejectedRules := make(map[string]bool)
 	for path, rule := range pathRules {
 		rrNamespace := strings.Split(rule, "/")[0]
 		httpRulePath, ok := httpruleNameObjMap[rule+path]
@@ -181,21 +639,38 @@ func BuildPoolHTTPRule(host, path, ingName, namespace, key string, vsNode AviVsE
 			continue
 		}
 
+		// pathprefix match
+		// lets say path: / and available pools are cluster--namespace-host_foo-ingName, cluster--namespace-host_bar-ingName
+		// then cluster--namespace-host_-ingName should qualify for both pools
+		// basic path prefix regex: ^<path_entered>.*
+		pathPrefix := strings.ReplaceAll(path, "/", "_")
+		// sni poolname match regex
+		secureRgx := regexp.MustCompile(fmt.Sprintf(`^%s%s-%s%s.*-%s`, lib.GetNamePrefix(), rrNamespace, host, pathPrefix, ingName))
+		// sharedvs poolname match regex
+		insecureRgx := regexp.MustCompile(fmt.Sprintf(`^%s%s.*-%s-%s`, lib.GetNamePrefix(), host+pathPrefix, rrNamespace, ingName))
+
+		// path actions are set only on the sni/evh child, the httprule is rejected for a path served on the insecure shared vs
+		if !isSNI && hasHTTPRulePathActions(httpRulePath) {
+			for _, pool := range vsNode.GetPoolRefs() {
+				if insecureRgx.MatchString(pool.Name) {
+					rejectHTTPRuleForInsecureHost(key, httpRuleObjs[rule], host,
+						fmt.Sprintf("header, rewrite, redirect, rate limit and access control actions of target %s apply only to secure hosts", path))
+					rejectedRules[rule] = true
+					break
+				}
+			}
+		}
+		if rejectedRules[rule] {
+			continue
+		}
+
 		for _, pool := range vsNode.GetPoolRefs() {
 			isPathSniEnabled := pool.SniEnabled
 			pathSslProfile := pool.SslProfileRef
 			destinationCertNode := pool.PkiProfile
+			clientCertNode := pool.SSLKeyCert
 			pathHMs := pool.HealthMonitors
-
-			// pathprefix match
-			// lets say path: / and available pools are cluster--namespace-host_foo-ingName, cluster--namespace-host_bar-ingName
-			// then cluster--namespace-host_-ingName should qualify for both pools
-			// basic path prefix regex: ^<path_entered>.*
-			pathPrefix := strings.ReplaceAll(path, "/", "_")
-			// sni poolname match regex
-			secureRgx := regexp.MustCompile(fmt.Sprintf(`^%s%s-%s%s.*-%s`, lib.GetNamePrefix(), rrNamespace, host, pathPrefix, ingName))
-			// sharedvs poolname match regex
-			insecureRgx := regexp.MustCompile(fmt.Sprintf(`^%s%s.*-%s-%s`, lib.GetNamePrefix(), host+pathPrefix, rrNamespace, ingName))
+			pathHMNodes := pool.HealthMonitorNodes
 
 			if (secureRgx.MatchString(pool.Name) && isSNI) || (insecureRgx.MatchString(pool.Name) && !isSNI) {
 				utils.AviLog.Debugf("key: %s, msg: computing poolNode %s for httprule.paths.target %s", key, pool.Name, path)
@@ -217,6 +692,12 @@ func BuildPoolHTTPRule(host, path, ingName, namespace, key string, vsNode AviVsE
 					} else {
 						destinationCertNode = nil
 					}
+
+					if httpRulePath.TLS.ClientCertificate != "" {
+						clientCertNode = buildPoolClientCertNode(pool.Name, rrNamespace, httpRulePath.TLS.ClientCertificate, key)
+					} else {
+						clientCertNode = nil
+					}
 				}
 
 				for _, hm := range httpRulePath.HealthMonitors {
@@ -225,10 +706,36 @@ func BuildPoolHTTPRule(host, path, ingName, namespace, key string, vsNode AviVsE
 					}
 				}
 
+				for _, hm := range httpRulePath.HealthMonitorSpecs {
+					hmNode := buildHealthMonitorNode(pool.Name, hm)
+					found := false
+					for i := range pathHMNodes {
+						if pathHMNodes[i].Name == hmNode.Name {
+							pathHMNodes[i], found = hmNode, true
+						}
+					}
+					if !found {
+						pathHMNodes = append(pathHMNodes, hmNode)
+					}
+				}
+
 				pool.SniEnabled = isPathSniEnabled
 				pool.SslProfileRef = pathSslProfile
 				pool.PkiProfile = destinationCertNode
+				pool.SSLKeyCert = clientCertNode
 				pool.HealthMonitors = pathHMs
+				pool.HealthMonitorNodes = pathHMNodes
+				if httpRulePath.MaxConnectionsPerServer > 0 {
+					pool.MaxConcurrentConnectionsPerServer = httpRulePath.MaxConnectionsPerServer
+				}
+				pool.ApplicationPersistenceProfileRef, pool.PersistenceProfile = buildPoolPersistence(pool.Name, httpRulePath.Persistence)
+				setPoolConnection(pool, httpRulePath.Connection)
+				switch httpRulePath.BackendProtocol {
+				case lib.BackendProtocolHTTP1:
+					pool.EnableHTTP2 = false
+				case lib.BackendProtocolH2C, lib.BackendProtocolGRPC:
+					pool.EnableHTTP2 = true
+				}
 
 				// from this path, generate refs to this pool node
 				pool.LbAlgorithm = httpRulePath.LoadBalancerPolicy.Algorithm
@@ -252,16 +759,287 @@ func BuildPoolHTTPRule(host, path, ingName, namespace, key string, vsNode AviVsE
 				utils.AviLog.Infof("key: %s, Attached httprule %s on pool %s", key, rule, pool.Name)
 			}
 		}
+
+		// header, rewrite and redirect actions are set on the path specific httppolicyset of the sni/evh child,
+		// which shares the pool naming and switches the path to its poolgroup
+		if !isSNI || !hasHTTPRulePathActions(httpRulePath) {
+			continue
+		}
+		for _, policy := range vsNode.GetHttpPolicyRefs() {
+			if !secureRgx.MatchString(policy.Name) {
+				continue
+			}
+			for i := range policy.HppMap {
+				policy.HppMap[i].PathActions = &AviHTTPPathActions{
+					Target:          path,
+					RequestHeaders:  httpRulePath.RequestHeaders,
+					ResponseHeaders: httpRulePath.ResponseHeaders,
+					Rewrite:         httpRulePath.Rewrite,
+					Redirect:        httpRulePath.Redirect,
+					RateLimit:       httpRulePath.RateLimit,
+					AccessControl:   httpRulePath.AccessControl,
+				}
+			}
+			utils.AviLog.Infof("key: %s, Attached httprule %s actions on httppolicyset %s", key, rule, policy.Name)
+		}
+	}
+
+	// httprules rejected while the host was insecure are accepted once they are applied on the secure host
+	for rule, httpRuleObj := range httpRuleObjs {
+		if isSNI && isHTTPRuleRejectedForInsecureHost(httpRuleObj, host) {
+			utils.AviLog.Infof("key: %s, msg: host %s is secure, accepting httprule %s", key, host, rule)
+			status.UpdateHTTPRuleStatus(key, httpRuleObj.DeepCopy(), status.UpdateCRDStatusOptions{
+				Status: lib.StatusAccepted,
+				Error:  "",
+			})
+		}
 	}
 
 	return
 }
 
+// filterHTTPRuleBackendsForInsecureHost removes the backends of the httprules rejected for an insecure host from its paths.
+// Canaries need switching rules, which are only available on the child vs, so an httprule with a canary is rejected for the insecure host.
+func filterHTTPRuleBackendsForInsecureHost(key, host string, pathsvc []IngressHostPathSvc) []IngressHostPathSvc {
+	rejectedRules := make(map[string]bool)
+	for _, obj := range pathsvc {
+		if !obj.httpRuleBackend || rejectedRules[obj.httpRule] {
+			continue
+		}
+		ruleNSName := strings.Split(obj.httpRule, "/")
+		httpRuleObj, err := lib.GetCRDInformers().HTTPRuleInformer.Lister().HTTPRules(ruleNSName[0]).Get(ruleNSName[1])
+		if err != nil || httpRuleObj.Status.Status == lib.StatusRejected {
+			rejectedRules[obj.httpRule] = true
+		} else if obj.canary != nil {
+			rejectHTTPRuleForInsecureHost(key, httpRuleObj, host, fmt.Sprintf("canary backend %s of path %s applies only to secure hosts", obj.ServiceName, obj.Path))
+			rejectedRules[obj.httpRule] = true
+		}
+	}
+
+	var filtered []IngressHostPathSvc
+	for _, obj := range pathsvc {
+		if obj.httpRuleBackend && rejectedRules[obj.httpRule] {
+			continue
+		}
+		filtered = append(filtered, obj)
+	}
+	return filtered
+}
+
+// httpRuleInsecureHostError prefixes the status error of an httprule rejected for settings which apply only to secure hosts.
+func httpRuleInsecureHostError(host string) string {
+	return fmt.Sprintf("host %s is insecure, ", host)
+}
+
+func isHTTPRuleRejectedForInsecureHost(httpRuleObj *akov1alpha1.HTTPRule, host string) bool {
+	return httpRuleObj.Status.Status == lib.StatusRejected && strings.HasPrefix(httpRuleObj.Status.Error, httpRuleInsecureHostError(host))
+}
+
+// rejectHTTPRuleForInsecureHost marks the httprule Rejected, the httprule is skipped for the host until the host is secure.
+func rejectHTTPRuleForInsecureHost(key string, httpRuleObj *akov1alpha1.HTTPRule, host, msg string) {
+	utils.AviLog.Warnf("key: %s, msg: rejecting httprule %s/%s, %s%s", key, httpRuleObj.Namespace, httpRuleObj.Name, httpRuleInsecureHostError(host), msg)
+	status.UpdateHTTPRuleStatus(key, httpRuleObj.DeepCopy(), status.UpdateCRDStatusOptions{
+		Status: lib.StatusRejected,
+		Error:  httpRuleInsecureHostError(host) + msg,
+	})
+}
+
+// setPoolConnection sets the server connection timeouts, reuse and retries of a pool from the httprule path
+func setPoolConnection(pool *AviPoolNode, connection akov1alpha1.HTTPRuleConnection) {
+	pool.ServerTimeout = connection.RequestTimeout * 1000
+	pool.ConnIdleTimeout = connection.IdleTimeout * 1000
+	pool.ConnMaxReuse = connection.MaxReuse
+	if connection.DisableReuse {
+		pool.ConnMaxReuse = 1
+	}
+	pool.RetryCount = connection.Retries
+	pool.RetryTimeout = connection.RetryTimeout * 1000
+}
+
+// buildPoolPersistence returns the ref of the persistence profile of a pool when the httprule path refers to a
+// profile on the controller, or else the persistence profile node which AKO creates for the persistence type
+func buildPoolPersistence(poolName string, persistence akov1alpha1.HTTPRulePersistence) (string, *AviPersistenceProfileNode) {
+	if persistence.Profile != "" {
+		return fmt.Sprintf("/api/applicationpersistenceprofile?name=%s", persistence.Profile), nil
+	}
+
+	persistenceNode := &AviPersistenceProfileNode{
+		Name:    lib.GetPoolPersistenceProfileName(poolName),
+		Tenant:  lib.GetTenant(),
+		Timeout: persistence.Timeout,
+	}
+	switch persistence.Type {
+	case lib.PersistenceTypeCookie:
+		persistenceNode.PersistenceType = "PERSISTENCE_TYPE_HTTP_COOKIE"
+		persistenceNode.CookieName = persistence.CookieName
+	case lib.PersistenceTypeHeader:
+		persistenceNode.PersistenceType = "PERSISTENCE_TYPE_CUSTOM_HTTP_HEADER"
+		persistenceNode.HeaderName = persistence.HeaderName
+	case lib.PersistenceTypeClientIP:
+		persistenceNode.PersistenceType = "PERSISTENCE_TYPE_CLIENT_IP_ADDRESS"
+	default:
+		return "", nil
+	}
+	return "", persistenceNode
+}
+
+// buildHealthMonitorNode builds the AKO managed health monitor of a pool from
+// the httprule health monitor spec, unset settings take the controller defaults
+func buildHealthMonitorNode(poolName string, hm akov1alpha1.HTTPRuleHealthMonitor) *AviHealthMonitorNode {
+	hmNode := &AviHealthMonitorNode{
+		Name:             lib.GetPoolHealthMonitorName(poolName, hm.Name),
+		Tenant:           lib.GetTenant(),
+		Type:             "HEALTH_MONITOR_" + hm.Type,
+		MonitorPort:      hm.Port,
+		SendInterval:     lib.DefaultHMSendInterval,
+		ReceiveTimeout:   lib.DefaultHMReceiveTimeout,
+		SuccessfulChecks: lib.DefaultHMSuccessfulChecks,
+		FailedChecks:     lib.DefaultHMFailedChecks,
+	}
+	if hm.Interval != 0 {
+		hmNode.SendInterval = hm.Interval
+	}
+	if hm.Timeout != 0 {
+		hmNode.ReceiveTimeout = hm.Timeout
+	}
+	if hm.SuccessfulChecks != 0 {
+		hmNode.SuccessfulChecks = hm.SuccessfulChecks
+	}
+	if hm.FailedChecks != 0 {
+		hmNode.FailedChecks = hm.FailedChecks
+	}
+
+	if hm.Type == utils.HTTP || hm.Type == utils.HTTPS {
+		path := hm.Path
+		if path == "" {
+			path = "/"
+		}
+		hmNode.HTTPRequest = fmt.Sprintf("GET %s HTTP/1.0", path)
+		codes := hm.ExpectedCodes
+		if len(codes) == 0 {
+			codes = []string{"2xx", "3xx"}
+		}
+		for _, code := range codes {
+			hmNode.HTTPResponseCodes = append(hmNode.HTTPResponseCodes, "HTTP_"+strings.ToUpper(code))
+		}
+	}
+	return hmNode
+}
+
+func hasHTTPRulePathActions(httpRulePath akov1alpha1.HTTPRulePaths) bool {
+	return !reflect.DeepEqual(httpRulePath.RequestHeaders, akov1alpha1.HTTPRuleHeaders{}) ||
+		!reflect.DeepEqual(httpRulePath.ResponseHeaders, akov1alpha1.HTTPRuleHeaders{}) ||
+		httpRulePath.Rewrite != (akov1alpha1.HTTPRuleRewrite{}) ||
+		httpRulePath.Redirect != (akov1alpha1.HTTPRuleRedirect{}) ||
+		httpRulePath.RateLimit.RequestsPerSecond > 0 ||
+		!isAccessControlEmpty(httpRulePath.AccessControl)
+}
+
+// GetHostruleForFqdn returns the HostRule applicable for a host. A HostRule with an exact fqdn match
+// takes precedence over the ones with Wildcard fqdnType, which in turn take precedence over Regex fqdnType.
+// Amongst multiple matching wildcard fqdns, the most specific one is chosen.
+func GetHostruleForFqdn(host string) (bool, string) {
+	if found, hostrule := objects.SharedCRDLister().GetFQDNToHostruleMapping(host); found {
+		return true, hostrule
+	}
+
+	var matchedFqdn, matchedFqdnType string
+	for fqdn, fqdnTypeIntf := range objects.SharedCRDLister().GetAllFQDNToFQDNTypeMapping() {
+		fqdnType := fqdnTypeIntf.(string)
+		if !isHostruleFqdnMatch(fqdn, fqdnType, host) {
+			continue
+		}
+		if matchedFqdn == "" || hostruleFqdnPrecedes(fqdn, fqdnType, matchedFqdn, matchedFqdnType) {
+			matchedFqdn, matchedFqdnType = fqdn, fqdnType
+		}
+	}
+	if matchedFqdn == "" {
+		return false, ""
+	}
+	return objects.SharedCRDLister().GetFQDNToHostruleMapping(matchedFqdn)
+}
+
+func isHostruleFqdnMatch(fqdn, fqdnType, host string) bool {
+	switch fqdnType {
+	case lib.FqdnTypeWildcard:
+		return lib.WildcardHostMatch(fqdn, host)
+	case lib.FqdnTypeRegex:
+		re, err := regexp.Compile("^(?:" + fqdn + ")$")
+		return err == nil && re.MatchString(host)
+	}
+	return fqdn == host
+}
+
+func hostruleFqdnPrecedes(fqdn, fqdnType, otherFqdn, otherFqdnType string) bool {
+	if fqdnType != otherFqdnType {
+		return fqdnType == lib.FqdnTypeWildcard
+	}
+	if fqdnType == lib.FqdnTypeWildcard && len(fqdn) != len(otherFqdn) {
+		return len(fqdn) > len(otherFqdn)
+	}
+	return fqdn < otherFqdn
+}
+
 // validateHostRuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func validateHostRuleObj(key string, hostrule *akov1alpha1.HostRule) error {
 	var err error
 	fqdn := hostrule.Spec.VirtualHost.Fqdn
+	switch hostrule.Spec.VirtualHost.FqdnType {
+	case lib.FqdnTypeWildcard:
+		if !lib.IsWildcardHost(fqdn) || !lib.IsValidWildcardHost(fqdn) {
+			err = fmt.Errorf("fqdn %s must be of the form *.example.com for fqdnType %s", fqdn, lib.FqdnTypeWildcard)
+		}
+	case lib.FqdnTypeRegex:
+		if _, reErr := regexp.Compile(fqdn); reErr != nil {
+			err = fmt.Errorf("invalid regex fqdn %s: %v", fqdn, reErr)
+		}
+	}
+	if err != nil {
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		return err
+	}
+
+	if err = validateHostRuleClientCertificate(hostrule); err != nil {
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		return err
+	}
+
+	if err = validateAccessControl(hostrule.Spec.VirtualHost.AccessControl); err != nil {
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		return err
+	}
+
+	if err = validateHostRuleRateLimit(hostrule); err != nil {
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		return err
+	}
+
+	if err = validateHostRuleTLS(hostrule); err != nil {
+		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		return err
+	}
+
 	foundHost, foundHR := objects.SharedCRDLister().GetFQDNToHostruleMapping(fqdn)
 	if foundHost && foundHR != hostrule.Namespace+"/"+hostrule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundHR)
@@ -282,6 +1060,10 @@ func validateHostRuleObj(key string, hostrule *akov1alpha1.HostRule) error {
 		hostrule.Spec.VirtualHost.ErrorPageProfile:           "ErrorPageProfile",
 	}
 
+	if altCert := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate; altCert.Name != "" {
+		refData[altCert.Name] = "SslKeyCert"
+	}
+
 	for _, policy := range hostrule.Spec.VirtualHost.HTTPPolicy.PolicySets {
 		refData[policy] = "HttpPolicySet"
 	}
@@ -290,6 +1072,10 @@ func validateHostRuleObj(key string, hostrule *akov1alpha1.HostRule) error {
 		refData[script] = "VsDatascript"
 	}
 
+	for _, group := range getAccessControlIPGroups(hostrule.Spec.VirtualHost.AccessControl) {
+		refData[group] = "IpAddrGroup"
+	}
+
 	if err := checkRefsOnController(key, refData); err != nil {
 		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
@@ -305,16 +1091,156 @@ func validateHostRuleObj(key string, hostrule *akov1alpha1.HostRule) error {
 	return nil
 }
 
+// validateAuthRuleObj would do validation checks on the AuthRule
+// and update its status, the auth profile it refers to must exist on the controller
+func validateAuthRuleObj(key string, authrule *akov1alpha1.AuthRule) error {
+	err := validateAuthRuleSpec(authrule)
+	if err == nil {
+		foundHost, foundAR := objects.SharedCRDLister().GetFQDNToAuthRuleMapping(authrule.Spec.Fqdn)
+		if foundHost && foundAR != authrule.Namespace+"/"+authrule.Name {
+			err = fmt.Errorf("duplicate fqdn %s found in %s", authrule.Spec.Fqdn, foundAR)
+		}
+	}
+	if err == nil {
+		err = checkRefsOnController(key, map[string]string{authrule.Spec.AuthProfile.Name: "AuthProfile"})
+	}
+	if err != nil {
+		status.UpdateAuthRuleStatus(key, authrule, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+		return err
+	}
+
+	status.UpdateAuthRuleStatus(key, authrule, status.UpdateCRDStatusOptions{
+		Status: lib.StatusAccepted,
+		Error:  "",
+	})
+	return nil
+}
+
+// validateAuthRuleSpec checks that the AuthRule sets exactly one of jwt or authProfile, along with their required fields
+func validateAuthRuleSpec(authrule *akov1alpha1.AuthRule) error {
+	spec := authrule.Spec
+	if spec.Fqdn == "" {
+		return fmt.Errorf("fqdn must be set")
+	}
+	for _, path := range spec.Paths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid path %s, must begin with /", path)
+		}
+	}
+
+	hasJWT := spec.JWT != (akov1alpha1.AuthRuleJWT{})
+	hasAuthProfile := spec.AuthProfile != (akov1alpha1.AuthRuleAuthProfile{})
+	if hasJWT == hasAuthProfile {
+		return fmt.Errorf("exactly one of jwt or authProfile must be set")
+	}
+
+	if hasAuthProfile {
+		if spec.AuthProfile.Name == "" || spec.AuthProfile.EntityID == "" || spec.AuthProfile.SingleSignonURL == "" {
+			return fmt.Errorf("authProfile requires name, entityID and singleSignonURL")
+		}
+		return nil
+	}
+
+	if spec.JWT.Issuer == "" || spec.JWT.Audience == "" || spec.JWT.JWKS == "" {
+		return fmt.Errorf("jwt requires issuer, audience and jwks")
+	}
+	switch spec.JWT.TokenLocation {
+	case "", lib.JWTTokenLocationHeader:
+	case lib.JWTTokenLocationQuery:
+		if spec.JWT.TokenName == "" {
+			return fmt.Errorf("jwt tokenName must be set for tokenLocation %s", lib.JWTTokenLocationQuery)
+		}
+	default:
+		return fmt.Errorf("invalid jwt tokenLocation %s", spec.JWT.TokenLocation)
+	}
+	if _, err := getAuthRuleJWKS(authrule.Namespace, spec.JWT.JWKS); err != nil {
+		return fmt.Errorf("invalid jwt jwks: %v", err)
+	}
+	return nil
+}
+
 // validateHTTPRuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func validateHTTPRuleObj(key string, httprule *akov1alpha1.HTTPRule) error {
 	refData := make(map[string]string)
 	for _, path := range httprule.Spec.Paths {
 		refData[path.TLS.SSLProfile] = "SslProfile"
+		refData[path.Persistence.Profile] = "PersistenceProfile"
 
 		for _, hm := range path.HealthMonitors {
 			refData[hm] = "HealthMonitor"
 		}
+
+		for _, group := range getAccessControlIPGroups(path.AccessControl) {
+			refData[group] = "IpAddrGroup"
+		}
+
+		if err := validateHTTPRulePathBackends(path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
+
+		if err := validateHTTPRulePathActions(path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
+
+		if err := validateHTTPRuleClientCertificate(httprule.Namespace, path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
+
+		if err := validateHTTPRuleHealthMonitors(path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
+
+		if err := validateHTTPRuleBackendProtocol(path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
+
+		if err := validateHTTPRuleConnection(path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
+
+		if err := validateHTTPRulePersistence(path); err != nil {
+			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+				Status: lib.StatusRejected,
+				Error:  err.Error(),
+			})
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			return err
+		}
 	}
 
 	if err := checkRefsOnController(key, refData); err != nil {
@@ -326,12 +1252,262 @@ func validateHTTPRuleObj(key string, httprule *akov1alpha1.HTTPRule) error {
 	}
 
 	status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
-		Status: lib.StatusAccepted,
-		Error:  "",
+		Status:  lib.StatusAccepted,
+		Error:   "",
+		Warning: getHTTPRuleWarnings(httprule),
 	})
 	return nil
 }
 
+// getHTTPRuleWarnings returns the limitations which apply to an accepted httprule, the grpc paths are
+// health checked over TCP only, as there is no gRPC health monitor to create
+func getHTTPRuleWarnings(httprule *akov1alpha1.HTTPRule) string {
+	var warnings []string
+	for _, path := range httprule.Spec.Paths {
+		if path.BackendProtocol == lib.BackendProtocolGRPC {
+			warnings = append(warnings, fmt.Sprintf("gRPC health checks are not supported, the servers of target %s are health checked over TCP only", path.Target))
+		}
+	}
+	return strings.Join(warnings, "; ")
+}
+
+// validateHTTPRuleClientCertificate checks that the client certificate of a path is used for
+// re-encrypt only, and that the referred secret holds a key/cert pair
+func validateHTTPRuleClientCertificate(namespace string, path akov1alpha1.HTTPRulePaths) error {
+	if path.TLS.ClientCertificate == "" {
+		return nil
+	}
+	if path.TLS.Type != lib.TypeTLSReencrypt {
+		return fmt.Errorf("clientCertificate for target %s requires tls type %s", path.Target, lib.TypeTLSReencrypt)
+	}
+	if _, _, err := getPoolClientCertKeyPair(namespace, path.TLS.ClientCertificate); err != nil {
+		return fmt.Errorf("invalid clientCertificate for target %s: %v", path.Target, err)
+	}
+	return nil
+}
+
+// validateHTTPRulePathActions checks the header, rewrite and redirect actions of an httprule path
+func validateHTTPRulePathActions(path akov1alpha1.HTTPRulePaths) error {
+	for _, headers := range []akov1alpha1.HTTPRuleHeaders{path.RequestHeaders, path.ResponseHeaders} {
+		for _, header := range append(headers.Add, headers.Replace...) {
+			if header.Name == "" {
+				return fmt.Errorf("header name not provided for target %s", path.Target)
+			}
+		}
+		for _, name := range headers.Remove {
+			if name == "" {
+				return fmt.Errorf("header name not provided for target %s", path.Target)
+			}
+		}
+	}
+
+	if path.Rewrite.PathPrefix != "" && path.Rewrite.StripPathPrefix {
+		return fmt.Errorf("rewrite pathPrefix and stripPathPrefix are mutually exclusive for target %s", path.Target)
+	}
+
+	if path.Redirect != (akov1alpha1.HTTPRuleRedirect{}) {
+		if path.Rewrite != (akov1alpha1.HTTPRuleRewrite{}) {
+			return fmt.Errorf("rewrite and redirect are mutually exclusive for target %s", path.Target)
+		}
+		if err := validateRedirect(path.Redirect); err != nil {
+			return fmt.Errorf("%v for target %s", err, path.Target)
+		}
+	}
+
+	if path.RateLimit.Key == lib.RateLimitKeyHeader {
+		return fmt.Errorf("rateLimit key %s is not supported for target %s", lib.RateLimitKeyHeader, path.Target)
+	}
+	if err := validateRateLimit(path.RateLimit); err != nil {
+		return fmt.Errorf("%v for target %s", err, path.Target)
+	}
+	if err := validateAccessControl(path.AccessControl); err != nil {
+		return fmt.Errorf("%v for target %s", err, path.Target)
+	}
+	return nil
+}
+
+func validateRedirect(redirect akov1alpha1.HTTPRuleRedirect) error {
+	if redirect.Protocol != "" && redirect.Protocol != utils.HTTP && redirect.Protocol != utils.HTTPS {
+		return fmt.Errorf("invalid redirect protocol %s", redirect.Protocol)
+	}
+	switch redirect.StatusCode {
+	case 0, 301, 302, 307:
+	default:
+		return fmt.Errorf("invalid redirect statusCode %d", redirect.StatusCode)
+	}
+	return nil
+}
+
+// validateRateLimit checks the rate limit set for a host or a path, an empty rate limit is valid
+func validateRateLimit(rateLimit akov1alpha1.RateLimit) error {
+	if rateLimit == (akov1alpha1.RateLimit{}) {
+		return nil
+	}
+	if rateLimit.RequestsPerSecond <= 0 {
+		return fmt.Errorf("rateLimit requestsPerSecond must be greater than 0")
+	}
+	switch rateLimit.Key {
+	case "", lib.RateLimitKeyClientIP:
+	case lib.RateLimitKeyHeader:
+		if rateLimit.Header == "" {
+			return fmt.Errorf("rateLimit header not provided for key %s", lib.RateLimitKeyHeader)
+		}
+	default:
+		return fmt.Errorf("invalid rateLimit key %s", rateLimit.Key)
+	}
+	switch rateLimit.Action.Type {
+	case "", lib.RateLimitActionDrop, lib.RateLimitActionReject:
+	case lib.RateLimitActionRedirect:
+		if err := validateRedirect(rateLimit.Action.Redirect); err != nil {
+			return fmt.Errorf("rateLimit action: %v", err)
+		}
+	default:
+		return fmt.Errorf("invalid rateLimit action %s", rateLimit.Action.Type)
+	}
+	return nil
+}
+
+// validateHTTPRulePathBackends checks the weighted and canary backends of an httprule path
+func validateHTTPRulePathBackends(path akov1alpha1.HTTPRulePaths) error {
+	var services []string
+	for _, backend := range path.Backends {
+		if backend.ServiceName == "" {
+			return fmt.Errorf("backend serviceName not provided for target %s", path.Target)
+		}
+		if utils.HasElem(services, backend.ServiceName) {
+			return fmt.Errorf("duplicate backend %s for target %s", backend.ServiceName, path.Target)
+		}
+		services = append(services, backend.ServiceName)
+	}
+
+	// the backends are added to the pools of the hostname shard and evh child vses
+	if (len(path.Backends) > 0 || path.Canary != (akov1alpha1.HTTPRuleCanary{})) && lib.GetShardScheme() == lib.NAMESPACE_SHARD_SCHEME {
+		return fmt.Errorf("weighted and canary backends for target %s are supported only with hostname sharding", path.Target)
+	}
+
+	if path.Canary != (akov1alpha1.HTTPRuleCanary{}) {
+		if path.Canary.ServiceName == "" {
+			return fmt.Errorf("canary serviceName not provided for target %s", path.Target)
+		}
+		if path.Canary.Header.Name == "" && path.Canary.Cookie.Name == "" {
+			return fmt.Errorf("canary header or cookie not provided for target %s", path.Target)
+		}
+		if utils.HasElem(services, path.Canary.ServiceName) {
+			return fmt.Errorf("canary %s is also a backend for target %s", path.Canary.ServiceName, path.Target)
+		}
+	}
+	return nil
+}
+
+// validateHTTPRuleHealthMonitors checks the health monitor specs of an httprule path
+func validateHTTPRuleHealthMonitors(path akov1alpha1.HTTPRulePaths) error {
+	var names []string
+	for _, hm := range path.HealthMonitorSpecs {
+		if hm.Name == "" {
+			return fmt.Errorf("healthmonitor name not provided for target %s", path.Target)
+		}
+		if utils.HasElem(names, hm.Name) {
+			return fmt.Errorf("healthmonitor %s is duplicated for target %s", hm.Name, path.Target)
+		}
+		names = append(names, hm.Name)
+
+		if hm.Type != utils.HTTP && hm.Type != utils.HTTPS && hm.Type != utils.TCP {
+			return fmt.Errorf("healthmonitor %s has unsupported type %s", hm.Name, hm.Type)
+		}
+
+		interval, timeout := lib.DefaultHMSendInterval, lib.DefaultHMReceiveTimeout
+		if hm.Interval != 0 {
+			interval = hm.Interval
+		}
+		if hm.Timeout != 0 {
+			timeout = hm.Timeout
+		}
+		if timeout >= interval {
+			return fmt.Errorf("healthmonitor %s timeout %d must be less than the interval %d", hm.Name, timeout, interval)
+		}
+	}
+	return nil
+}
+
+// validateHTTPRulePersistence checks the persistence settings of an httprule path, the persistence
+// is either referred from the controller or defined by its type
+func validateHTTPRulePersistence(path akov1alpha1.HTTPRulePaths) error {
+	persistence := path.Persistence
+	if persistence.Profile != "" {
+		if persistence != (akov1alpha1.HTTPRulePersistence{Profile: persistence.Profile}) {
+			return fmt.Errorf("persistence profile can not be combined with other persistence settings for target %s", path.Target)
+		}
+		return nil
+	}
+
+	switch persistence.Type {
+	case "":
+		if persistence != (akov1alpha1.HTTPRulePersistence{}) {
+			return fmt.Errorf("persistence type not provided for target %s", path.Target)
+		}
+	case lib.PersistenceTypeCookie:
+		if persistence.HeaderName != "" {
+			return fmt.Errorf("headerName is not applicable to cookie persistence for target %s", path.Target)
+		}
+	case lib.PersistenceTypeHeader:
+		if persistence.HeaderName == "" {
+			return fmt.Errorf("headerName not provided for header persistence for target %s", path.Target)
+		}
+		if persistence.CookieName != "" || persistence.Timeout != 0 {
+			return fmt.Errorf("only headerName is applicable to header persistence for target %s", path.Target)
+		}
+	case lib.PersistenceTypeClientIP:
+		if persistence.CookieName != "" || persistence.HeaderName != "" {
+			return fmt.Errorf("only timeout is applicable to clientIP persistence for target %s", path.Target)
+		}
+		if persistence.Timeout > lib.MaxClientIPPersistenceTimeout {
+			return fmt.Errorf("clientIP persistence timeout %d exceeds %d minutes for target %s",
+				persistence.Timeout, lib.MaxClientIPPersistenceTimeout, path.Target)
+		}
+	default:
+		return fmt.Errorf("unsupported persistence type %s for target %s", persistence.Type, path.Target)
+	}
+	return nil
+}
+
+// validateHTTPRuleBackendProtocol checks the backend protocol of an httprule path, the HTTP health
+// monitors speak HTTP/1.1 and can not probe the gRPC servers
+func validateHTTPRuleBackendProtocol(path akov1alpha1.HTTPRulePaths) error {
+	switch path.BackendProtocol {
+	case "", lib.BackendProtocolHTTP1, lib.BackendProtocolH2C:
+	case lib.BackendProtocolGRPC:
+		for _, hm := range path.HealthMonitorSpecs {
+			if hm.Type == utils.HTTP || hm.Type == utils.HTTPS {
+				return fmt.Errorf("healthmonitor %s of type %s can not be used with the grpc backend protocol for target %s",
+					hm.Name, hm.Type, path.Target)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported backend protocol %s for target %s", path.BackendProtocol, path.Target)
+	}
+	return nil
+}
+
+// validateHTTPRuleConnection checks the server connection settings of an httprule path
+func validateHTTPRuleConnection(path akov1alpha1.HTTPRulePaths) error {
+	connection := path.Connection
+	if connection.RequestTimeout < 0 || connection.IdleTimeout < 0 || connection.MaxReuse < 0 ||
+		connection.Retries < 0 || connection.RetryTimeout < 0 {
+		return fmt.Errorf("connection settings can not be negative for target %s", path.Target)
+	}
+	if connection.RequestTimeout > lib.MaxPoolServerTimeout || connection.IdleTimeout > lib.MaxPoolServerTimeout ||
+		connection.RetryTimeout > lib.MaxPoolServerTimeout {
+		return fmt.Errorf("connection timeouts exceed %d seconds for target %s", lib.MaxPoolServerTimeout, path.Target)
+	}
+	if connection.DisableReuse && connection.MaxReuse != 0 {
+		return fmt.Errorf("maxReuse can not be combined with disableReuse for target %s", path.Target)
+	}
+	if connection.RetryTimeout != 0 && connection.Retries == 0 {
+		return fmt.Errorf("retryTimeout requires retries for target %s", path.Target)
+	}
+	return nil
+}
+
 // validateAviInfraSetting would do validaion checks on the
 // ingested AviInfraSetting objects
 func validateAviInfraSetting(key string, infraSetting *akov1alpha1.AviInfraSetting) error {
@@ -344,6 +1520,15 @@ func validateAviInfraSetting(key string, infraSetting *akov1alpha1.AviInfraSetti
 		addSeGroupLabel(key, infraSetting.Spec.SeGroup.Name)
 	}
 
+	if ipFamily := infraSetting.Spec.Network.IPFamily; ipFamily != "" && !lib.IsValidIPFamily(ipFamily) {
+		err := fmt.Errorf("ipFamily %s not supported, supported values are V4, V6 and V4_V6", ipFamily)
+		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+			Status: lib.StatusRejected,
+			Error:  err.Error(),
+		})
+		return err
+	}
+
 	if err := checkRefsOnController(key, refData); err != nil {
 		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
@@ -387,6 +1572,9 @@ var refModelMap = map[string]string{
 	"HealthMonitor":      "healthmonitor",
 	"ServiceEngineGroup": "serviceenginegroup",
 	"Network":            "network",
+	"IpAddrGroup":        "ipaddrgroup",
+	"AuthProfile":        "authprofile",
+	"PersistenceProfile": "applicationpersistenceprofile",
 }
 
 func checkRefsOnController(key string, refMap map[string]string) error {
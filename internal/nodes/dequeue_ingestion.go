@@ -17,13 +17,16 @@ package nodes
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -142,7 +145,7 @@ func DequeueIngestion(key string, fullsync bool) {
 
 	// handle the services APIs
 	if lib.GetAdvancedL4() || lib.UseServicesAPI() &&
-		(objType == utils.L4LBService || objType == lib.Gateway || objType == lib.GatewayClass || objType == utils.Endpoints || objType == lib.AviInfraSetting) {
+		(objType == utils.L4LBService || objType == lib.Gateway || objType == lib.GatewayClass || objType == utils.Endpoints || objType == lib.AviInfraSetting || objType == lib.BackendGrant) {
 		if !valid && objType == utils.L4LBService {
 			schema, _ = ConfigDescriptor().GetByType(utils.Service)
 		}
@@ -227,6 +230,65 @@ func handlePod(key, namespace, podName string, fullsync bool) {
 	} else {
 		utils.AviLog.Infof("key: %s, NPL annotation not found for Pod", key)
 		objects.SharedNPLLister().Delete(podKey)
+		if lib.IsPodReadinessGateEnabled() && lib.HasPodReadinessGate(pod) {
+			handlePodReadinessGate(key, podKey, pod, fullsync)
+		}
+	}
+}
+
+// handlePodReadinessGate stores the Pod to Services mapping for a Pod with the pool readiness gate,
+// so that the pools backed by the Pod are rebuilt once the Pod containers are ready. The Ingresses
+// and Routes of the Services are rebuilt through the Pod schema, using the same mapping.
+func handlePodReadinessGate(key, podKey string, pod *corev1.Pod, fullsync bool) {
+	if !lib.IsPodWaitingOnReadinessGate(pod) {
+		return
+	}
+	services, lbSvcs := lib.GetAllServicesForPod(pod)
+	if len(services) != 0 {
+		objects.SharedPodToSvcLister().Save(podKey, services)
+	}
+	if len(lbSvcs) != 0 {
+		objects.SharedPodToLBSvcLister().Save(podKey, lbSvcs)
+	}
+	for _, lbSvc := range lbSvcs {
+		lbSvcKey := utils.L4LBService + "/" + lbSvc
+		utils.AviLog.Debugf("key: %s, msg: handling l4 svc %s for pod readiness gate", key, lbSvcKey)
+		handleL4Service(lbSvcKey, fullsync)
+	}
+}
+
+// updateUnchangedPoolsReadinessGates sets the readiness gate for the Pods of the pools of a model
+// which is not pushed again, since its pools already are on the controller with the Pod servers.
+func updateUnchangedPoolsReadinessGates(aviGraph *AviObjectGraph, key string) {
+	if !lib.IsPodReadinessGateEnabled() {
+		return
+	}
+	var poolNodes []*AviPoolNode
+	for _, vsNode := range aviGraph.GetAviVS() {
+		poolNodes = append(poolNodes, vsNode.PoolRefs...)
+		for _, sniNode := range vsNode.SniNodes {
+			poolNodes = append(poolNodes, sniNode.PoolRefs...)
+		}
+	}
+	for _, vsNode := range aviGraph.GetAviEvhVS() {
+		poolNodes = append(poolNodes, vsNode.PoolRefs...)
+		for _, evhNode := range vsNode.EvhNodes {
+			poolNodes = append(poolNodes, evhNode.PoolRefs...)
+		}
+	}
+	poolCache := avicache.SharedAviObjCache().PoolCache
+	for _, poolNode := range poolNodes {
+		poolKey := avicache.NamespaceName{Namespace: lib.GetTenant(), Name: poolNode.Name}
+		poolCacheIntf, found := poolCache.AviCacheGet(poolKey)
+		if !found {
+			continue
+		}
+		poolCacheObj, ok := poolCacheIntf.(*avicache.AviPoolCache)
+		if !ok || poolCacheObj.CloudConfigCksum != strconv.Itoa(int(poolNode.GetCheckSum())) {
+			// the pool is yet to be updated on the controller.
+			continue
+		}
+		status.UpdatePoolReadinessGates(poolNode.Name, poolCacheObj.Uuid, key)
 	}
 }
 
@@ -384,7 +446,7 @@ func handleIngress(key string, fullsync bool, ingressNames []string) {
 }
 
 func getIngressNSNameForIngestion(objType, namespace, nsname string) (string, string) {
-	if objType == lib.HostRule || objType == lib.HTTPRule || objType == utils.Secret {
+	if objType == lib.HostRule || objType == lib.HTTPRule || objType == lib.AuthRule || objType == utils.Secret {
 		arr := strings.Split(nsname, "/")
 		return arr[0], arr[1]
 	}
@@ -394,6 +456,11 @@ func getIngressNSNameForIngestion(objType, namespace, nsname string) (string, st
 		return arr[0], arr[1]
 	}
 
+	// ingresses referring to services in other namespaces are mapped as namespace/name
+	if arr := strings.Split(nsname, "/"); len(arr) == 2 {
+		return arr[0], arr[1]
+	}
+
 	return namespace, nsname
 }
 
@@ -412,6 +479,7 @@ func saveAviModel(model_name string, aviGraph *AviObjectGraph, key string) bool
 		utils.AviLog.Debugf("key: %s, msg: the model: %s has a present checksum: %v", key, model_name, presentChecksum)
 		if prevChecksum == presentChecksum {
 			utils.AviLog.Debugf("key: %s, msg: The model: %s has identical checksums, hence not processing. Checksum value: %v", key, model_name, presentChecksum)
+			updateUnchangedPoolsReadinessGates(aviGraph, key)
 			return false
 		}
 	}
@@ -74,6 +74,10 @@ func (h *HostNamePathStore) GetHostPathStore(host string) (bool, map[string][]st
 	return true, obj.(map[string][]string)
 }
 
+func (h *HostNamePathStore) GetAllHostNames() map[string]interface{} {
+	return h.hostNamePathStore.CopyAllObjects()
+}
+
 func (h *HostNamePathStore) GetHostPathStoreIngresses(host, path string) (bool, []string) {
 	ok, obj := h.hostNamePathStore.Get(host)
 	if !ok {
@@ -0,0 +1,269 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var hostnameClaimStoreInstance *HostnameClaimStore
+var hcOnce sync.Once
+
+func SharedHostnameClaimStore() *HostnameClaimStore {
+	hcOnce.Do(func() {
+		hostnameClaimStoreInstance = &HostnameClaimStore{
+			hostClaims: make(map[string]map[string]metav1.Time),
+			objHosts:   make(map[string][]string),
+		}
+	})
+	return hostnameClaimStoreInstance
+}
+
+// HostnameClaimStore keeps track of the Ingresses/Routes claiming a hostname, the oldest claimant
+// owns the hostname when the hostname conflict policy is first-wins or reject.
+// cache sample: foo.com -> {Ingress/ns1/ing1: creationTimestamp, Ingress/ns2/ing2: creationTimestamp}
+// objects are identified by their ingestion keys, so that the claimants can be re-queued as is.
+type HostnameClaimStore struct {
+	sync.RWMutex
+	hostClaims map[string]map[string]metav1.Time
+	objHosts   map[string][]string
+}
+
+// UpdateClaims replaces the hostnames claimed by an object, and returns the other claimants of
+// the hostnames whose owner changed as a result.
+func (h *HostnameClaimStore) UpdateClaims(objKey string, created metav1.Time, hosts []string) []string {
+	h.Lock()
+	defer h.Unlock()
+
+	affectedHosts := append(append([]string{}, hosts...), h.objHosts[objKey]...)
+	oldOwners := make(map[string]string)
+	for _, host := range affectedHosts {
+		oldOwners[host] = h.getOwner(host)
+	}
+
+	for _, host := range h.objHosts[objKey] {
+		delete(h.hostClaims[host], objKey)
+		if len(h.hostClaims[host]) == 0 {
+			delete(h.hostClaims, host)
+		}
+	}
+	delete(h.objHosts, objKey)
+	for _, host := range hosts {
+		if _, ok := h.hostClaims[host]; !ok {
+			h.hostClaims[host] = make(map[string]metav1.Time)
+		}
+		h.hostClaims[host][objKey] = created
+	}
+	if len(hosts) > 0 {
+		h.objHosts[objKey] = hosts
+	}
+
+	var claimants []string
+	for host, oldOwner := range oldOwners {
+		if h.getOwner(host) == oldOwner {
+			continue
+		}
+		for claimant := range h.hostClaims[host] {
+			if claimant != objKey && !utils.HasElem(claimants, claimant) {
+				claimants = append(claimants, claimant)
+			}
+		}
+	}
+	return claimants
+}
+
+// DeleteClaims removes all the hostnames claimed by an object, and returns the other claimants
+// of the hostnames whose owner changed as a result.
+func (h *HostnameClaimStore) DeleteClaims(objKey string) []string {
+	return h.UpdateClaims(objKey, metav1.Time{}, nil)
+}
+
+// GetOwner returns the key of the oldest object claiming the hostname.
+func (h *HostnameClaimStore) GetOwner(host string) (bool, string) {
+	h.RLock()
+	defer h.RUnlock()
+	owner := h.getOwner(host)
+	return owner != "", owner
+}
+
+func (h *HostnameClaimStore) getOwner(host string) string {
+	var owner string
+	var ownerCreated metav1.Time
+	for claimant, created := range h.hostClaims[host] {
+		if owner == "" || created.Before(&ownerCreated) ||
+			(created.Equal(&ownerCreated) && claimant < owner) {
+			owner, ownerCreated = claimant, created
+		}
+	}
+	return owner
+}
+
+// updateHostnameClaims records the hostnames claimed by an Ingress/Route, and re-queues the other
+// claimants which won or lost a hostname due to this update.
+func updateHostnameClaims(key, objType, namespace, name string, created metav1.Time, hosts []string) {
+	if lib.GetHostnameConflictPolicy() == lib.HostnameConflictAllowMerge {
+		return
+	}
+	objKey := objType + "/" + namespace + "/" + name
+	var claimants []string
+	if len(hosts) == 0 {
+		claimants = SharedHostnameClaimStore().DeleteClaims(objKey)
+	} else {
+		claimants = SharedHostnameClaimStore().UpdateClaims(objKey, created, hosts)
+	}
+	if len(claimants) == 0 {
+		return
+	}
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	for _, claimant := range claimants {
+		claimantNS := strings.Split(claimant, "/")[1]
+		bkt := utils.Bkt(claimantNS, sharedQueue.NumWorkers)
+		sharedQueue.Workqueue[bkt].AddRateLimited(claimant)
+		utils.AviLog.Infof("key: %s, msg: hostname ownership changed, re-evaluating %s", key, claimant)
+	}
+}
+
+// getHostnameConflict checks whether an object in the namespace is allowed to use the host, and if not,
+// returns the owner of the host. Domains granted to namespaces via the allowed-domains annotation
+// take precedence over the hostname conflict policy.
+func getHostnameConflict(namespace, host string) (bool, string) {
+	if grantedNamespaces := getNamespacesForDomain(host); len(grantedNamespaces) > 0 {
+		if utils.HasElem(grantedNamespaces, namespace) {
+			return false, ""
+		}
+		return true, "namespace " + strings.Join(grantedNamespaces, ",")
+	}
+
+	if lib.GetHostnameConflictPolicy() == lib.HostnameConflictAllowMerge {
+		return false, ""
+	}
+	found, owner := SharedHostnameClaimStore().GetOwner(host)
+	if !found {
+		return false, ""
+	}
+	// owner is of the form objType/namespace/name
+	ownerKey := strings.SplitN(owner, "/", 3)
+	if ownerKey[1] == namespace {
+		return false, ""
+	}
+	ownerType := "ingress"
+	if ownerKey[0] == utils.OshiftRoute {
+		ownerType = "route"
+	}
+	return true, ownerType + " " + ownerKey[1] + "/" + ownerKey[2]
+}
+
+// getNamespacesForDomain returns the namespaces which are granted a domain covering the host.
+func getNamespacesForDomain(host string) []string {
+	domainLister := objects.SharedNamespaceDomainLister()
+	if !domainLister.IsPopulated() {
+		populateNamespaceDomains()
+	}
+	return domainLister.GetNamespacesForHost(host)
+}
+
+// populateNamespaceDomains saves the domains granted to all the namespaces, the namespace
+// event handler keeps them up to date afterwards.
+func populateNamespaceDomains() {
+	if utils.GetInformers().NSInformer == nil {
+		return
+	}
+	nsObjs, err := utils.GetInformers().NSInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("Unable to list namespaces for allowed domains: %v", err)
+		return
+	}
+	domainLister := objects.SharedNamespaceDomainLister()
+	for _, nsObj := range nsObjs {
+		domainLister.Save(nsObj.Name, lib.GetNamespaceAllowedDomains(nsObj))
+	}
+	domainLister.SetPopulated()
+}
+
+// getHostnameConflicts returns the hosts which are owned by another namespace, along with their owners.
+func getHostnameConflicts(namespace string, hosts []string) map[string]string {
+	conflicts := make(map[string]string)
+	for _, host := range hosts {
+		if conflict, owner := getHostnameConflict(namespace, host); conflict {
+			conflicts[host] = owner
+		}
+	}
+	return conflicts
+}
+
+// getConflictingHosts returns the hosts of an Ingress/Route which are owned by another namespace.
+func getConflictingHosts(key, objType, namespace, name string, hosts []string) []string {
+	var conflictingHosts []string
+	for _, host := range hosts {
+		if utils.HasElem(conflictingHosts, host) {
+			continue
+		}
+		if conflict, owner := getHostnameConflict(namespace, host); conflict {
+			conflictingHosts = append(conflictingHosts, host)
+			reportHostnameConflict(key, objType, namespace, name, host, owner)
+		}
+	}
+	return conflictingHosts
+}
+
+// reportHostnameConflict publishes an Event on the Ingress/Route which lost the host, Routes are
+// additionally marked as not admitted.
+func reportHostnameConflict(key, objType, namespace, name, host, owner string) {
+	msg := fmt.Sprintf("host %s is owned by %s", host, owner)
+	utils.AviLog.Warnf("key: %s, msg: skipping %s, %s", key, host, msg)
+
+	if objType == utils.OshiftRoute {
+		status.UpdateRouteStatusWithErrReason(key, name, namespace, lib.HostnameConflictReason, msg)
+	}
+	recordObjectEvent(objType, namespace, name, corev1.EventTypeWarning, lib.HostnameConflictReason, msg)
+}
+
+// recordObjectEvent publishes an Event on an Ingress or a Route.
+func recordObjectEvent(objType, namespace, name, eventType, reason, msg string) {
+	ref := &corev1.ObjectReference{
+		Namespace: namespace,
+		Name:      name,
+	}
+	switch objType {
+	case utils.Ingress:
+		ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(name)
+		if err != nil {
+			return
+		}
+		ref.Kind, ref.APIVersion, ref.UID = "Ingress", "networking.k8s.io/v1beta1", ingObj.UID
+	case utils.OshiftRoute:
+		routeObj, err := utils.GetInformers().RouteInformer.Lister().Routes(namespace).Get(name)
+		if err != nil {
+			return
+		}
+		ref.Kind, ref.APIVersion, ref.UID = "Route", "route.openshift.io/v1", routeObj.UID
+	default:
+		return
+	}
+	if recorder := lib.AKOEventRecorder(); recorder != nil {
+		recorder.Event(ref, eventType, reason, msg)
+	}
+}
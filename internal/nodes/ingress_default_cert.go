@@ -0,0 +1,137 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// getIngressDefaultCert returns the namespace and name of the default certificate for an Ingress, the
+// AviInfraSetting referred to by the IngressClass of the Ingress takes precedence over the AKO config.
+func getIngressDefaultCert(key string, ingSpec networkingv1beta1.IngressSpec) (string, string) {
+	if infraSetting := getIngressClassInfraSetting(key, ingSpec); infraSetting != nil &&
+		infraSetting.Spec.L7Settings.DefaultCertificate != "" {
+		return lib.ParseSecretRef(infraSetting.Spec.L7Settings.DefaultCertificate)
+	}
+	return lib.GetDefaultIngressCert()
+}
+
+// getIngressClassInfraSetting returns the AviInfraSetting set as the parameters of the IngressClass
+// of an Ingress, nil if there is none or it is rejected.
+func getIngressClassInfraSetting(key string, ingSpec networkingv1beta1.IngressSpec) *akov1alpha1.AviInfraSetting {
+	if ingSpec.IngressClassName == nil || !utils.GetIngressClassEnabled() ||
+		lib.GetCRDInformers() == nil || lib.GetCRDInformers().AviInfraSettingInformer == nil {
+		return nil
+	}
+	ingClassObj, err := utils.GetInformers().IngressClassInformer.Lister().Get(*ingSpec.IngressClassName)
+	if err != nil || !isAviInfraSettingRef(ingClassObj.Spec.Parameters) {
+		return nil
+	}
+	infraSetting, err := lib.GetCRDInformers().AviInfraSettingInformer.Lister().Get(ingClassObj.Spec.Parameters.Name)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get AviInfraSetting %s of ingressclass %s: %v",
+			key, ingClassObj.Spec.Parameters.Name, ingClassObj.Name, err)
+		return nil
+	}
+	if infraSetting.Status.Status == lib.StatusRejected {
+		return nil
+	}
+	return infraSetting
+}
+
+func isAviInfraSettingRef(ref *corev1.TypedLocalObjectReference) bool {
+	return ref != nil && ref.APIGroup != nil && *ref.APIGroup == lib.AkoGroup && ref.Kind == lib.AviInfraSetting
+}
+
+// isSecretPresent checks whether the secret of an Ingress TLS entry exists.
+func isSecretPresent(namespace, name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(name)
+	return err == nil
+}
+
+// addDefaultCertMappings maps the default certificate to the Ingress, so that the Ingress is
+// processed again when the secret is created, updated or deleted.
+func addDefaultCertMappings(ns, ingName, secretNS, secretName string) {
+	objects.SharedSvcLister().IngressMappings(ns).AddIngressToSecretsMappings(secretNS, ingName, secretName)
+	objects.SharedSvcLister().IngressMappings(secretNS).AddSecretsToIngressMappings(ns, ingName, secretName)
+}
+
+// defaultCertReports holds the last default certificate message reported for each Ingress namespace/name.
+var defaultCertReports = struct {
+	sync.Mutex
+	msgs map[string]string
+}{msgs: make(map[string]string)}
+
+// reportDefaultCert publishes an Event on the Ingress whose hosts are served with the default certificate,
+// the v1beta1 Ingress status has no conditions to carry this. The Event is published only when the hosts
+// or the certificate change, as the Ingress is parsed again for every update of its services and secrets.
+func reportDefaultCert(key, namespace, name, secretNS, secretName string, hosts []string) {
+	var msg string
+	if len(hosts) > 0 {
+		sort.Strings(hosts)
+		msg = fmt.Sprintf("hosts %v are served with the default certificate %s/%s", hosts, secretNS, secretName)
+	}
+
+	defaultCertReports.Lock()
+	defer defaultCertReports.Unlock()
+	ingNSName := namespace + "/" + name
+	if defaultCertReports.msgs[ingNSName] == msg {
+		return
+	}
+	if msg == "" {
+		delete(defaultCertReports.msgs, ingNSName)
+		return
+	}
+	defaultCertReports.msgs[ingNSName] = msg
+	utils.AviLog.Infof("key: %s, msg: %s", key, msg)
+	recordObjectEvent(utils.Ingress, namespace, name, corev1.EventTypeNormal, lib.DefaultCertificateReason, msg)
+}
+
+// AviSettingToIng returns the Ingresses of the IngressClasses which refer to the AviInfraSetting,
+// their default certificate might have changed.
+func AviSettingToIng(infraSettingName string, namespace string, key string) ([]string, bool) {
+	var allIngresses []string
+	if !utils.GetIngressClassEnabled() {
+		return allIngresses, false
+	}
+	ingClassObjs, err := utils.GetInformers().IngressClassInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		return allIngresses, false
+	}
+	for _, ingClass := range ingClassObjs {
+		if !isAviInfraSettingRef(ingClass.Spec.Parameters) || ingClass.Spec.Parameters.Name != infraSettingName {
+			continue
+		}
+		ingresses, _ := IngClassToIng(ingClass.Name, metav1.NamespaceAll, key)
+		allIngresses = append(allIngresses, ingresses...)
+	}
+	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, allIngresses)
+	return allIngresses, len(allIngresses) > 0
+}
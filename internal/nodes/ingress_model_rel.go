@@ -61,6 +61,7 @@ var (
 	Pod = GraphSchema{
 		Type:               "Pod",
 		GetParentIngresses: PodToIng,
+		GetParentRoutes:    PodToRoute,
 	}
 	Node = GraphSchema{
 		Type:               "Node",
@@ -95,10 +96,21 @@ var (
 		Type:              "GatewayClass",
 		GetParentGateways: GWClassToGateway,
 	}
+	AuthRule = GraphSchema{
+		Type:               lib.AuthRule,
+		GetParentIngresses: AuthRuleToIng,
+		GetParentRoutes:    AuthRuleToIng,
+	}
+	BackendGrant = GraphSchema{
+		Type:               lib.BackendGrant,
+		GetParentIngresses: BackendGrantToIng,
+		GetParentGateways:  BackendGrantToGateway,
+	}
 	AviInfraSetting = GraphSchema{
-		Type:              "AviInfraSetting",
-		GetParentGateways: AviSettingToGateway,
-		GetParentServices: AviSettingToSvc,
+		Type:               "AviInfraSetting",
+		GetParentGateways:  AviSettingToGateway,
+		GetParentServices:  AviSettingToSvc,
+		GetParentIngresses: AviSettingToIng,
 	}
 	SupportedGraphTypes = GraphDescriptor{
 		Ingress,
@@ -111,9 +123,11 @@ var (
 		Node,
 		HostRule,
 		HTTPRule,
+		AuthRule,
 		Gateway,
 		GatewayClass,
 		AviInfraSetting,
+		BackendGrant,
 	}
 )
 
@@ -141,14 +155,16 @@ func RouteChanges(routeName string, namespace string, key string) ([]string, boo
 			// Remove all the Ingress to Services mapping.
 			// Remove the references of this ingress from the Services
 			objects.OshiftRouteSvcLister().IngressMappings(namespace).RemoveIngressMappings(routeName)
+			updateHostnameClaims(key, utils.OshiftRoute, namespace, routeName, metav1.Time{}, nil)
 		}
 	} else {
 		validateRouteSpecFromHostnameCache(key, namespace, routeName, routeObj.Spec)
-		services := parseServicesForRoute(routeObj.Spec, key)
-		for _, svc := range services {
-			utils.AviLog.Debugf("key: %s, msg: updating route relationship for service: %s", key, svc)
-			objects.OshiftRouteSvcLister().IngressMappings(namespace).UpdateIngressMappings(routeName, svc)
+		var hosts []string
+		if routeHost := lib.GetRouteHostName(routeObj.Spec); routeHost != "" {
+			hosts = append(hosts, routeHost)
 		}
+		updateHostnameClaims(key, utils.OshiftRoute, namespace, routeName, routeObj.CreationTimestamp, hosts)
+		updateRouteServiceMappings(key, routeObj)
 		if routeObj.Spec.TLS != nil {
 			secret := lib.RouteSecretsPrefix + routeName
 			if routeObj.Spec.TLS.Certificate == "" || routeObj.Spec.TLS.Key == "" {
@@ -162,6 +178,21 @@ func RouteChanges(routeName string, namespace string, key string) ([]string, boo
 	return routes, true
 }
 
+// updateRouteServiceMappings maps the services of the route, including the services added to its path by
+// an httprule, to the route and removes the mappings of the services no longer used by it.
+func updateRouteServiceMappings(key string, routeObj *routev1.Route) {
+	namespace, routeName := routeObj.Namespace, routeObj.Name
+	_, oldSvcs := objects.OshiftRouteSvcLister().IngressMappings(namespace).GetIngToSvc(routeName)
+	currSvcs := parseServicesForRoute(routeObj.Spec, key)
+	for _, svc := range lib.Difference(oldSvcs, currSvcs) {
+		objects.OshiftRouteSvcLister().IngressMappings(namespace).RemoveSvcFromIngressMappings(routeName, svc)
+	}
+	for _, svc := range currSvcs {
+		utils.AviLog.Debugf("key: %s, msg: updating route relationship for service: %s", key, svc)
+		objects.OshiftRouteSvcLister().IngressMappings(namespace).UpdateIngressMappings(routeName, svc)
+	}
+}
+
 func SvcToRoute(svcName string, namespace string, key string) ([]string, bool) {
 	_, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(svcName)
 	if err != nil && k8serrors.IsNotFound(err) {
@@ -294,13 +325,24 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 		if k8serrors.IsNotFound(err) {
 			// Remove all the Ingress to Services mapping.
 			// Remove the references of this ingress from the Services
+			_, oldSvcs := objects.SharedSvcLister().IngressMappings(namespace).GetIngToSvc(ingName)
 			svcToDel := objects.SharedSvcLister().IngressMappings(namespace).RemoveIngressMappings(ingName)
+			for _, svc := range oldSvcs {
+				if svcNS, svcName := getSvcNSName(namespace, svc); svcNS != namespace {
+					if !removeCrossNSIngressMappings(namespace, ingName, svcNS, svcName) {
+						svcToDel = utils.Remove(svcToDel, svc)
+					}
+				}
+			}
 			if lib.AutoAnnotateNPLSvc() {
 				for _, svc := range svcToDel {
-					status.DeleteSvcAnnotation(key, namespace, svc)
+					svcNS, svcName := getSvcNSName(namespace, svc)
+					status.DeleteSvcAnnotation(key, svcNS, svcName)
 				}
 			}
 			objects.SharedSvcLister().IngressMappings(metav1.NamespaceAll).RemoveIngressClassMappings(namespace + "/" + ingName)
+			updateHostnameClaims(key, utils.Ingress, namespace, ingName, metav1.Time{}, nil)
+			reportDefaultCert(key, namespace, ingName, "", "", nil)
 		}
 	} else {
 		// simple validator check for duplicate hostpaths, logs Warning if duplicates found
@@ -309,34 +351,21 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 			return ingresses, false
 		}
 
+		// only the ingresses handled by AKO claim their hostnames
+		var hosts []string
+		if validateIngressForClass(key, ingObj) && utils.CheckIfNamespaceAccepted(namespace, utils.GetGlobalNSFilter(), nil, true) {
+			hosts = parseHostsForIngress(ingObj.Spec, key)
+		}
+		updateHostnameClaims(key, utils.Ingress, namespace, ingName, ingObj.CreationTimestamp, hosts)
+		status.UpdateIngressHostnameConflicts(key, namespace, ingName, getHostnameConflicts(namespace, hosts))
+
 		if ingObj.Spec.IngressClassName != nil {
 			objects.SharedSvcLister().IngressMappings(metav1.NamespaceAll).UpdateIngressClassMappings(namespace+"/"+ingName, *ingObj.Spec.IngressClassName)
 		} else {
 			objects.SharedSvcLister().IngressMappings(metav1.NamespaceAll).RemoveIngressClassMappings(namespace + "/" + ingName)
 		}
 
-		_, oldSvcs := objects.SharedSvcLister().IngressMappings(namespace).GetIngToSvc(ingName)
-		currSvcs := parseServicesForIngress(ingObj.Spec, key)
-
-		svcToDel := lib.Difference(oldSvcs, currSvcs)
-		for _, svc := range svcToDel {
-			_, ingrforSvc := objects.SharedSvcLister().IngressMappings(namespace).GetSvcToIng(svc)
-			ingrforSvc = utils.Remove(ingrforSvc, ingName)
-			if lib.AutoAnnotateNPLSvc() && len(ingrforSvc) == 0 {
-				status.DeleteSvcAnnotation(key, namespace, svc)
-			}
-			objects.SharedSvcLister().IngressMappings(namespace).RemoveSvcFromIngressMappings(ingName, svc)
-		}
-
-		svcToAdd := lib.Difference(currSvcs, oldSvcs)
-		for _, svc := range svcToAdd {
-			utils.AviLog.Debugf("key: %s, msg: updating ingress relationship for service:  %s", key, svc)
-			objects.SharedSvcLister().IngressMappings(namespace).UpdateIngressMappings(ingName, svc)
-			// Check and update NPl annotation for svc
-			if lib.AutoAnnotateNPLSvc() {
-				status.CheckUpdateSvcAnnotation(key, namespace, svc)
-			}
-		}
+		updateIngressServiceMappings(key, ingObj)
 		secrets := parseSecretsForIngress(ingObj.Spec, key)
 		if len(secrets) > 0 {
 			for _, secret := range secrets {
@@ -348,6 +377,102 @@ func IngressChanges(ingName string, namespace string, key string) ([]string, boo
 	return ingresses, true
 }
 
+// updateIngressServiceMappings updates the mappings of an ingress to the services it refers to,
+// along with the services added to its paths by HTTPRules.
+func updateIngressServiceMappings(key string, ingObj *networkingv1beta1.Ingress) {
+	namespace, ingName := ingObj.Namespace, ingObj.Name
+	_, oldSvcs := objects.SharedSvcLister().IngressMappings(namespace).GetIngToSvc(ingName)
+	currSvcs := parseServicesForIngress(ingObj, key)
+
+	svcToDel := lib.Difference(oldSvcs, currSvcs)
+	for _, svc := range svcToDel {
+		svcNS, svcName := getSvcNSName(namespace, svc)
+		var noIngForSvc bool
+		if svcNS != namespace {
+			noIngForSvc = removeCrossNSIngressMappings(namespace, ingName, svcNS, svcName)
+		} else {
+			_, ingrforSvc := objects.SharedSvcLister().IngressMappings(namespace).GetSvcToIng(svc)
+			noIngForSvc = len(utils.Remove(ingrforSvc, ingName)) == 0
+		}
+		if lib.AutoAnnotateNPLSvc() && noIngForSvc {
+			status.DeleteSvcAnnotation(key, svcNS, svcName)
+		}
+		objects.SharedSvcLister().IngressMappings(namespace).RemoveSvcFromIngressMappings(ingName, svc)
+	}
+
+	svcToAdd := lib.Difference(currSvcs, oldSvcs)
+	for _, svc := range svcToAdd {
+		utils.AviLog.Debugf("key: %s, msg: updating ingress relationship for service:  %s", key, svc)
+		objects.SharedSvcLister().IngressMappings(namespace).UpdateIngressMappings(ingName, svc)
+		// Services in other namespaces map back to the ingress as namespace/name, so that
+		// updates to the service or its endpoints retrigger the ingress.
+		svcNS, svcName := getSvcNSName(namespace, svc)
+		if svcNS != namespace {
+			objects.SharedSvcLister().IngressMappings(svcNS).UpdateIngressMappings(namespace+"/"+ingName, svcName)
+		}
+		// Check and update NPl annotation for svc
+		if lib.AutoAnnotateNPLSvc() {
+			status.CheckUpdateSvcAnnotation(key, svcNS, svcName)
+		}
+	}
+}
+
+// getSvcNSName returns the namespace and name of a service mapped to an ingress,
+// services in other namespaces are mapped as namespace/name.
+func getSvcNSName(namespace, svc string) (string, string) {
+	if arr := strings.Split(svc, "/"); len(arr) == 2 {
+		return arr[0], arr[1]
+	}
+	return namespace, svc
+}
+
+// removeCrossNSIngressMappings removes the mapping of an ingress to a service in another namespace,
+// and returns true if the service is no longer referred to by any ingress.
+func removeCrossNSIngressMappings(ingNamespace, ingName, svcNamespace, svcName string) bool {
+	svcMappings := objects.SharedSvcLister().IngressMappings(svcNamespace)
+	svcMappings.RemoveSvcFromIngressMappings(ingNamespace+"/"+ingName, svcName)
+	_, ingresses := svcMappings.GetSvcToIng(svcName)
+	return len(ingresses) == 0
+}
+
+// BackendGrantToIng returns the ingresses in other namespaces which refer to the services in the
+// namespace of the BackendGrant, since a grant change can allow or revoke their backends.
+func BackendGrantToIng(grantName string, namespace string, key string) ([]string, bool) {
+	var ingresses []string
+	for _, ingName := range objects.SharedSvcLister().IngressMappings(namespace).GetAllIngresses() {
+		if strings.Contains(ingName, "/") {
+			ingresses = append(ingresses, ingName)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingresses)
+	return ingresses, len(ingresses) > 0
+}
+
+// BackendGrantToGateway re-evaluates the services in the namespace of the BackendGrant which are
+// labelled for gateways in other namespaces, and returns the gateways they are added to or removed from.
+func BackendGrantToGateway(grantName string, namespace string, key string) ([]string, bool) {
+	var allGateways []string
+	svcs, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list services in namespace %s: %v", key, namespace, err)
+		return allGateways, false
+	}
+	for _, svc := range svcs {
+		gwNamespace, ok := svc.GetLabels()[lib.GatewayNamespaceLabelKey]
+		if !ok || gwNamespace == namespace {
+			continue
+		}
+		gateways, _ := SvcToGateway(svc.Name, namespace, key)
+		for _, gateway := range gateways {
+			if !utils.HasElem(allGateways, gateway) {
+				allGateways = append(allGateways, gateway)
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, allGateways)
+	return allGateways, len(allGateways) > 0
+}
+
 func IngClassToIng(ingClassName string, namespace string, key string) ([]string, bool) {
 	found, ingresses := objects.SharedSvcLister().IngressMappings(metav1.NamespaceAll).GetClassToIng(ingClassName)
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingresses)
@@ -426,6 +551,24 @@ func PodToIng(podName string, namespace string, key string) ([]string, bool) {
 	return allIngresses, true
 }
 
+func PodToRoute(podName string, namespace string, key string) ([]string, bool) {
+	var allRoutes []string
+	podKey := namespace + "/" + podName
+	ok, servicesIntf := objects.SharedPodToSvcLister().Get(podKey)
+	if !ok {
+		return allRoutes, false
+	}
+	services := servicesIntf.([]string)
+	utils.AviLog.Debugf("key: %s, msg: Services retrieved:  %s", key, services)
+	for _, svc := range services {
+		_, svcName := utils.ExtractNamespaceObjectName(svc)
+		routes, _ := SvcToRoute(svcName, namespace, key)
+		allRoutes = append(allRoutes, routes...)
+	}
+	utils.AviLog.Debugf("key: %s, msg: Routes retrieved:  %s", key, allRoutes)
+	return allRoutes, true
+}
+
 func EPToIng(epName string, namespace string, key string) ([]string, bool) {
 	ingresses, found := SvcToIng(epName, namespace, key)
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingresses)
@@ -434,8 +577,15 @@ func EPToIng(epName string, namespace string, key string) ([]string, bool) {
 
 func SecretToIng(secretName string, namespace string, key string) ([]string, bool) {
 	ok, ingNames := objects.SharedSvcLister().IngressMappings(namespace).GetSecretToIng(secretName)
+	crdIngNames := append(getIngressesForHostRuleCABundle(secretName, namespace, key), getIngressesForHTTPRuleClientCert(secretName, namespace, key)...)
+	crdIngNames = append(crdIngNames, getIngressesForAuthRuleJWKS(secretName, namespace, key)...)
+	for _, ing := range crdIngNames {
+		if !utils.HasElem(ingNames, ing) {
+			ingNames = append(ingNames, ing)
+		}
+	}
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingNames)
-	if ok {
+	if ok || len(crdIngNames) > 0 {
 		return ingNames, true
 	}
 	return nil, false
@@ -443,13 +593,102 @@ func SecretToIng(secretName string, namespace string, key string) ([]string, boo
 
 func SecretToRoute(secretName string, namespace string, key string) ([]string, bool) {
 	ok, ingNames := objects.OshiftRouteSvcLister().IngressMappings(namespace).GetSecretToIng(secretName)
+	crdIngNames := append(getIngressesForHostRuleCABundle(secretName, namespace, key), getIngressesForHTTPRuleClientCert(secretName, namespace, key)...)
+	crdIngNames = append(crdIngNames, getIngressesForAuthRuleJWKS(secretName, namespace, key)...)
+	for _, ing := range crdIngNames {
+		if !utils.HasElem(ingNames, ing) {
+			ingNames = append(ingNames, ing)
+		}
+	}
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, ingNames)
-	if ok {
+	if ok || len(crdIngNames) > 0 {
 		return ingNames, true
 	}
 	return nil, false
 }
 
+// getIngressesForHostRuleCABundle returns the ingresses/routes for the hosts of the HostRules which refer to the secret
+// as the client CA bundle, the HostRules are processed again as the CA bundle might have been added or removed.
+func getIngressesForHostRuleCABundle(secretName, namespace, key string) []string {
+	var ingresses []string
+	if lib.GetCRDInformers() == nil || lib.GetCRDInformers().HostRuleInformer == nil {
+		return ingresses
+	}
+	hostrules, err := lib.GetCRDInformers().HostRuleInformer.Lister().HostRules(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list hostrules in namespace %s: %v", key, namespace, err)
+		return ingresses
+	}
+	for _, hostrule := range hostrules {
+		caBundle := hostrule.Spec.VirtualHost.TLS.ClientCertificate.CABundle
+		if caBundle.Kind != lib.CABundleKindSecret || caBundle.Name != secretName {
+			continue
+		}
+		hrIngresses, _ := HostRuleToIng(hostrule.Name, namespace, key)
+		for _, ing := range hrIngresses {
+			if !utils.HasElem(ingresses, ing) {
+				ingresses = append(ingresses, ing)
+			}
+		}
+	}
+	return ingresses
+}
+
+// getIngressesForHTTPRuleClientCert returns the ingresses/routes for the hosts of the HTTPRules which refer to the secret
+// as the client certificate of a re-encrypt path, the HTTPRules are processed again to pick up the rotated key/cert.
+func getIngressesForHTTPRuleClientCert(secretName, namespace, key string) []string {
+	var ingresses []string
+	if lib.GetCRDInformers() == nil || lib.GetCRDInformers().HTTPRuleInformer == nil {
+		return ingresses
+	}
+	httprules, err := lib.GetCRDInformers().HTTPRuleInformer.Lister().HTTPRules(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list httprules in namespace %s: %v", key, namespace, err)
+		return ingresses
+	}
+	for _, httprule := range httprules {
+		for _, path := range httprule.Spec.Paths {
+			if path.TLS.ClientCertificate != secretName {
+				continue
+			}
+			rrIngresses, _ := HTTPRuleToIng(httprule.Name, namespace, key)
+			for _, ing := range rrIngresses {
+				if !utils.HasElem(ingresses, ing) {
+					ingresses = append(ingresses, ing)
+				}
+			}
+			break
+		}
+	}
+	return ingresses
+}
+
+// getIngressesForAuthRuleJWKS returns the ingresses/routes for the hosts of the AuthRules which refer to the secret
+// for the JSON Web Key Set, the AuthRules are processed again to pick up the rotated keys.
+func getIngressesForAuthRuleJWKS(secretName, namespace, key string) []string {
+	var ingresses []string
+	if lib.GetCRDInformers() == nil || lib.GetCRDInformers().AuthRuleInformer == nil {
+		return ingresses
+	}
+	authrules, err := lib.GetCRDInformers().AuthRuleInformer.Lister().AuthRules(namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list authrules in namespace %s: %v", key, namespace, err)
+		return ingresses
+	}
+	for _, authrule := range authrules {
+		if authrule.Spec.JWT.JWKS != secretName {
+			continue
+		}
+		arIngresses, _ := AuthRuleToIng(authrule.Name, namespace, key)
+		for _, ing := range arIngresses {
+			if !utils.HasElem(ingresses, ing) {
+				ingresses = append(ingresses, ing)
+			}
+		}
+	}
+	return ingresses
+}
+
 func SecretToGateway(secretName string, namespace string, key string) ([]string, bool) {
 	return nil, false
 }
@@ -462,6 +701,8 @@ func parseServicesForRoute(routeSpec routev1.RouteSpec, key string) []string {
 	for _, ab := range routeSpec.AlternateBackends {
 		services = append(services, ab.Name)
 	}
+	// services referred to only by an httprule map to the route for their updates to be processed
+	services = append(services, getHTTPRuleBackendServices(lib.GetRouteHostName(routeSpec), routeSpec.Path)...)
 
 	utils.AviLog.Debugf("key: %s, msg: total services retrieved from route: %v", key, services)
 	return services
@@ -470,6 +711,7 @@ func parseServicesForRoute(routeSpec routev1.RouteSpec, key string) []string {
 func HostRuleToIng(hrname string, namespace string, key string) ([]string, bool) {
 	var err error
 	var oldFqdn, fqdn string
+	var oldFqdnType, fqdnType string
 	var oldFound bool
 
 	allIngresses := make([]string, 0)
@@ -477,6 +719,7 @@ func HostRuleToIng(hrname string, namespace string, key string) ([]string, bool)
 	if k8serrors.IsNotFound(err) {
 		utils.AviLog.Debugf("key: %s, msg: HostRule Deleted\n", key)
 		_, fqdn = objects.SharedCRDLister().GetHostruleToFQDNMapping(namespace + "/" + hrname)
+		_, fqdnType = objects.SharedCRDLister().GetFQDNToFQDNTypeMapping(fqdn)
 		objects.SharedCRDLister().DeleteHostruleFQDNMapping(namespace + "/" + hrname)
 	} else if err != nil {
 		utils.AviLog.Errorf("key: %s, msg: Error getting hostrule: %v\n", key, err)
@@ -487,39 +730,76 @@ func HostRuleToIng(hrname string, namespace string, key string) ([]string, bool)
 		}
 
 		fqdn = hostrule.Spec.VirtualHost.Fqdn
+		fqdnType = hostrule.Spec.VirtualHost.FqdnType
 		oldFound, oldFqdn = objects.SharedCRDLister().GetHostruleToFQDNMapping(namespace + "/" + hrname)
 		if oldFound {
+			_, oldFqdnType = objects.SharedCRDLister().GetFQDNToFQDNTypeMapping(oldFqdn)
 			objects.SharedCRDLister().DeleteHostruleFQDNMapping(namespace + "/" + hrname)
 		}
 		objects.SharedCRDLister().UpdateFQDNHostruleMapping(fqdn, namespace+"/"+hrname)
+		if fqdnType != "" && fqdnType != lib.FqdnTypeExact {
+			objects.SharedCRDLister().UpdateFQDNToFQDNTypeMapping(fqdn, fqdnType)
+		}
 	}
 
 	// find ingresses with host==fqdn, across all namespaces
-	ok, obj := SharedHostNameLister().GetHostPathStore(fqdn)
-	if !ok {
-		utils.AviLog.Debugf("key: %s, msg: Couldn't find hostpath info for host: %s in cache", key, fqdn)
-	} else {
-		for _, ingresses := range obj {
-			for _, ing := range ingresses {
-				if !utils.HasElem(allIngresses, ing) {
-					allIngresses = append(allIngresses, ing)
-				}
-			}
+	for _, ing := range getIngressesForHostruleFqdn(fqdn, fqdnType, key) {
+		if !utils.HasElem(allIngresses, ing) {
+			allIngresses = append(allIngresses, ing)
 		}
 	}
 
 	// in case the hostname is updated, we need to find ingresses for the old ones as well to recompute
 	if oldFound {
-		ok, oldobj := SharedHostNameLister().GetHostPathStore(oldFqdn)
-		if !ok {
-			utils.AviLog.Debugf("key: %s, msg: Couldn't find hostpath info for host: %s in cache", key, oldFqdn)
-		} else {
-			for _, ingresses := range oldobj {
-				for _, ing := range ingresses {
-					if !utils.HasElem(allIngresses, ing) {
-						allIngresses = append(allIngresses, ing)
-					}
-				}
+		for _, ing := range getIngressesForHostruleFqdn(oldFqdn, oldFqdnType, key) {
+			if !utils.HasElem(allIngresses, ing) {
+				allIngresses = append(allIngresses, ing)
+			}
+		}
+	}
+
+	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, allIngresses)
+	return allIngresses, true
+}
+
+// AuthRuleToIng validates the AuthRule and returns the ingresses/routes for its host,
+// along with the ones of the previous host in case the fqdn of the AuthRule is updated.
+func AuthRuleToIng(arname string, namespace string, key string) ([]string, bool) {
+	var oldFqdn, fqdn string
+	var oldFound bool
+
+	allIngresses := make([]string, 0)
+	authrule, err := lib.GetCRDInformers().AuthRuleInformer.Lister().AuthRules(namespace).Get(arname)
+	if k8serrors.IsNotFound(err) {
+		utils.AviLog.Debugf("key: %s, msg: AuthRule Deleted\n", key)
+		_, fqdn = objects.SharedCRDLister().GetAuthRuleToFQDNMapping(namespace + "/" + arname)
+		objects.SharedCRDLister().DeleteAuthRuleFQDNMapping(namespace + "/" + arname)
+	} else if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: Error getting authrule: %v\n", key, err)
+		return nil, false
+	} else {
+		if err = validateAuthRuleObj(key, authrule); err != nil {
+			return allIngresses, false
+		}
+
+		fqdn = authrule.Spec.Fqdn
+		oldFound, oldFqdn = objects.SharedCRDLister().GetAuthRuleToFQDNMapping(namespace + "/" + arname)
+		if oldFound {
+			objects.SharedCRDLister().DeleteAuthRuleFQDNMapping(namespace + "/" + arname)
+		}
+		objects.SharedCRDLister().UpdateFQDNAuthRuleMapping(fqdn, namespace+"/"+arname)
+	}
+
+	for _, ing := range getIngressesForHostruleFqdn(fqdn, lib.FqdnTypeExact, key) {
+		if !utils.HasElem(allIngresses, ing) {
+			allIngresses = append(allIngresses, ing)
+		}
+	}
+
+	if oldFound && oldFqdn != fqdn {
+		for _, ing := range getIngressesForHostruleFqdn(oldFqdn, lib.FqdnTypeExact, key) {
+			if !utils.HasElem(allIngresses, ing) {
+				allIngresses = append(allIngresses, ing)
 			}
 		}
 	}
@@ -528,6 +808,37 @@ func HostRuleToIng(hrname string, namespace string, key string) ([]string, bool)
 	return allIngresses, true
 }
 
+// getIngressesForHostruleFqdn returns the ingresses/routes for all the hosts that are matched by a HostRule fqdn.
+func getIngressesForHostruleFqdn(fqdn, fqdnType, key string) []string {
+	var hosts []string
+	if fqdnType == "" || fqdnType == lib.FqdnTypeExact {
+		hosts = append(hosts, fqdn)
+	} else {
+		for host := range SharedHostNameLister().GetAllHostNames() {
+			if host == fqdn || isHostruleFqdnMatch(fqdn, fqdnType, host) {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+
+	var ingresses []string
+	for _, host := range hosts {
+		ok, obj := SharedHostNameLister().GetHostPathStore(host)
+		if !ok {
+			utils.AviLog.Debugf("key: %s, msg: Couldn't find hostpath info for host: %s in cache", key, host)
+			continue
+		}
+		for _, pathIngresses := range obj {
+			for _, ing := range pathIngresses {
+				if !utils.HasElem(ingresses, ing) {
+					ingresses = append(ingresses, ing)
+				}
+			}
+		}
+	}
+	return ingresses
+}
+
 func HTTPRuleToIng(rrname string, namespace string, key string) ([]string, bool) {
 	var err error
 	allIngresses := make([]string, 0)
@@ -616,6 +927,23 @@ func HTTPRuleToIng(rrname string, namespace string, key string) ([]string, bool)
 		}
 	}
 
+	// the services added to the paths of the ingresses and routes by the httprule are mapped to them
+	for _, ing := range allIngresses {
+		ingNSName := strings.Split(ing, "/")
+		if len(ingNSName) != 2 {
+			continue
+		}
+		if utils.GetInformers().IngressInformer != nil {
+			if ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(ingNSName[0]).Get(ingNSName[1]); err == nil {
+				updateIngressServiceMappings(key, ingObj)
+			}
+		} else if utils.GetInformers().RouteInformer != nil {
+			if routeObj, err := utils.GetInformers().RouteInformer.Lister().Routes(ingNSName[0]).Get(ingNSName[1]); err == nil {
+				updateRouteServiceMappings(key, routeObj)
+			}
+		}
+	}
+
 	utils.AviLog.Debugf("key: %s, msg: Ingresses retrieved %s", key, allIngresses)
 	return allIngresses, true
 }
@@ -686,13 +1014,21 @@ func AviSettingToSvc(infraSettingName string, namespace string, key string) ([]s
 	return allSvcs, true
 }
 
-func parseServicesForIngress(ingSpec networkingv1beta1.IngressSpec, key string) []string {
-	// Figure out the service names that are part of this ingress
+func parseServicesForIngress(ingObj *networkingv1beta1.Ingress, key string) []string {
+	// Figure out the service names that are part of this ingress,
+	// services in other namespaces are returned as namespace/name
 	var services []string
-	for _, rule := range ingSpec.Rules {
+	backendNamespaces := lib.GetIngressBackendNamespaces(ingObj.GetAnnotations())
+	for _, rule := range ingObj.Spec.Rules {
 		if rule.IngressRuleValue.HTTP != nil {
 			for _, path := range rule.IngressRuleValue.HTTP.Paths {
-				services = append(services, path.Backend.ServiceName)
+				svc := path.Backend.ServiceName
+				if svcNS, ok := backendNamespaces[svc]; ok && svcNS != ingObj.Namespace {
+					svc = svcNS + "/" + svc
+				}
+				services = append(services, svc)
+				// services referred to only by an httprule map to the ingress for their updates to be processed
+				services = append(services, getHTTPRuleBackendServices(rule.Host, path.Path)...)
 			}
 		}
 	}
@@ -700,6 +1036,25 @@ func parseServicesForIngress(ingSpec networkingv1beta1.IngressSpec, key string)
 	return services
 }
 
+func parseHostsForIngress(ingSpec networkingv1beta1.IngressSpec, key string) []string {
+	// Figure out the hostnames claimed by this ingress
+	var hosts []string
+	for _, rule := range ingSpec.Rules {
+		if rule.Host != "" && !utils.HasElem(hosts, rule.Host) {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	for _, tlsSettings := range ingSpec.TLS {
+		for _, host := range tlsSettings.Hosts {
+			if host != "" && !utils.HasElem(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: total hosts retrieved from ingress: %s", key, hosts)
+	return hosts
+}
+
 func parseSecretsForIngress(ingSpec networkingv1beta1.IngressSpec, key string) []string {
 	// Figure out the service names that are part of this ingress
 	var secrets []string
@@ -718,6 +1073,14 @@ func ParseL4ServiceForGateway(svc *corev1.Service, key string) (string, []string
 	if name, ok := labels[lib.GatewayNameLabelKey]; ok {
 		if namespace, ok := labels[lib.GatewayNamespaceLabelKey]; ok {
 			gateway = namespace + "/" + name
+			// services in other namespaces are attached to the gateway only when granted by a BackendGrant,
+			// which is available with the services API.
+			if lib.UseServicesAPI() && namespace != svc.Namespace &&
+				!isBackendGranted(lib.Gateway, namespace, svc.Namespace, svc.Name) {
+				utils.AviLog.Warnf("key: %s, msg: service %s/%s is not granted to gateways in namespace %s by any %s",
+					key, svc.Namespace, svc.Name, namespace, lib.BackendGrant)
+				return "", portProtocols
+			}
 		}
 	}
 	if gateway != "" {
@@ -17,6 +17,7 @@ package nodes
 import (
 	"strings"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 
@@ -24,6 +25,7 @@ import (
 
 	routev1 "github.com/openshift/api/route/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -40,6 +42,11 @@ func NewNodesValidator() *Validator {
 }
 
 func (v *Validator) IsValidHostName(hostname string) bool {
+	// Wildcard hosts are allowed only in the form of *.example.com
+	if !lib.IsValidWildcardHost(hostname) {
+		utils.AviLog.Warnf("Invalid wildcard hostname :%s, only a leading '*.' label is supported", hostname)
+		return false
+	}
 	// Check if a hostname is valid or not by verifying if it has a prefix that
 	// matches any of the sub-domains.
 	if v.subDomains == nil {
@@ -77,9 +84,10 @@ func validateSpecFromHostnameCache(key, ns, ingName string, ingSpec networkingv1
 
 func validateRouteSpecFromHostnameCache(key, ns, routeName string, routeSpec routev1.RouteSpec) {
 	nsRoute := ns + "/" + routeName
-	found, val := SharedHostNameLister().GetHostPathStoreIngresses(routeSpec.Host, routeSpec.Path)
+	hostName := lib.GetRouteHostName(routeSpec)
+	found, val := SharedHostNameLister().GetHostPathStoreIngresses(hostName, routeSpec.Path)
 	if found && len(val) > 0 && utils.HasElem(val, nsRoute) && len(val) > 1 {
-		utils.AviLog.Warnf("key: %s, msg: Duplicate entries found for hostpath %s%s: %s in routes: %+v", key, nsRoute, routeSpec.Host, routeSpec.Path, utils.Stringify(val))
+		utils.AviLog.Warnf("key: %s, msg: Duplicate entries found for hostpath %s%s: %s in routes: %+v", key, nsRoute, hostName, routeSpec.Path, utils.Stringify(val))
 	}
 }
 
@@ -89,7 +97,7 @@ func sslKeyCertHostRulePresent(key, host string) (bool, string) {
 	}
 
 	// from host check if hostrule is present
-	found, hrNSNameStr := objects.SharedCRDLister().GetFQDNToHostruleMapping(host)
+	found, hrNSNameStr := GetHostruleForFqdn(host)
 	if !found {
 		utils.AviLog.Debugf("key: %s, msg: Couldn't find fqdn %s to hostrule mapping in cache", key, host)
 		return false, ""
@@ -115,6 +123,127 @@ func sslKeyCertHostRulePresent(key, host string) (bool, string) {
 	return false, ""
 }
 
+// passthroughHostRulePresent checks if the host has a valid hostrule with tls termination passthrough,
+// this should be only in case of hostname sharding
+func passthroughHostRulePresent(key, host string) bool {
+	if lib.GetShardScheme() == "namespace" {
+		return false
+	}
+
+	found, hrNSNameStr := GetHostruleForFqdn(host)
+	if !found {
+		return false
+	}
+
+	hrNSName := strings.Split(hrNSNameStr, "/")
+	hostRuleObj, err := lib.GetCRDInformers().HostRuleInformer.Lister().HostRules(hrNSName[0]).Get(hrNSName[1])
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: Couldn't find hostrule %s: %v", key, hrNSNameStr, err)
+		return false
+	} else if hostRuleObj.Status.Status == lib.StatusRejected {
+		return false
+	}
+
+	return hostRuleObj.Spec.VirtualHost.TLS.Termination == lib.TLSTerminationPassthrough
+}
+
+// getHTTPRulePathForIngressPath returns the HTTPRule, and its path with the longest target matching the ingress path.
+// An HTTPRule rejected for an insecure host is returned for the host, its backends are skipped on the insecure host.
+func getHTTPRulePathForIngressPath(host, ingPath string) (string, *akov1alpha1.HTTPRulePaths) {
+	found, pathRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(host)
+	if !found {
+		return "", nil
+	}
+
+	var target, rule string
+	for path, pathRule := range pathRules {
+		if strings.HasPrefix(ingPath, path) && len(path) > len(target) {
+			target, rule = path, pathRule
+		}
+	}
+	if rule == "" {
+		return "", nil
+	}
+
+	ruleNSName := strings.Split(rule, "/")
+	httpRuleObj, err := lib.GetCRDInformers().HTTPRuleInformer.Lister().HTTPRules(ruleNSName[0]).Get(ruleNSName[1])
+	if err != nil || (httpRuleObj.Status.Status == lib.StatusRejected && !isHTTPRuleRejectedForInsecureHost(httpRuleObj, host)) {
+		return "", nil
+	}
+	for i := range httpRuleObj.Spec.Paths {
+		if httpRuleObj.Spec.Paths[i].Target == target {
+			return rule, &httpRuleObj.Spec.Paths[i]
+		}
+	}
+	return "", nil
+}
+
+// getHTTPRuleBackendServices returns the services added as weighted and canary backends to an ingress path by the HTTPRule.
+func getHTTPRuleBackendServices(host, ingPath string) []string {
+	var services []string
+	_, httpRulePath := getHTTPRulePathForIngressPath(host, ingPath)
+	if httpRulePath == nil {
+		return services
+	}
+	for _, backend := range httpRulePath.Backends {
+		services = append(services, backend.ServiceName)
+	}
+	if httpRulePath.Canary.ServiceName != "" {
+		services = append(services, httpRulePath.Canary.ServiceName)
+	}
+	return services
+}
+
+// getHTTPRuleBackends returns the weighted and canary backends added to an ingress path by the HTTPRule
+// with the longest target matching the path, and sets the weight of the ingress backend of the path.
+func getHTTPRuleBackends(key, host string, hostPathMapSvc *IngressHostPathSvc) []IngressHostPathSvc {
+	rule, path := getHTTPRulePathForIngressPath(host, hostPathMapSvc.Path)
+	if path == nil {
+		return nil
+	}
+
+	var httpRuleBackends []IngressHostPathSvc
+	newBackend := func(serviceName string, port int32) IngressHostPathSvc {
+		backend := IngressHostPathSvc{
+			Path:            hostPathMapSvc.Path,
+			PathType:        hostPathMapSvc.PathType,
+			ServiceName:     serviceName,
+			Port:            port,
+			weight:          100,
+			httpRuleBackend: true,
+			httpRule:        rule,
+		}
+		if port == 0 {
+			backend.Port = hostPathMapSvc.Port
+			backend.PortName = hostPathMapSvc.PortName
+			backend.TargetPort = hostPathMapSvc.TargetPort
+		}
+		return backend
+	}
+	if path.Weight != nil {
+		hostPathMapSvc.weight = *path.Weight
+	}
+	for _, backend := range path.Backends {
+		if backend.ServiceName == hostPathMapSvc.ServiceName {
+			continue
+		}
+		httpRuleBackend := newBackend(backend.ServiceName, backend.Port)
+		if backend.Weight != nil {
+			httpRuleBackend.weight = *backend.Weight
+		}
+		httpRuleBackends = append(httpRuleBackends, httpRuleBackend)
+	}
+	if path.Canary.ServiceName != "" {
+		canary := path.Canary
+		httpRuleBackend := newBackend(canary.ServiceName, canary.Port)
+		httpRuleBackend.canary = &canary
+		httpRuleBackends = append(httpRuleBackends, httpRuleBackend)
+	}
+
+	utils.AviLog.Debugf("key: %s, msg: httprule %s backends for host %s path %s: %s", key, rule, host, hostPathMapSvc.Path, utils.Stringify(httpRuleBackends))
+	return httpRuleBackends
+}
+
 func destinationCAHTTPRulePresent(key, host, path string) (bool, string) {
 	// from host check if httprule is present
 	found, pathRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(host)
@@ -167,6 +296,36 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		useDefaultSecret = strings.EqualFold(val, "true")
 	}
 
+	conflictingHosts := getConflictingHosts(key, utils.Ingress, ns, ingName, parseHostsForIngress(ingSpec, key))
+	if len(conflictingHosts) > 0 && lib.GetHostnameConflictPolicy() == lib.HostnameConflictReject {
+		utils.AviLog.Warnf("key: %s, msg: rejecting ingress due to hostname conflicts for hosts: %v", key, conflictingHosts)
+		return ingressConfig
+	}
+
+	backendNamespaces := lib.GetIngressBackendNamespaces(annotations)
+
+	// passthrough is only supported with hostname sharding
+	var enablePassthrough bool
+	if val, found := annotations[lib.PassthroughAnnotation]; found && lib.GetShardScheme() != "namespace" {
+		enablePassthrough = strings.EqualFold(val, "true")
+	}
+	passConfig := make(map[string]PassthroughSettings)
+
+	// the default certificate is used for the tls hosts without a usable secret, and optionally
+	// for the hosts under the default certificate domain which are not part of any tls entry
+	defaultCertNS, defaultCert := getIngressDefaultCert(key, ingSpec)
+	var defaultCertDomain string
+	if defaultCert != "" {
+		defaultCertDomain = lib.GetDefaultCertDomain()
+	}
+	tlsHosts := make(map[string]bool)
+	for _, tlsSettings := range ingSpec.TLS {
+		for _, host := range tlsSettings.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+	var defaultCertHosts []string
+
 	var tlsConfigs []TlsSettings
 	for _, rule := range ingSpec.Rules {
 		var hostPathMapSvcList []IngressHostPathSvc
@@ -184,12 +343,29 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 				}
 			}
 		} else {
-			if !v.IsValidHostName(rule.Host) {
+			if !v.IsValidHostName(rule.Host) || utils.HasElem(conflictingHosts, rule.Host) {
 				continue
 			}
 			hostName = rule.Host
 		}
 
+		// the tls connections of a passthrough host are switched to its backends by SNI, the paths are not used
+		if enablePassthrough || passthroughHostRulePresent(key, hostName) {
+			pass := passConfig[hostName]
+			pass.host = hostName
+			pass.redirect, _ = getHostRuleRedirect(hostName, true)
+			if rule.IngressRuleValue.HTTP != nil {
+				for _, path := range rule.IngressRuleValue.HTTP.Paths {
+					hostPathMapSvc, ok := getPassthroughPathSvc(key, ns, path, backendNamespaces)
+					if ok && !hasPassthroughService(pass.PathSvc, hostPathMapSvc) {
+						pass.PathSvc = append(pass.PathSvc, hostPathMapSvc)
+					}
+				}
+			}
+			passConfig[hostName] = pass
+			continue
+		}
+
 		if len(hostMap[hostName]) > 0 {
 			hostPathMapSvcList = hostMap[hostName]
 		}
@@ -218,41 +394,62 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 					Port:        path.Backend.ServicePort.IntVal,
 					PortName:    path.Backend.ServicePort.StrVal,
 				}
+				if svcNS, ok := backendNamespaces[path.Backend.ServiceName]; ok && svcNS != ns {
+					if !isBackendGranted(utils.Ingress, ns, svcNS, path.Backend.ServiceName) {
+						utils.AviLog.Warnf("key: %s, msg: skipping path %s, service %s/%s is not granted to namespace %s by any %s",
+							key, path.Path, svcNS, path.Backend.ServiceName, ns, lib.BackendGrant)
+						continue
+					}
+					hostPathMapSvc.ServiceNamespace = svcNS
+				}
 				if hostPathMapSvc.Port == 0 {
 					// Default to port 80 if not set in the ingress object
 					hostPathMapSvc.Port = 80
 				}
 				// for ingress use 100 as default weight
 				hostPathMapSvc.weight = 100
+				httpRuleBackends := getHTTPRuleBackends(key, hostName, &hostPathMapSvc)
 				hostPathMapSvcList = append(hostPathMapSvcList, hostPathMapSvc)
+				hostPathMapSvcList = append(hostPathMapSvcList, httpRuleBackends...)
 			}
 		}
 
+		inDefaultCertDomain := defaultCertDomain != "" && !tlsHosts[hostName] && lib.WildcardHostMatch(defaultCertDomain, hostName)
 		if useHostRuleSSL {
 			additionalSecureHostMap[hostName] = hostPathMapSvcList
-		} else if useDefaultSecret {
+		} else if useDefaultSecret || inDefaultCertDomain {
 			defaultTLS := TlsSettings{}
 			defaultTLS.SecretName = lib.GetDefaultSecretForRoutes()
 			defaultTLS.SecretNS = utils.GetAKONamespace()
+			if defaultCert != "" {
+				defaultTLS.SecretName, defaultTLS.SecretNS = defaultCert, defaultCertNS
+			}
 			defaultTLSHostSvcMap := make(IngressHostMap)
 			defaultTLSHostSvcMap[hostName] = hostPathMapSvcList
 			defaultTLS.Hosts = defaultTLSHostSvcMap
 			defaultTLS.redirect = true
 			tlsConfigs = append(tlsConfigs, defaultTLS)
-			if ok, _ := objects.SharedSvcLister().IngressMappings(ns).GetIngToSecret(ingName); !ok {
-				objects.SharedSvcLister().IngressMappings(ns).AddIngressToSecretsMappings(ns, ingName, defaultTLS.SecretName)
-				objects.SharedSvcLister().IngressMappings(ns).AddSecretsToIngressMappings(ns, ingName, defaultTLS.SecretName)
+			addDefaultCertMappings(ns, ingName, defaultTLS.SecretNS, defaultTLS.SecretName)
+			if !useDefaultSecret {
+				defaultCertHosts = append(defaultCertHosts, hostName)
 			}
 		} else {
 			hostMap[hostName] = hostPathMapSvcList
 		}
 	}
 
+	// index in tlsConfigs of the TLS entry securing each host
+	tlsHostIndex := make(map[string]int)
 	for _, tlsSettings := range ingSpec.TLS {
 		tlsHostSvcMap := make(IngressHostMap)
 		tls := TlsSettings{}
 		tls.SecretName = tlsSettings.SecretName
 		tls.SecretNS = ns
+		useDefaultCert := defaultCert != "" && !isSecretPresent(ns, tlsSettings.SecretName)
+		if useDefaultCert {
+			tls.SecretName, tls.SecretNS = defaultCert, defaultCertNS
+			addDefaultCertMappings(ns, ingName, defaultCertNS, defaultCert)
+		}
 		for _, host := range tlsSettings.Hosts {
 			if _, ok := additionalSecureHostMap[host]; ok {
 				continue
@@ -264,6 +461,13 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 			if ok {
 				tlsHostSvcMap[host] = hostSvcMap
 				delete(hostMap, host)
+				tlsHostIndex[host] = len(tlsConfigs)
+				if useDefaultCert {
+					defaultCertHosts = append(defaultCertHosts, host)
+				}
+			} else if i, found := tlsHostIndex[host]; found && !useDefaultCert {
+				// the host is already secured by an earlier TLS entry, this one holds its alternate certificate
+				addAltSecret(key, &tlsConfigs[i], host, tlsSettings.SecretName)
 			}
 		}
 		tls.Hosts = tlsHostSvcMap
@@ -296,19 +500,58 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		}
 	}
 
+	reportDefaultCert(key, ns, ingName, defaultCertNS, defaultCert, defaultCertHosts)
+
 	ingressConfig.TlsCollection = tlsConfigs
 	ingressConfig.IngressHostMap = hostMap
+	if len(passConfig) > 0 {
+		ingressConfig.PassthroughCollection = passConfig
+	}
 	utils.AviLog.Infof("key: %s, msg: host path config from ingress: %+v", key, utils.Stringify(ingressConfig))
 	return ingressConfig
 }
 
+// getPassthroughPathSvc returns the backend of an ingress path on a passthrough host,
+// which is skipped if the service is in another namespace that is not granted to the ingress
+func getPassthroughPathSvc(key, ns string, path networkingv1beta1.HTTPIngressPath, backendNamespaces map[string]string) (IngressHostPathSvc, bool) {
+	hostPathMapSvc := IngressHostPathSvc{
+		Path:        path.Path,
+		ServiceName: path.Backend.ServiceName,
+		Port:        path.Backend.ServicePort.IntVal,
+		PortName:    path.Backend.ServicePort.StrVal,
+		weight:      100,
+	}
+	if svcNS, ok := backendNamespaces[path.Backend.ServiceName]; ok && svcNS != ns {
+		if !isBackendGranted(utils.Ingress, ns, svcNS, path.Backend.ServiceName) {
+			utils.AviLog.Warnf("key: %s, msg: skipping path %s, service %s/%s is not granted to namespace %s by any %s",
+				key, path.Path, svcNS, path.Backend.ServiceName, ns, lib.BackendGrant)
+			return hostPathMapSvc, false
+		}
+		hostPathMapSvc.ServiceNamespace = svcNS
+	}
+	return hostPathMapSvc, true
+}
+
+// hasPassthroughService checks if the namespace/name of the backend service already has a pool for the passthrough host
+func hasPassthroughService(pathSvcList []IngressHostPathSvc, pathSvc IngressHostPathSvc) bool {
+	for _, obj := range pathSvcList {
+		if obj.ServiceNamespace == pathSvc.ServiceNamespace && obj.ServiceName == pathSvc.ServiceName {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *Validator) ParseHostPathForRoute(ns string, routeName string, routeSpec routev1.RouteSpec, key string) IngressConfig {
 	ingressConfig := IngressConfig{}
 	hostMap := make(IngressHostMap)
-	hostName := routeSpec.Host
+	hostName := lib.GetRouteHostName(routeSpec)
 	if !v.IsValidHostName(hostName) {
 		return ingressConfig
 	}
+	if conflictingHosts := getConflictingHosts(key, utils.OshiftRoute, ns, routeName, []string{hostName}); len(conflictingHosts) > 0 {
+		return ingressConfig
+	}
 	defaultWeight := int32(100)
 	var hostPathMapSvcList []IngressHostPathSvc
 
@@ -330,6 +573,7 @@ func (v *Validator) ParseHostPathForRoute(ns string, routeName string, routeSpec
 		utils.AviLog.Infof("key: %s, msg: no port specified for route, all ports would be used", key)
 	}
 
+	httpRuleBackends := getHTTPRuleBackends(key, hostName, &hostPathMapSvc)
 	hostPathMapSvcList = append(hostPathMapSvcList, hostPathMapSvc)
 
 	for _, backend := range routeSpec.AlternateBackends {
@@ -342,6 +586,7 @@ func (v *Validator) ParseHostPathForRoute(ns string, routeName string, routeSpec
 		}
 		hostPathMapSvcList = append(hostPathMapSvcList, hostPathMapSvc)
 	}
+	hostPathMapSvcList = append(hostPathMapSvcList, httpRuleBackends...)
 
 	hostMap[hostName] = hostPathMapSvcList
 
@@ -417,3 +662,35 @@ func (v *Validator) ParseHostPathForRoute(ns string, routeName string, routeSpec
 	utils.AviLog.Infof("key: %s, msg: host path config from routes: %+v", key, utils.Stringify(ingressConfig))
 	return ingressConfig
 }
+
+// isBackendGranted checks whether a BackendGrant in the namespace of the service allows
+// the ingresses or gateways in the given namespace to use the service as a backend.
+func isBackendGranted(kind, fromNamespace, svcNamespace, svcName string) bool {
+	if lib.GetCRDInformers() == nil {
+		return false
+	}
+	backendGrants, err := lib.GetCRDInformers().BackendGrantInformer.Lister().BackendGrants(svcNamespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("Unable to list backendgrant.ako.vmware.com in namespace %s: %v", svcNamespace, err)
+		return false
+	}
+	for _, backendGrant := range backendGrants {
+		var fromGranted, toGranted bool
+		for _, from := range backendGrant.Spec.From {
+			if from.Kind == kind && from.Namespace == fromNamespace {
+				fromGranted = true
+				break
+			}
+		}
+		for _, to := range backendGrant.Spec.To {
+			if to.Name == "" || to.Name == svcName {
+				toGranted = true
+				break
+			}
+		}
+		if fromGranted && toGranted {
+			return true
+		}
+	}
+	return false
+}
@@ -27,8 +27,11 @@ func SharedCRDLister() *CRDLister {
 		CRDinstance = &CRDLister{
 			FqdnHostRuleCache:  NewObjectMapStore(),
 			HostRuleFQDNCache:  NewObjectMapStore(),
+			FqdnFqdnTypeCache:  NewObjectMapStore(),
 			FqdnHTTPRulesCache: NewObjectMapStore(),
 			HTTPRuleFqdnCache:  NewObjectMapStore(),
+			FqdnAuthRuleCache:  NewObjectMapStore(),
+			AuthRuleFQDNCache:  NewObjectMapStore(),
 		}
 	})
 	return CRDinstance
@@ -45,11 +48,20 @@ type CRDLister struct {
 	// hr1: fqdn.com - required for httprule
 	HostRuleFQDNCache *ObjectMapStore
 
+	// *.fqdn.com: Wildcard, only the fqdns that are not matched exactly are stored
+	FqdnFqdnTypeCache *ObjectMapStore
+
 	// fqdn.com: {path1: rr1, path2: rr1, path3: rr2}
 	FqdnHTTPRulesCache *ObjectMapStore
 
 	// rr1: fqdn1.com, rr2: fqdn2.com
 	HTTPRuleFqdnCache *ObjectMapStore
+
+	// fqdn.com: ar1
+	FqdnAuthRuleCache *ObjectMapStore
+
+	// ar1: fqdn.com
+	AuthRuleFQDNCache *ObjectMapStore
 }
 
 // FqdnHostRuleCache
@@ -77,6 +89,7 @@ func (c *CRDLister) DeleteHostruleFQDNMapping(hostrule string) bool {
 	if found {
 		success1 := c.HostRuleFQDNCache.Delete(hostrule)
 		success2 := c.FqdnHostRuleCache.Delete(fqdn.(string))
+		c.FqdnFqdnTypeCache.Delete(fqdn.(string))
 		// utils.AviLog.Infof("Deleted the ingress mappings for hostrule: %s, fqdn: %s", hostrule, fqdn)
 		return success1 && success2
 	}
@@ -91,6 +104,26 @@ func (c *CRDLister) UpdateFQDNHostruleMapping(fqdn string, hostrule string) {
 	c.HostRuleFQDNCache.AddOrUpdate(hostrule, fqdn)
 }
 
+// FqdnFqdnTypeCache
+
+func (c *CRDLister) GetFQDNToFQDNTypeMapping(fqdn string) (bool, string) {
+	found, fqdnType := c.FqdnFqdnTypeCache.Get(fqdn)
+	if !found {
+		return false, ""
+	}
+	return true, fqdnType.(string)
+}
+
+func (c *CRDLister) GetAllFQDNToFQDNTypeMapping() map[string]interface{} {
+	return c.FqdnFqdnTypeCache.CopyAllObjects()
+}
+
+func (c *CRDLister) UpdateFQDNToFQDNTypeMapping(fqdn string, fqdnType string) {
+	c.NSLock.Lock()
+	defer c.NSLock.Unlock()
+	c.FqdnFqdnTypeCache.AddOrUpdate(fqdn, fqdnType)
+}
+
 // FqdnHTTPRulesCache
 
 func (c *CRDLister) GetFqdnHTTPRulesMapping(fqdn string) (bool, map[string]string) {
@@ -139,3 +172,40 @@ func (c *CRDLister) UpdateFqdnHTTPRulesMappings(fqdn, path, httprule string) {
 	pathRules[path] = httprule
 	c.FqdnHTTPRulesCache.AddOrUpdate(fqdn, pathRules)
 }
+
+// FqdnAuthRuleCache
+
+func (c *CRDLister) GetFQDNToAuthRuleMapping(fqdn string) (bool, string) {
+	found, authrule := c.FqdnAuthRuleCache.Get(fqdn)
+	if !found {
+		return false, ""
+	}
+	return true, authrule.(string)
+}
+
+func (c *CRDLister) GetAuthRuleToFQDNMapping(authrule string) (bool, string) {
+	found, fqdn := c.AuthRuleFQDNCache.Get(authrule)
+	if !found {
+		return false, ""
+	}
+	return true, fqdn.(string)
+}
+
+func (c *CRDLister) DeleteAuthRuleFQDNMapping(authrule string) bool {
+	c.NSLock.Lock()
+	defer c.NSLock.Unlock()
+	found, fqdn := c.AuthRuleFQDNCache.Get(authrule)
+	if found {
+		success1 := c.AuthRuleFQDNCache.Delete(authrule)
+		success2 := c.FqdnAuthRuleCache.Delete(fqdn.(string))
+		return success1 && success2
+	}
+	return true
+}
+
+func (c *CRDLister) UpdateFQDNAuthRuleMapping(fqdn string, authrule string) {
+	c.NSLock.Lock()
+	defer c.NSLock.Unlock()
+	c.FqdnAuthRuleCache.AddOrUpdate(fqdn, authrule)
+	c.AuthRuleFQDNCache.AddOrUpdate(authrule, fqdn)
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package objects
+
+import (
+	"sync"
+)
+
+var fullSyncInstance *FullSyncLister
+var fullSyncOnce sync.Once
+
+func SharedFullSyncLister() *FullSyncLister {
+	fullSyncOnce.Do(func() {
+		fullSyncInstance = &FullSyncLister{
+			resourceVersions: make(map[string]string),
+			seen:             make(map[string]bool),
+			modelChecksums:   make(map[string]uint32),
+		}
+	})
+	return fullSyncInstance
+}
+
+// FullSyncLister keeps the resourceVersions of the objects evaluated by the last full sync and the
+// checksums of the models last pushed to the rest layer, so that an incremental full sync only
+// evaluates the objects and pushes the models which changed since.
+type FullSyncLister struct {
+	lock             sync.RWMutex
+	resourceVersions map[string]string
+	seen             map[string]bool
+	modelChecksums   map[string]uint32
+}
+
+// BeginSync starts tracking the objects seen by a full sync.
+func (f *FullSyncLister) BeginSync() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.seen = make(map[string]bool)
+}
+
+// ObjectChanged records the resourceVersion of an object seen by the full sync,
+// and returns whether it changed since the previous full sync.
+func (f *FullSyncLister) ObjectChanged(key, resourceVersion string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.seen[key] = true
+	if resourceVersion == "" {
+		return true
+	}
+	previous, found := f.resourceVersions[key]
+	f.resourceVersions[key] = resourceVersion
+	return !found || previous != resourceVersion
+}
+
+// DeletedObjects returns the objects seen by the previous full sync and not by the current one,
+// and stops tracking them.
+func (f *FullSyncLister) DeletedObjects() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var deleted []string
+	for key := range f.resourceVersions {
+		if !f.seen[key] {
+			deleted = append(deleted, key)
+			delete(f.resourceVersions, key)
+		}
+	}
+	return deleted
+}
+
+func (f *FullSyncLister) SaveModelChecksum(modelName string, checksum uint32) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.modelChecksums[modelName] = checksum
+}
+
+func (f *FullSyncLister) GetModelChecksum(modelName string) (uint32, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	checksum, found := f.modelChecksums[modelName]
+	return checksum, found
+}
+
+func (f *FullSyncLister) DeleteModelChecksum(modelName string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.modelChecksums, modelName)
+}
+
+// GetModelChecksums returns a copy of the checksums of the models last pushed, to persist them with the cache.
+func (f *FullSyncLister) GetModelChecksums() map[string]uint32 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	checksums := make(map[string]uint32, len(f.modelChecksums))
+	for modelName, checksum := range f.modelChecksums {
+		checksums[modelName] = checksum
+	}
+	return checksums
+}
+
+// RestoreModelChecksums restores the checksums of the models pushed before AKO restarted.
+func (f *FullSyncLister) RestoreModelChecksums(checksums map[string]uint32) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for modelName, checksum := range checksums {
+		f.modelChecksums[modelName] = checksum
+	}
+}
+
+// Reset forgets everything, the next full sync evaluates all the objects and pushes all the models.
+func (f *FullSyncLister) Reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.resourceVersions = make(map[string]string)
+	f.seen = make(map[string]bool)
+	f.modelChecksums = make(map[string]uint32)
+}
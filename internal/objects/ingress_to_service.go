@@ -212,6 +212,14 @@ func (v *IngNSCache) GetIngToSvc(ingName string) (bool, []string) {
 	return true, svcNames.([]string)
 }
 
+func (v *IngNSCache) GetAllIngresses() []string {
+	var ingresses []string
+	for ingName := range v.ingSvcObjects.CopyAllObjects() {
+		ingresses = append(ingresses, ingName)
+	}
+	return ingresses
+}
+
 func (v *IngNSCache) DeleteIngToSvcMapping(ingName string) bool {
 	success := v.ingSvcObjects.Delete(ingName)
 	return success
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package objects
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+var nsDomainInstance *NamespaceDomainLister
+var nsDomainOnce sync.Once
+
+func SharedNamespaceDomainLister() *NamespaceDomainLister {
+	nsDomainOnce.Do(func() {
+		nsDomainInstance = &NamespaceDomainLister{
+			nsDomains:        make(map[string][]string),
+			domainNamespaces: make(map[string]map[string]struct{}),
+		}
+	})
+	return nsDomainInstance
+}
+
+// NamespaceDomainLister indexes the domains granted to namespaces via the allowed-domains annotation
+// by domain, so that the namespaces granted a host are found by walking up the labels of the host.
+// cache sample: foo.com -> {ns1}, bar.foo.com -> {ns2}
+type NamespaceDomainLister struct {
+	lock             sync.RWMutex
+	populated        bool
+	nsDomains        map[string][]string
+	domainNamespaces map[string]map[string]struct{}
+}
+
+// IsPopulated returns true once the domains of all the namespaces are saved in the lister.
+func (n *NamespaceDomainLister) IsPopulated() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.populated
+}
+
+func (n *NamespaceDomainLister) SetPopulated() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.populated = true
+}
+
+// Save replaces the domains granted to a namespace.
+func (n *NamespaceDomainLister) Save(namespace string, domains []string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.delete(namespace)
+	if len(domains) == 0 {
+		return
+	}
+	n.nsDomains[namespace] = domains
+	for _, domain := range domains {
+		if _, ok := n.domainNamespaces[domain]; !ok {
+			n.domainNamespaces[domain] = make(map[string]struct{})
+		}
+		n.domainNamespaces[domain][namespace] = struct{}{}
+	}
+}
+
+func (n *NamespaceDomainLister) Delete(namespace string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.delete(namespace)
+}
+
+func (n *NamespaceDomainLister) delete(namespace string) {
+	for _, domain := range n.nsDomains[namespace] {
+		delete(n.domainNamespaces[domain], namespace)
+		if len(n.domainNamespaces[domain]) == 0 {
+			delete(n.domainNamespaces, domain)
+		}
+	}
+	delete(n.nsDomains, namespace)
+}
+
+// GetNamespacesForHost returns the namespaces granted a domain which is the host itself, or any of its parent domains.
+func (n *NamespaceDomainLister) GetNamespacesForHost(host string) []string {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	var namespaces []string
+	seen := make(map[string]bool)
+	domain := strings.TrimPrefix(strings.ToLower(host), "*.")
+	for domain != "" {
+		for namespace := range n.domainNamespaces[domain] {
+			if !seen[namespace] {
+				seen[namespace] = true
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		i := strings.Index(domain, ".")
+		if i < 0 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package objects
+
+import (
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// AnyPoolServer is used as the server key when every Pod of a pool is fronted by
+// all the pool servers, for instance the Node servers in NodePort mode.
+const AnyPoolServer = "*"
+
+// PoolServerPods maps a pool server, formatted as ip:port, to the Pods it fronts.
+type PoolServerPods map[string][]utils.NamespaceName
+
+// AllPods returns the unique Pods fronted by the pool servers.
+func (p PoolServerPods) AllPods() []utils.NamespaceName {
+	var pods []utils.NamespaceName
+	seen := make(map[utils.NamespaceName]bool)
+	for _, serverPods := range p {
+		for _, pod := range serverPods {
+			if !seen[pod] {
+				seen[pod] = true
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods
+}
+
+var poolPodInstance *PoolPodLister
+var poolPodOnce sync.Once
+
+func SharedPoolPodLister() *PoolPodLister {
+	poolPodOnce.Do(func() {
+		store := NewObjectMapStore()
+		poolPodInstance = &PoolPodLister{}
+		poolPodInstance.store = store
+	})
+	return poolPodInstance
+}
+
+// PoolPodLister stores the Pods backing the servers of a pool, used for
+// setting the pool readiness gate on Pods once the pool is pushed to the controller.
+type PoolPodLister struct {
+	store *ObjectMapStore
+}
+
+func (a *PoolPodLister) Save(poolName string, val PoolServerPods) {
+	a.store.AddOrUpdate(poolName, val)
+}
+
+func (a *PoolPodLister) Get(poolName string) (bool, PoolServerPods) {
+	ok, obj := a.store.Get(poolName)
+	if !ok {
+		return false, nil
+	}
+	serverPods, ok := obj.(PoolServerPods)
+	return ok, serverPods
+}
+
+func (a *PoolPodLister) Delete(poolName string) {
+	a.store.Delete(poolName)
+}
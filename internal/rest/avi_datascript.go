@@ -125,7 +125,20 @@ func (rest *RestOperations) AviDSCacheAdd(rest_op *utils.RestOp, vsKey avicache.
 		ds_cache_obj := avicache.AviDSCache{Name: name, Tenant: rest_op.Tenant,
 			Uuid: uuid, PoolGroups: poolgroups}
 
-		checksum := lib.DSChecksum(ds_cache_obj.PoolGroups)
+		var scripts []string
+		if resp["datascript"] != nil {
+			dsScripts, _ := resp["datascript"].([]interface{})
+			for _, dsScript := range dsScripts {
+				dsScriptMap, ok := dsScript.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if script, ok := dsScriptMap["script"].(string); ok {
+					scripts = append(scripts, script)
+				}
+			}
+		}
+		checksum := lib.DSChecksum(ds_cache_obj.PoolGroups, scripts...)
 		checksum += lib.GetClusterLabelChecksum()
 		ds_cache_obj.CloudConfigCksum = checksum
 
@@ -29,7 +29,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
-func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string, avimodel *nodes.AviObjectGraph, sniNode bool, vs_cache_obj *avicache.AviVsCache, key string) {
+func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string, avimodel *nodes.AviObjectGraph, sniNode bool, vs_cache_obj *avicache.AviVsCache, key string) bool {
 	var pools_to_delete []avicache.NamespaceName
 	var pgs_to_delete []avicache.NamespaceName
 	var vsvip_to_delete []avicache.NamespaceName
@@ -58,7 +58,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		vsvip_to_delete, rest_ops, vsvipErr = rest.VSVipCU(aviVsNode.VSVIPRefs, vs_cache_obj, namespace, rest_ops, key)
 		if vsvipErr != nil {
 			if rest.CheckAndPublishForRetry(vsvipErr, publishKey, key, avimodel) {
-				return
+				return false
 			}
 		}
 		sslkey_cert_delete, rest_ops = rest.CACertCU(aviVsNode.CACertRefs, vs_cache_obj.SSLKeyCertCollection, namespace, rest_ops, key)
@@ -79,14 +79,14 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 
 		}
 		if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
-			return
+			return false
 		}
 	} else {
 		var rest_ops []*utils.RestOp
 		_, rest_ops, vsvipErr = rest.VSVipCU(aviVsNode.VSVIPRefs, nil, namespace, rest_ops, key)
 		if vsvipErr != nil {
 			if rest.CheckAndPublishForRetry(vsvipErr, publishKey, key, avimodel) {
-				return
+				return false
 			}
 		}
 		_, rest_ops = rest.CACertCU(aviVsNode.CACertRefs, []avicache.NamespaceName{}, namespace, rest_ops, key)
@@ -101,7 +101,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		utils.AviLog.Debugf("POST key: %s, vsKey: %s", key, vsKey)
 		utils.AviLog.Debugf("POST restops %s", utils.Stringify(rest_ops))
 		if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
-			return
+			return false
 		}
 	}
 	if vs_cache_obj != nil {
@@ -123,7 +123,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, key)
 	if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
-		return
+		return false
 	}
 
 	for _, evhNode := range aviVsNode.EvhNodes {
@@ -137,7 +137,7 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 			_, evh_rest_ops = rest.EvhNodeCU(evhNode, nil, namespace, sni_to_delete, evh_rest_ops, key)
 		}
 		if success := rest.ExecuteRestAndPopulateCache(evh_rest_ops, vsKey, avimodel, key, true); !success {
-			return
+			return false
 		}
 	}
 
@@ -148,12 +148,13 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		for _, del_sni := range sni_to_delete {
 			rest.SNINodeDelete(del_sni, namespace, rest_ops, avimodel, key)
 			if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
-				return
+				return false
 			}
 		}
 
 	}
 
+	return true
 }
 
 func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj *avicache.AviVsCache, namespace string, cache_sni_nodes []avicache.NamespaceName, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
@@ -161,6 +162,9 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 	var sni_pgs_to_delete []avicache.NamespaceName
 	var http_policies_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
+	var app_profiles_to_delete []avicache.NamespaceName
+	var ssl_profiles_to_delete []avicache.NamespaceName
+	var sso_policies_to_delete []avicache.NamespaceName
 	if vs_cache_obj != nil {
 		sni_key := avicache.NamespaceName{Namespace: namespace, Name: sni_node.Name}
 		// Search the VS cache and obtain the UUID of this VS. Then see if this UUID is part of the SNIChildCollection or not.
@@ -179,6 +183,9 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 				sni_pools_to_delete, rest_ops = rest.PoolCU(sni_node.PoolRefs, sni_cache_obj, namespace, rest_ops, key)
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				app_profiles_to_delete, rest_ops = rest.AppProfileCU(sni_node.AppProfileNode, sni_cache_obj, namespace, rest_ops, key)
+				ssl_profiles_to_delete, rest_ops = rest.SSLProfileCU(sni_node.SSLProfileNode, sni_cache_obj, namespace, rest_ops, key)
+				sso_policies_to_delete, rest_ops = rest.SSOPolicyCU(sni_node.SSOPolicyNode, sni_cache_obj, namespace, rest_ops, key)
 
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
@@ -195,6 +202,9 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 			_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.AppProfileCU(sni_node.AppProfileNode, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.SSLProfileCU(sni_node.SSLProfileNode, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.SSOPolicyCU(sni_node.SSOPolicyNode, nil, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -204,6 +214,9 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		rest_ops = rest.HTTPPolicyDelete(http_policies_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.AppProfileDelete(app_profiles_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.SSLProfileDelete(ssl_profiles_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.SSOPolicyDelete(sso_policies_to_delete, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: the SNI VSes to be deleted are: %s", key, cache_sni_nodes)
 	} else {
 		utils.AviLog.Debugf("key: %s, msg: sni child %s not found in cache and SNI parent also does not exist in cache", key, sni_node.Name)
@@ -212,6 +225,9 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.AppProfileCU(sni_node.AppProfileNode, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.SSLProfileCU(sni_node.SSLProfileNode, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.SSOPolicyCU(sni_node.SSOPolicyNode, nil, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -278,9 +294,14 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 		}
 		// TODO other fields like cloud_ref, mix of TCP & UDP protocols, etc.
 
+		enableHTTP2 := vs_meta.HasHTTP2Pools()
 		for i, pp := range vs_meta.PortProto {
 			port := pp.Port
 			svc := avimodels.Service{Port: &port, EnableSsl: &vs_meta.PortProto[i].EnableSSL}
+			if enableHTTP2 && pp.EnableSSL {
+				// the clients of the HTTP/2 pools, such as gRPC, negotiate HTTP/2 on the SSL ports
+				svc.EnableHttp2 = &enableHTTP2
+			}
 			vs.Services = append(vs.Services, &svc)
 		}
 
@@ -304,6 +325,9 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 			// this overwrites the sslkeycert created from the Secret object, with the one mentioned in HostRule.TLS
 			if vs_meta.SSLKeyCertAviRef != "" {
 				vs.SslKeyAndCertificateRefs = append(vs.SslKeyAndCertificateRefs, vs_meta.SSLKeyCertAviRef)
+				if vs_meta.AltSSLKeyCertAviRef != "" {
+					vs.SslKeyAndCertificateRefs = append(vs.SslKeyAndCertificateRefs, vs_meta.AltSSLKeyCertAviRef)
+				}
 			} else {
 				for _, sslkeycert := range vs_meta.SSLKeyCertRefs {
 					certName := "/api/sslkeyandcertificate/?name=" + sslkeycert.Name
@@ -415,11 +439,22 @@ func (rest *RestOperations) AviVsChildEvhBuild(vs_meta *nodes.AviEvhVsNode, rest
 		evhChild.PoolGroupRef = &poolgroup_ref
 	}
 
+	// from authrule CRD
+	if vs_meta.SSOPolicyNode != nil {
+		ssoPolicyRef := "/api/ssopolicy/?name=" + vs_meta.SSOPolicyNode.Name
+		evhChild.SsoPolicyRef = &ssoPolicyRef
+		evhChild.JwtConfig = vs_meta.SSOPolicyNode.JwtConfig
+		evhChild.SamlSpConfig = vs_meta.SSOPolicyNode.SamlSpConfig
+	}
+
 	// No need of HTTP rules for TLS passthrough.
 	if vs_meta.TLSType != utils.TLS_PASSTHROUGH {
 		// this overwrites the sslkeycert created from the Secret object, with the one mentioned in HostRule.TLS
 		if vs_meta.SSLKeyCertAviRef != "" {
 			evhChild.SslKeyAndCertificateRefs = append(evhChild.SslKeyAndCertificateRefs, vs_meta.SSLKeyCertAviRef)
+			if vs_meta.AltSSLKeyCertAviRef != "" {
+				evhChild.SslKeyAndCertificateRefs = append(evhChild.SslKeyAndCertificateRefs, vs_meta.AltSSLKeyCertAviRef)
+			}
 		} else {
 			for _, sslkeycert := range vs_meta.SSLKeyCertRefs {
 				certName := "/api/sslkeyandcertificate/?name=" + sslkeycert.Name
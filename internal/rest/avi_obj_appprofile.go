@@ -0,0 +1,191 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/avinetworks/sdk/go/models"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviAppProfileBuild(app_profile_node *nodes.AviAppProfileNode, cache_obj *avicache.AviAppProfileCache, key string) *utils.RestOp {
+	name := app_profile_node.Name
+	cksumString := strconv.Itoa(int(app_profile_node.GetCheckSum()))
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", app_profile_node.Tenant)
+	cr := lib.AKOUser
+	appProfileType := lib.AllowedApplicationProfile
+	clientCertMode := app_profile_node.ClientCertMode
+
+	httpProfile := &avimodels.HTTPApplicationProfile{
+		SslClientCertificateMode: &clientCertMode,
+	}
+	if app_profile_node.PkiProfile != nil {
+		pkiProfileRef := "/api/pkiprofile?name=" + app_profile_node.PkiProfile.Name
+		httpProfile.PkiProfileRef = &pkiProfileRef
+	}
+	if len(app_profile_node.ClientCertHeaders) > 0 {
+		httpProfile.SslClientCertificateAction = &avimodels.SSLClientCertificateAction{
+			Headers: app_profile_node.ClientCertHeaders,
+		}
+	}
+
+	appProfile := avimodels.ApplicationProfile{
+		Name:             &name,
+		TenantRef:        &tenant,
+		CreatedBy:        &cr,
+		Type:             &appProfileType,
+		HTTPProfile:      httpProfile,
+		CloudConfigCksum: &cksumString,
+	}
+	if rateLimit := app_profile_node.RateLimit; rateLimit != nil && rateLimit.RequestsPerSecond > 0 {
+		rateProfile := &avimodels.RateProfile{
+			Action:      buildRateLimiterAction(rateLimit.Action),
+			RateLimiter: buildRateLimiter(*rateLimit),
+		}
+		rlProfile := &avimodels.RateLimiterProfile{}
+		if rateLimit.Key == lib.RateLimitKeyHeader {
+			rateProfile.HTTPHeader = &rateLimit.Header
+			rlProfile.HTTPHeaderRateLimits = []*avimodels.RateProfile{rateProfile}
+		} else {
+			rlProfile.ClientIPRequestsRateLimit = rateProfile
+		}
+		appProfile.DosRlProfile = &avimodels.DosRateLimitProfile{RlProfile: rlProfile}
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "ApplicationProfile", Data: appProfile}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/applicationprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: appProfile,
+			Tenant: app_profile_node.Tenant, Model: "ApplicationProfile", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: app_profile_node.Tenant, Model: "ApplicationProfile", Version: utils.CtrlVersion}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: applicationprofile Restop %v K8sAviAppProfileMeta %v\n", key,
+		utils.Stringify(rest_op), *app_profile_node))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviAppProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/applicationprofile/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "ApplicationProfile", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: applicationprofile DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviAppProfileCacheAdd(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for applicationprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "applicationprofile", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find applicationprofile obj in resp %v", key, rest_op.Response)
+		return errors.New("applicationprofile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var appProfile avimodels.ApplicationProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			appProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile)
+		case avimodels.ApplicationProfile:
+			appProfile = rest_op.Obj.(avimodels.ApplicationProfile)
+		}
+
+		cksum, _ := resp["cloud_config_cksum"].(string)
+
+		var pkiKey avicache.NamespaceName
+		if httpProfile := appProfile.HTTPProfile; httpProfile != nil && httpProfile.PkiProfileRef != nil {
+			pkiKey = avicache.NamespaceName{Namespace: rest_op.Tenant, Name: strings.TrimPrefix(*httpProfile.PkiProfileRef, "/api/pkiprofile?name=")}
+		}
+
+		app_profile_cache_obj := avicache.AviAppProfileCache{
+			Name:                 name,
+			Tenant:               rest_op.Tenant,
+			Uuid:                 uuid,
+			CloudConfigCksum:     cksum,
+			PkiProfileCollection: pkiKey,
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.AppProfileCache.AviCacheAdd(k, &app_profile_cache_obj)
+		// Update the VS object
+		if vsKey != (avicache.NamespaceName{}) {
+			vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+			if ok {
+				vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+				if found {
+					vs_cache_obj.AddToAppProfileCollection(k)
+					utils.AviLog.Debugf("key: %s, msg: modified the VS cache object for applicationprofile collection, the cache now is: %v", key, utils.Stringify(vs_cache_obj))
+				}
+			} else {
+				vs_cache_obj := rest.cache.VsCacheMeta.AviCacheAddVS(vsKey)
+				vs_cache_obj.AddToAppProfileCollection(k)
+				utils.AviLog.Info(spew.Sprintf("key: %s, msg: added VS cache key during applicationprofile update %v val %v\n", key, vsKey,
+					vs_cache_obj))
+			}
+		}
+		utils.AviLog.Info(spew.Sprintf("key: %s, msg: added applicationprofile cache k %v val %v\n", key, k,
+			app_profile_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviAppProfileCacheDel(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	appProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting applicationprofile with key: %s", key, appProfileKey)
+	rest.cache.AppProfileCache.AviCacheDelete(appProfileKey)
+	if vsKey != (avicache.NamespaceName{}) {
+		vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+		if ok {
+			vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+			if found {
+				vs_cache_obj.RemoveFromAppProfileCollection(appProfileKey)
+			}
+		}
+	}
+	return nil
+}
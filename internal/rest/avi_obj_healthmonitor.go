@@ -0,0 +1,148 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/avinetworks/sdk/go/models"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviHealthMonitorBuild(hm_node *nodes.AviHealthMonitorNode, cache_obj *avicache.AviHealthMonitorCache, key string) *utils.RestOp {
+	name := hm_node.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", hm_node.Tenant)
+	hmType := hm_node.Type
+	sendInterval := hm_node.SendInterval
+	receiveTimeout := hm_node.ReceiveTimeout
+	successfulChecks := hm_node.SuccessfulChecks
+	failedChecks := hm_node.FailedChecks
+
+	hm := avimodels.HealthMonitor{
+		Name:             &name,
+		TenantRef:        &tenant,
+		Type:             &hmType,
+		SendInterval:     &sendInterval,
+		ReceiveTimeout:   &receiveTimeout,
+		SuccessfulChecks: &successfulChecks,
+		FailedChecks:     &failedChecks,
+	}
+	if hm_node.MonitorPort != 0 {
+		monitorPort := hm_node.MonitorPort
+		hm.MonitorPort = &monitorPort
+	}
+
+	if hm_node.HTTPRequest != "" {
+		httpRequest := hm_node.HTTPRequest
+		httpMonitor := &avimodels.HealthMonitorHTTP{
+			HTTPRequest:      &httpRequest,
+			HTTPResponseCode: hm_node.HTTPResponseCodes,
+		}
+		if hmType == "HEALTH_MONITOR_HTTPS" {
+			hm.HTTPSMonitor = httpMonitor
+		} else {
+			hm.HTTPMonitor = httpMonitor
+		}
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "HealthMonitor", Data: hm}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/healthmonitor/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: hm,
+			Tenant: hm_node.Tenant, Model: "HealthMonitor", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: hm_node.Tenant, Model: "HealthMonitor", Version: utils.CtrlVersion}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: healthmonitor Restop %v K8sAviHealthMonitorMeta %v\n", key,
+		utils.Stringify(rest_op), *hm_node))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviHealthMonitorDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/healthmonitor/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "HealthMonitor", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: healthmonitor DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviHealthMonitorCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for healthmonitor, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "healthmonitor", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find healthmonitor obj in resp %v", key, rest_op.Response)
+		return errors.New("healthmonitor not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var hm avimodels.HealthMonitor
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			hm = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor)
+		case avimodels.HealthMonitor:
+			hm = rest_op.Obj.(avimodels.HealthMonitor)
+		}
+
+		hm_cache_obj := avicache.AviHealthMonitorCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.AviHealthMonitorChecksum(&hm),
+		}
+
+		// the pool cache picks up the healthmonitor from the pool response, which follows this one
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.HealthMonitorCache.AviCacheAdd(k, &hm_cache_obj)
+		utils.AviLog.Info(spew.Sprintf("key: %s, msg: added HealthMonitor cache k %v val %v\n", key, k,
+			hm_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviHealthMonitorCacheDel(rest_op *utils.RestOp, key string) error {
+	hmKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting healthmonitor with key: %s", key, hmKey)
+	rest.cache.HealthMonitorCache.AviCacheDelete(hmKey)
+	return nil
+}
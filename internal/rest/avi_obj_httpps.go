@@ -17,8 +17,11 @@ package rest
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
@@ -51,14 +54,7 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 		name := fmt.Sprintf("%s-%d", hps_meta.Name, idx)
 		match_target := avimodels.MatchTarget{}
 		if hppmap.Host != "" {
-			var host []string
-			host = append(host, hppmap.Host)
-			match_crit := "HDR_EQUALS"
-			host_hdr_match := avimodels.HostHdrMatch{
-				MatchCriteria: &match_crit,
-				Value:         host,
-			}
-			match_target.HostHdr = &host_hdr_match
+			match_target.HostHdr = buildHostHdrMatch([]string{hppmap.Host})
 		}
 
 		if len(hppmap.Path) > 0 {
@@ -82,6 +78,10 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 			match_target.VsPort = &vsport_match
 		}
 
+		if hppmap.Canary != nil {
+			buildCanaryMatch(hppmap.Canary, &match_target)
+		}
+
 		sw_action := avimodels.HttpswitchingAction{}
 		if hppmap.Pool != "" {
 			action := "HTTP_SWITCHING_SELECT_POOL"
@@ -104,33 +104,111 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 			Match:           &match_target,
 			SwitchingAction: &sw_action,
 		}
+		if hppmap.PathActions != nil {
+			buildHTTPPathActions(hppmap.PathActions, &rule)
+			if hdrActions := buildHdrActions(hppmap.PathActions.ResponseHeaders); len(hdrActions) > 0 {
+				if hps.HTTPResponsePolicy == nil {
+					hps.HTTPResponsePolicy = &avimodels.HTTPResponsePolicy{}
+				}
+				rspName := fmt.Sprintf("%s-rsp-%d", hps_meta.Name, idx)
+				rspIndex := int32(len(hps.HTTPResponsePolicy.Rules))
+				rspRule := avimodels.HTTPResponseRule{
+					Index:  &rspIndex,
+					Enable: &enable,
+					Name:   &rspName,
+					Match: &avimodels.ResponseMatchTarget{
+						HostHdr: match_target.HostHdr,
+						Path:    match_target.Path,
+						VsPort:  match_target.VsPort,
+					},
+					HdrAction: hdrActions,
+				}
+				hps.HTTPResponsePolicy.Rules = append(hps.HTTPResponsePolicy.Rules, &rspRule)
+			}
+			pathMatch := avimodels.MatchTarget{
+				HostHdr: match_target.HostHdr,
+				Path:    match_target.Path,
+				VsPort:  match_target.VsPort,
+			}
+			buildAccessControlRules(&hps, fmt.Sprintf("%s-%d", hps_meta.Name, idx), hppmap.PathActions.AccessControl, pathMatch)
+			if rateLimit := hppmap.PathActions.RateLimit; rateLimit.RequestsPerSecond > 0 {
+				if hps.HTTPSecurityPolicy == nil {
+					hps.HTTPSecurityPolicy = &avimodels.HttpsecurityPolicy{}
+				}
+				secName := fmt.Sprintf("%s-rl-%d", hps_meta.Name, idx)
+				secIndex := int32(len(hps.HTTPSecurityPolicy.Rules))
+				secAction := "HTTP_SECURITY_ACTION_RATE_LIMIT"
+				perClientIP := rateLimit.Key == "" || rateLimit.Key == lib.RateLimitKeyClientIP
+				secRule := avimodels.HttpsecurityRule{
+					Index:  &secIndex,
+					Enable: &enable,
+					Name:   &secName,
+					Match:  &pathMatch,
+					Action: &avimodels.HttpsecurityAction{
+						Action: &secAction,
+						RateProfile: &avimodels.HttpsecurityActionRateProfile{
+							Action:      buildRateLimiterAction(rateLimit.Action),
+							PerClientIP: &perClientIP,
+							RateLimiter: buildRateLimiter(rateLimit),
+						},
+					},
+				}
+				hps.HTTPSecurityPolicy.Rules = append(hps.HTTPSecurityPolicy.Rules, &secRule)
+			}
+		}
 		http_req_pol.Rules = append(http_req_pol.Rules, &rule)
 		idx = idx + 1
 	}
 
 	for _, hppmap := range hps_meta.RedirectPorts {
-		enable := true
-		name := fmt.Sprintf("%s-%d", hps_meta.Name, idx)
-		match_target := avimodels.MatchTarget{}
-		if len(hppmap.Hosts) > 0 {
-			match_crit := "HDR_EQUALS"
-			host_hdr_match := avimodels.HostHdrMatch{MatchCriteria: &match_crit,
-				Value: hppmap.Hosts}
-			match_target.HostHdr = &host_hdr_match
-			port_match_crit := "IS_IN"
-			match_target.VsPort = &avimodels.PortMatch{MatchCriteria: &port_match_crit, Ports: []int64{int64(hppmap.VsPort)}}
+		// Exact hosts and wildcard hosts need different match criteria, the exact hosts
+		// are matched first so that they take precedence over the wildcard hosts.
+		var exactHosts, wildcardHosts []string
+		for _, host := range hppmap.Hosts {
+			if lib.IsWildcardHost(host) {
+				wildcardHosts = append(wildcardHosts, host)
+			} else {
+				exactHosts = append(exactHosts, host)
+			}
 		}
-		redirect_action := avimodels.HTTPRedirectAction{}
-		protocol := "HTTPS"
-		redirect_action.StatusCode = &hppmap.StatusCode
-		redirect_action.Protocol = &protocol
-		redirect_action.Port = &hppmap.RedirectPort
-		var j int32
-		j = idx
-		rule := avimodels.HTTPRequestRule{Enable: &enable, Index: &j,
-			Name: &name, Match: &match_target, RedirectAction: &redirect_action}
-		http_req_pol.Rules = append(http_req_pol.Rules, &rule)
-		idx = idx + 1
+		var hostGroups [][]string
+		if len(exactHosts) > 0 {
+			hostGroups = append(hostGroups, exactHosts)
+		}
+		if len(wildcardHosts) > 0 {
+			hostGroups = append(hostGroups, wildcardHosts)
+		}
+		if len(hostGroups) == 0 {
+			hostGroups = append(hostGroups, nil)
+		}
+		for _, hosts := range hostGroups {
+			enable := true
+			name := fmt.Sprintf("%s-%d", hps_meta.Name, idx)
+			match_target := avimodels.MatchTarget{}
+			if len(hosts) > 0 {
+				match_target.HostHdr = buildHostHdrMatch(hosts)
+				port_match_crit := "IS_IN"
+				match_target.VsPort = &avimodels.PortMatch{MatchCriteria: &port_match_crit, Ports: []int64{int64(hppmap.VsPort)}}
+			}
+			redirect_action := avimodels.HTTPRedirectAction{}
+			protocol := "HTTPS"
+			redirect_action.StatusCode = &hppmap.StatusCode
+			redirect_action.Protocol = &protocol
+			redirect_action.Port = &hppmap.RedirectPort
+			var j int32
+			j = idx
+			rule := avimodels.HTTPRequestRule{Enable: &enable, Index: &j,
+				Name: &name, Match: &match_target, RedirectAction: &redirect_action}
+			http_req_pol.Rules = append(http_req_pol.Rules, &rule)
+			idx = idx + 1
+		}
+	}
+
+	if hps_meta.AccessControl != nil {
+		buildAccessControlRules(&hps, hps_meta.Name, *hps_meta.AccessControl, avimodels.MatchTarget{})
+	}
+	if hps_meta.HSTS != nil {
+		buildHSTSRule(&hps, hps_meta.Name, *hps_meta.HSTS)
 	}
 
 	macro := utils.AviRestObjMacro{ModelName: "HTTPPolicySet", Data: hps}
@@ -162,6 +240,240 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 	return &rest_op
 }
 
+// buildCanaryMatch adds the header and cookie matches of a canary backend set via HTTPRule,
+// the header or cookie is matched on its value if provided, else on its presence.
+func buildCanaryMatch(canary *akov1alpha1.HTTPRuleCanary, match *avimodels.MatchTarget) {
+	if header := canary.Header; header.Name != "" {
+		matchCriteria := "HDR_EXISTS"
+		hdrMatch := &avimodels.HdrMatch{Hdr: &header.Name, MatchCriteria: &matchCriteria}
+		if header.Value != "" {
+			matchCriteria = "HDR_EQUALS"
+			hdrMatch.Value = []string{header.Value}
+		}
+		match.Hdrs = append(match.Hdrs, hdrMatch)
+	}
+	if cookie := canary.Cookie; cookie.Name != "" {
+		matchCriteria := "HDR_EXISTS"
+		cookieMatch := &avimodels.CookieMatch{Name: &cookie.Name, MatchCriteria: &matchCriteria}
+		if cookie.Value != "" {
+			matchCriteria = "HDR_EQUALS"
+			cookieMatch.Value = &cookie.Value
+		}
+		match.Cookie = cookieMatch
+	}
+}
+
+// buildHTTPPathActions adds the request header, URL rewrite and redirect actions set via HTTPRule
+// to the switching rule of a path, a redirect replaces the switching action.
+func buildHTTPPathActions(pathActions *nodes.AviHTTPPathActions, rule *avimodels.HTTPRequestRule) {
+	rule.HdrAction = buildHdrActions(pathActions.RequestHeaders)
+
+	if pathActions.Redirect != (akov1alpha1.HTTPRuleRedirect{}) {
+		rule.RedirectAction = buildRedirectAction(pathActions.Redirect)
+		rule.SwitchingAction = nil
+		return
+	}
+
+	rewrite := pathActions.Rewrite
+	rewriteAction := avimodels.HTTPRewriteURLAction{}
+	if rewrite.Host != "" {
+		rewriteAction.HostHdr = buildURIParam(buildURIStringToken(rewrite.Host))
+	}
+	// the path is tokenized on "/", the tokens after the target path prefix are retained
+	// and prefixed with the rewritten path prefix
+	var targetSegments int32
+	if target := strings.Trim(pathActions.Target, "/"); target != "" {
+		targetSegments = int32(len(strings.Split(target, "/")))
+	}
+	pathType, endIndex := "URI_TOKEN_TYPE_PATH", int32(65535)
+	pathToken := &avimodels.URIParamToken{Type: &pathType, StartIndex: &targetSegments, EndIndex: &endIndex}
+	if prefix := strings.Trim(rewrite.PathPrefix, "/"); prefix != "" {
+		rewriteAction.Path = buildURIParam(buildURIStringToken(prefix+"/"), pathToken)
+	} else if rewrite.PathPrefix != "" || (rewrite.StripPathPrefix && targetSegments > 0) {
+		rewriteAction.Path = buildURIParam(pathToken)
+	}
+	if rewriteAction.HostHdr != nil || rewriteAction.Path != nil {
+		rule.RewriteURLAction = &rewriteAction
+	}
+}
+
+// buildAccessControlRules adds the security rules denying the clients as per the access control set via
+// HostRule or HTTPRule, match restricts the rules to the requests of a path.
+func buildAccessControlRules(hps *avimodels.HTTPPolicySet, name string, accessControl akov1alpha1.AccessControl, match avimodels.MatchTarget) {
+	addDenyRule := func(ruleName, matchCriteria string, cidrs, groups []string) {
+		if len(cidrs) == 0 && len(groups) == 0 {
+			return
+		}
+		if hps.HTTPSecurityPolicy == nil {
+			hps.HTTPSecurityPolicy = &avimodels.HttpsecurityPolicy{}
+		}
+		ipMatch := &avimodels.IPAddrMatch{MatchCriteria: &matchCriteria}
+		for _, cidr := range cidrs {
+			if prefix := buildIPAddrPrefix(cidr); prefix != nil {
+				ipMatch.Prefixes = append(ipMatch.Prefixes, prefix)
+			}
+		}
+		for _, group := range groups {
+			ipMatch.GroupRefs = append(ipMatch.GroupRefs, fmt.Sprintf("/api/ipaddrgroup/?name=%s", group))
+		}
+		ruleMatch := match
+		ruleMatch.ClientIP = ipMatch
+		enable := true
+		index := int32(len(hps.HTTPSecurityPolicy.Rules))
+		action, statusCode := "HTTP_SECURITY_ACTION_SEND_RESPONSE", "HTTP_LOCAL_RESPONSE_STATUS_CODE_403"
+		hps.HTTPSecurityPolicy.Rules = append(hps.HTTPSecurityPolicy.Rules, &avimodels.HttpsecurityRule{
+			Index:  &index,
+			Enable: &enable,
+			Name:   &ruleName,
+			Match:  &ruleMatch,
+			Action: &avimodels.HttpsecurityAction{Action: &action, StatusCode: &statusCode},
+		})
+	}
+	addDenyRule(name+"-deny", "IS_IN", accessControl.DenyCIDRs, accessControl.DenyIPGroups)
+	addDenyRule(name+"-allow", "IS_NOT_IN", accessControl.AllowCIDRs, accessControl.AllowIPGroups)
+}
+
+func buildIPAddrPrefix(cidr string) *avimodels.IPAddrPrefix {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+	addr, addrType := ipNet.IP.String(), "V4"
+	if ipNet.IP.To4() == nil {
+		addrType = "V6"
+	}
+	mask, _ := ipNet.Mask.Size()
+	prefixLen := int32(mask)
+	return &avimodels.IPAddrPrefix{IPAddr: &avimodels.IPAddr{Addr: &addr, Type: &addrType}, Mask: &prefixLen}
+}
+
+// buildRedirectAction returns the redirect action for a redirect set via HTTPRule, the protocol
+// defaults to HTTPS and the status code to 302.
+func buildRedirectAction(redirect akov1alpha1.HTTPRuleRedirect) *avimodels.HTTPRedirectAction {
+	protocol := redirect.Protocol
+	if protocol == "" {
+		protocol = utils.HTTPS
+	}
+	statusCode := lib.STATUS_REDIRECT
+	if redirect.StatusCode != 0 {
+		statusCode = fmt.Sprintf("HTTP_REDIRECT_STATUS_CODE_%d", redirect.StatusCode)
+	}
+	redirectAction := avimodels.HTTPRedirectAction{Protocol: &protocol, StatusCode: &statusCode}
+	if redirect.Port != 0 {
+		redirectAction.Port = &redirect.Port
+	}
+	if redirect.Host != "" {
+		redirectAction.Host = buildURIParam(buildURIStringToken(redirect.Host))
+	}
+	if redirect.Path != "" {
+		redirectAction.Path = buildURIParam(buildURIStringToken(strings.TrimPrefix(redirect.Path, "/")))
+	}
+	return &redirectAction
+}
+
+// buildRateLimiter returns the rate limiter for a rate limit set via HostRule or HTTPRule,
+// the requests are counted over a period of one second.
+func buildRateLimiter(rateLimit akov1alpha1.RateLimit) *avimodels.RateLimiter {
+	count, period, burst := rateLimit.RequestsPerSecond, int32(1), rateLimit.Burst
+	return &avimodels.RateLimiter{Count: &count, Period: &period, BurstSz: &burst}
+}
+
+// buildRateLimiterAction returns the action taken on the requests exceeding a rate limit,
+// the requests are rejected with a 429 response unless set to be dropped or redirected.
+func buildRateLimiterAction(action akov1alpha1.RateLimitAction) *avimodels.RateLimiterAction {
+	var actionType string
+	rlAction := &avimodels.RateLimiterAction{Type: &actionType}
+	switch action.Type {
+	case lib.RateLimitActionDrop:
+		actionType = "RL_ACTION_DROP_CONN"
+	case lib.RateLimitActionRedirect:
+		actionType = "RL_ACTION_REDIRECT"
+		rlAction.Redirect = buildRedirectAction(action.Redirect)
+	default:
+		actionType = "RL_ACTION_LOCAL_RSP"
+		statusCode := "HTTP_LOCAL_RESPONSE_STATUS_CODE_429"
+		rlAction.StatusCode = &statusCode
+	}
+	return rlAction
+}
+
+// buildHSTSRule adds the response rule setting the Strict-Transport-Security header on all the responses of the virtualhost
+func buildHSTSRule(hps *avimodels.HTTPPolicySet, name string, hsts akov1alpha1.HostRuleHSTS) {
+	maxAge := hsts.MaxAge
+	if maxAge == 0 {
+		maxAge = lib.HSTSDefaultMaxAge
+	}
+	value := fmt.Sprintf("max-age=%d", maxAge)
+	if hsts.IncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if hsts.Preload {
+		value += "; preload"
+	}
+	if hps.HTTPResponsePolicy == nil {
+		hps.HTTPResponsePolicy = &avimodels.HTTPResponsePolicy{}
+	}
+	enable := true
+	rspName := name + "-hsts"
+	rspIndex := int32(len(hps.HTTPResponsePolicy.Rules))
+	hdrActions := buildHdrActions(akov1alpha1.HTTPRuleHeaders{
+		Replace: []akov1alpha1.HTTPRuleHeader{{Name: "Strict-Transport-Security", Value: value}},
+	})
+	hps.HTTPResponsePolicy.Rules = append(hps.HTTPResponsePolicy.Rules, &avimodels.HTTPResponseRule{
+		Index:     &rspIndex,
+		Enable:    &enable,
+		Name:      &rspName,
+		HdrAction: hdrActions,
+	})
+}
+
+// buildHdrActions returns the header actions to add, replace and remove the headers set via HTTPRule
+func buildHdrActions(headers akov1alpha1.HTTPRuleHeaders) []*avimodels.HTTPHdrAction {
+	var hdrActions []*avimodels.HTTPHdrAction
+	addHdrAction := func(action, name, value string) {
+		hdrData := &avimodels.HTTPHdrData{Name: &name}
+		if value != "" {
+			hdrData.Value = &avimodels.HTTPHdrValue{Val: &value}
+		}
+		hdrActions = append(hdrActions, &avimodels.HTTPHdrAction{Action: &action, Hdr: hdrData})
+	}
+	for _, header := range headers.Add {
+		addHdrAction("HTTP_ADD_HDR", header.Name, header.Value)
+	}
+	for _, header := range headers.Replace {
+		addHdrAction("HTTP_REPLACE_HDR", header.Name, header.Value)
+	}
+	for _, name := range headers.Remove {
+		addHdrAction("HTTP_REMOVE_HDR", name, "")
+	}
+	return hdrActions
+}
+
+func buildURIStringToken(value string) *avimodels.URIParamToken {
+	tokenType := "URI_TOKEN_TYPE_STRING"
+	return &avimodels.URIParamToken{Type: &tokenType, StrValue: &value}
+}
+
+func buildURIParam(tokens ...*avimodels.URIParamToken) *avimodels.URIParam {
+	paramType := "URI_PARAM_TYPE_TOKENIZED"
+	return &avimodels.URIParam{Type: &paramType, Tokens: tokens}
+}
+
+// buildHostHdrMatch returns the host header match for a set of hosts, wildcard hosts
+// are matched on the domain suffix they cover.
+func buildHostHdrMatch(hosts []string) *avimodels.HostHdrMatch {
+	match_crit := "HDR_EQUALS"
+	var values []string
+	for _, host := range hosts {
+		if lib.IsWildcardHost(host) {
+			match_crit = "HDR_ENDS_WITH"
+			host = lib.GetWildcardHostSuffix(host)
+		}
+		values = append(values, host)
+	}
+	return &avimodels.HostHdrMatch{MatchCriteria: &match_crit, Value: values}
+}
+
 func (rest *RestOperations) AviHttpPolicyDel(uuid string, tenant string, key string) *utils.RestOp {
 	path := "/api/httppolicyset/" + uuid
 	rest_op := utils.RestOp{Path: path, Method: "DELETE",
@@ -212,7 +524,7 @@ func (rest *RestOperations) AviHTTPPolicyCacheAdd(rest_op *utils.RestOp, vsKey a
 		if resp["http_request_policy"] != nil {
 			rules, rulessOk := resp["http_request_policy"].(map[string]interface{})
 			if rulessOk {
-				rulesArr := rules["rules"].([]interface{})
+				rulesArr, _ := rules["rules"].([]interface{})
 				for _, ruleIntf := range rulesArr {
 					rulemap, _ := ruleIntf.(map[string]interface{})
 					if rulemap["switching_action"] != nil {
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/avinetworks/sdk/go/models"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviPersistenceProfileBuild(persistence_node *nodes.AviPersistenceProfileNode, cache_obj *avicache.AviPersistenceProfileCache, key string) *utils.RestOp {
+	name := persistence_node.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", persistence_node.Tenant)
+	persistenceType := persistence_node.PersistenceType
+
+	persistenceProfile := avimodels.ApplicationPersistenceProfile{
+		Name:            &name,
+		TenantRef:       &tenant,
+		PersistenceType: &persistenceType,
+	}
+	switch persistenceType {
+	case "PERSISTENCE_TYPE_HTTP_COOKIE":
+		persistenceProfile.HTTPCookiePersistenceProfile = &avimodels.HTTPCookiePersistenceProfile{}
+		if persistence_node.CookieName != "" {
+			cookieName := persistence_node.CookieName
+			persistenceProfile.HTTPCookiePersistenceProfile.CookieName = &cookieName
+		}
+		if persistence_node.Timeout != 0 {
+			timeout := persistence_node.Timeout
+			persistenceProfile.HTTPCookiePersistenceProfile.Timeout = &timeout
+		}
+	case "PERSISTENCE_TYPE_CUSTOM_HTTP_HEADER":
+		headerName := persistence_node.HeaderName
+		persistenceProfile.HdrPersistenceProfile = &avimodels.HdrPersistenceProfile{PrstHdrName: &headerName}
+	case "PERSISTENCE_TYPE_CLIENT_IP_ADDRESS":
+		persistenceProfile.IPPersistenceProfile = &avimodels.IPPersistenceProfile{}
+		if persistence_node.Timeout != 0 {
+			timeout := persistence_node.Timeout
+			persistenceProfile.IPPersistenceProfile.IPPersistentTimeout = &timeout
+		}
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "ApplicationPersistenceProfile", Data: persistenceProfile}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/applicationpersistenceprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: persistenceProfile,
+			Tenant: persistence_node.Tenant, Model: "ApplicationPersistenceProfile", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: persistence_node.Tenant, Model: "ApplicationPersistenceProfile", Version: utils.CtrlVersion}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: applicationpersistenceprofile Restop %v K8sAviPersistenceProfileMeta %v\n", key,
+		utils.Stringify(rest_op), *persistence_node))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviPersistenceProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/applicationpersistenceprofile/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "ApplicationPersistenceProfile", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: applicationpersistenceprofile DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+// AviPersistenceProfileCacheAdd adds the persistence profile to the cache, the profile is tracked
+// by the pool which refers to it
+func (rest *RestOperations) AviPersistenceProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for applicationpersistenceprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "applicationpersistenceprofile", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find applicationpersistenceprofile obj in resp %v", key, rest_op.Response)
+		return errors.New("applicationpersistenceprofile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var persistenceProfile avimodels.ApplicationPersistenceProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			persistenceProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile)
+		case avimodels.ApplicationPersistenceProfile:
+			persistenceProfile = rest_op.Obj.(avimodels.ApplicationPersistenceProfile)
+		}
+
+		persistence_cache_obj := avicache.AviPersistenceProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.AviPersistenceProfileChecksum(&persistenceProfile),
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.PersistenceProfileCache.AviCacheAdd(k, &persistence_cache_obj)
+		utils.AviLog.Info(spew.Sprintf("key: %s, msg: added applicationpersistenceprofile cache k %v val %v\n", key, k,
+			persistence_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviPersistenceProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	persistenceKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting applicationpersistenceprofile with key: %s", key, persistenceKey)
+	rest.cache.PersistenceProfileCache.AviCacheDelete(persistenceKey)
+	return nil
+}
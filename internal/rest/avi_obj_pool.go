@@ -24,6 +24,7 @@ import (
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -100,6 +101,48 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		pkiProfileName := "/api/pkiprofile?name=" + pool_meta.PkiProfile.Name
 		pool.PkiProfileRef = &pkiProfileName
 	}
+	if pool_meta.SSLKeyCert != nil {
+		sslKeyCertName := "/api/sslkeyandcertificate?name=" + pool_meta.SSLKeyCert.Name
+		pool.SslKeyAndCertificateRef = &sslKeyCertName
+	}
+	if pool_meta.PersistenceProfile != nil {
+		persistenceProfileRef := "/api/applicationpersistenceprofile?name=" + pool_meta.PersistenceProfile.Name
+		pool.ApplicationPersistenceProfileRef = &persistenceProfileRef
+	} else if pool_meta.ApplicationPersistenceProfileRef != "" {
+		pool.ApplicationPersistenceProfileRef = &pool_meta.ApplicationPersistenceProfileRef
+	}
+	if pool_meta.MaxConcurrentConnectionsPerServer != 0 {
+		pool.MaxConcurrentConnectionsPerServer = &pool_meta.MaxConcurrentConnectionsPerServer
+	}
+	if pool_meta.EnableHTTP2 {
+		pool.EnableHttp2 = &pool_meta.EnableHTTP2
+	}
+	if pool_meta.ServerTimeout != 0 {
+		pool.ServerTimeout = &pool_meta.ServerTimeout
+	}
+	if pool_meta.ConnIdleTimeout != 0 || pool_meta.ConnMaxReuse != 0 {
+		pool.ConnPoolProperties = &avimodels.ConnPoolProperties{}
+		if pool_meta.ConnIdleTimeout != 0 {
+			pool.ConnPoolProperties.UpstreamConnpoolConnIDLETmo = &pool_meta.ConnIdleTimeout
+		}
+		if pool_meta.ConnMaxReuse != 0 {
+			pool.ConnPoolProperties.UpstreamConnpoolConnMaxReuse = &pool_meta.ConnMaxReuse
+		}
+	}
+	if pool_meta.RetryCount != 0 {
+		// requests are retried on another server for 5xx responses
+		enabled := true
+		pool.ServerReselect = &avimodels.HttpserverReselect{
+			Enabled:    &enabled,
+			NumRetries: &pool_meta.RetryCount,
+			SvrRespCode: &avimodels.HTTPReselectRespCode{
+				RespCodeBlock: []string{"HTTP_RSP_5XX"},
+			},
+		}
+		if pool_meta.RetryTimeout != 0 {
+			pool.ServerReselect.RetryTimeout = &pool_meta.RetryTimeout
+		}
+	}
 
 	// there are defaults set by the Avi controller internally
 	if pool_meta.LbAlgorithm != "" {
@@ -127,8 +170,11 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 	}
 
 	// overwrite with healthmonitors provided by CRD
-	if len(pool_meta.HealthMonitors) > 0 {
-		pool.HealthMonitorRefs = pool_meta.HealthMonitors
+	if len(pool_meta.HealthMonitors) > 0 || len(pool_meta.HealthMonitorNodes) > 0 {
+		pool.HealthMonitorRefs = append(pool.HealthMonitorRefs, pool_meta.HealthMonitors...)
+		for _, hm := range pool_meta.HealthMonitorNodes {
+			pool.HealthMonitorRefs = append(pool.HealthMonitorRefs, "/api/healthmonitor?name="+hm.Name)
+		}
 	} else {
 		var hm string
 		if pool_meta.Protocol == utils.UDP {
@@ -233,14 +279,47 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 			}
 		}
 
+		// the client key/cert of the pool is taken from the request
+		var sslKey avicache.NamespaceName
+		var pool avimodels.Pool
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			pool = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.Pool)
+		case avimodels.Pool:
+			pool = rest_op.Obj.(avimodels.Pool)
+		}
+		if pool.SslKeyAndCertificateRef != nil {
+			sslKey = avicache.NamespaceName{Namespace: rest_op.Tenant, Name: strings.TrimPrefix(*pool.SslKeyAndCertificateRef, "/api/sslkeyandcertificate?name=")}
+		}
+		// the persistence profile is tracked by the pool only when it was created by AKO
+		var persistenceKey avicache.NamespaceName
+		if pool.ApplicationPersistenceProfileRef != nil {
+			k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: strings.TrimPrefix(*pool.ApplicationPersistenceProfileRef, "/api/applicationpersistenceprofile?name=")}
+			if _, found := rest.cache.PersistenceProfileCache.AviCacheGet(k); found {
+				persistenceKey = k
+			}
+		}
+
+		var hmRefs []string
+		if refs, ok := resp["health_monitor_refs"].([]interface{}); ok {
+			for _, ref := range refs {
+				if hmRef, ok := ref.(string); ok {
+					hmRefs = append(hmRefs, hmRef)
+				}
+			}
+		}
+
 		pool_cache_obj := avicache.AviPoolCache{
-			Name:                 name,
-			Tenant:               rest_op.Tenant,
-			Uuid:                 uuid,
-			CloudConfigCksum:     cksum,
-			ServiceMetadataObj:   svc_mdata_obj,
-			PkiProfileCollection: pkiKey,
-			LastModified:         lastModifiedStr,
+			Name:                         name,
+			Tenant:                       rest_op.Tenant,
+			Uuid:                         uuid,
+			CloudConfigCksum:             cksum,
+			ServiceMetadataObj:           svc_mdata_obj,
+			PkiProfileCollection:         pkiKey,
+			SSLKeyCertCollection:         sslKey,
+			HealthMonitorCollection:      rest.cache.GetHealthMonitorCollection(hmRefs),
+			PersistenceProfileCollection: persistenceKey,
+			LastModified:                 lastModifiedStr,
 		}
 		if lastModifiedStr == "" {
 			pool_cache_obj.InvalidData = true
@@ -258,6 +337,7 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 				if svc_mdata_obj.Namespace != "" {
 					status.UpdateRouteIngressStatus([]status.UpdateOptions{{
 						Vip:                vs_cache_obj.Vip,
+						V6Vip:              vs_cache_obj.V6Vip,
 						ServiceMetadata:    svc_mdata_obj,
 						Key:                key,
 						VirtualServiceUUID: vs_cache_obj.Uuid,
@@ -272,6 +352,7 @@ func (rest *RestOperations) AviPoolCacheAdd(rest_op *utils.RestOp, vsKey avicach
 		}
 		utils.AviLog.Info(spew.Sprintf("key: %s, msg: Added Pool cache k %v val %v\n", key, k,
 			pool_cache_obj))
+		status.UpdatePoolReadinessGates(name, uuid, key)
 	}
 
 	return nil
@@ -294,6 +375,7 @@ func (rest *RestOperations) AviPoolCacheDel(rest_op *utils.RestOp, vsKey avicach
 	rest.DeletePoolIngressStatus(poolKey, false, key)
 	// Now delete the cache.
 	rest.cache.PoolCache.AviCacheDelete(poolKey)
+	objects.SharedPoolPodLister().Delete(rest_op.ObjName)
 
 	return nil
 }
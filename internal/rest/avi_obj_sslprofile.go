@@ -0,0 +1,159 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/avinetworks/sdk/go/models"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviSSLProfileBuild(ssl_profile_node *nodes.AviSSLProfileNode, cache_obj *avicache.AviSSLProfileCache, key string) *utils.RestOp {
+	name := ssl_profile_node.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", ssl_profile_node.Tenant)
+	profileType := "SSL_PROFILE_TYPE_APPLICATION"
+
+	sslProfile := avimodels.SSLProfile{
+		Name:      &name,
+		TenantRef: &tenant,
+		Type:      &profileType,
+	}
+	for _, version := range ssl_profile_node.AcceptedVersions {
+		versionType := version
+		sslProfile.AcceptedVersions = append(sslProfile.AcceptedVersions, &avimodels.SSLVersion{Type: &versionType})
+	}
+	if ssl_profile_node.AcceptedCiphers != "" {
+		acceptedCiphers := ssl_profile_node.AcceptedCiphers
+		sslProfile.AcceptedCiphers = &acceptedCiphers
+	}
+	if ssl_profile_node.Ciphersuites != "" {
+		ciphersuites := ssl_profile_node.Ciphersuites
+		sslProfile.Ciphersuites = &ciphersuites
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "SSLProfile", Data: sslProfile}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/sslprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: sslProfile,
+			Tenant: ssl_profile_node.Tenant, Model: "SSLProfile", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: ssl_profile_node.Tenant, Model: "SSLProfile", Version: utils.CtrlVersion}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: sslprofile Restop %v K8sAviSSLProfileMeta %v\n", key,
+		utils.Stringify(rest_op), *ssl_profile_node))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviSSLProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/sslprofile/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "SSLProfile", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: sslprofile DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviSSLProfileCacheAdd(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for sslprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "sslprofile", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find sslprofile obj in resp %v", key, rest_op.Response)
+		return errors.New("sslprofile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var sslProfile avimodels.SSLProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			sslProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.SSLProfile)
+		case avimodels.SSLProfile:
+			sslProfile = rest_op.Obj.(avimodels.SSLProfile)
+		}
+
+		ssl_profile_cache_obj := avicache.AviSSLProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.AviSSLProfileChecksum(&sslProfile),
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.SSLProfileCache.AviCacheAdd(k, &ssl_profile_cache_obj)
+		// Update the VS object
+		if vsKey != (avicache.NamespaceName{}) {
+			vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+			if ok {
+				vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+				if found {
+					vs_cache_obj.AddToSSLProfileCollection(k)
+					utils.AviLog.Debugf("key: %s, msg: modified the VS cache object for sslprofile collection, the cache now is: %v", key, utils.Stringify(vs_cache_obj))
+				}
+			} else {
+				vs_cache_obj := rest.cache.VsCacheMeta.AviCacheAddVS(vsKey)
+				vs_cache_obj.AddToSSLProfileCollection(k)
+				utils.AviLog.Info(spew.Sprintf("key: %s, msg: added VS cache key during sslprofile update %v val %v\n", key, vsKey,
+					vs_cache_obj))
+			}
+		}
+		utils.AviLog.Info(spew.Sprintf("key: %s, msg: added sslprofile cache k %v val %v\n", key, k,
+			ssl_profile_cache_obj))
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviSSLProfileCacheDel(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	sslProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting sslprofile with key: %s", key, sslProfileKey)
+	rest.cache.SSLProfileCache.AviCacheDelete(sslProfileKey)
+	if vsKey != (avicache.NamespaceName{}) {
+		vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+		if ok {
+			vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+			if found {
+				vs_cache_obj.RemoveFromSSLProfileCollection(sslProfileKey)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avimodels "github.com/avinetworks/sdk/go/models"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviSSOPolicyBuild(sso_policy_node *nodes.AviSSOPolicyNode, cache_obj *avicache.AviSSOPolicyCache, key string) *utils.RestOp {
+	name := sso_policy_node.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", sso_policy_node.Tenant)
+	ssoType := sso_policy_node.Type
+	authProfileRef := fmt.Sprintf("/api/authprofile/?name=%s", sso_policy_node.AuthProfile)
+
+	ssoPolicy := avimodels.SSOPolicy{
+		Name:      &name,
+		TenantRef: &tenant,
+		Type:      &ssoType,
+		AuthenticationPolicy: &avimodels.AuthenticationPolicy{
+			DefaultAuthProfileRef: &authProfileRef,
+		},
+	}
+	if len(sso_policy_node.Paths) > 0 {
+		// the requests outside of the paths of the AuthRule skip the authentication
+		ruleName := name + "-skip-authentication"
+		enable, index := true, int32(1)
+		matchCriteria, actionType := "DOES_NOT_BEGIN_WITH", "SKIP_AUTHENTICATION"
+		ssoPolicy.AuthenticationPolicy.AuthnRules = []*avimodels.AuthenticationRule{{
+			Name:   &ruleName,
+			Enable: &enable,
+			Index:  &index,
+			Match: &avimodels.AuthenticationMatch{
+				Path: &avimodels.PathMatch{
+					MatchCriteria: &matchCriteria,
+					MatchStr:      sso_policy_node.Paths,
+				},
+			},
+			Action: &avimodels.AuthenticationAction{Type: &actionType},
+		}}
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "SSOPolicy", Data: ssoPolicy}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/ssopolicy/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: ssoPolicy,
+			Tenant: sso_policy_node.Tenant, Model: "SSOPolicy", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: sso_policy_node.Tenant, Model: "SSOPolicy", Version: utils.CtrlVersion}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: ssopolicy Restop %v K8sAviSSOPolicyMeta %v\n", key,
+		utils.Stringify(rest_op), *sso_policy_node))
+	return &rest_op
+}
+
+// AviAuthProfileBuild builds the auth profile of type JWT, which refers to the JWT server profile of the node
+func (rest *RestOperations) AviAuthProfileBuild(jwt_profile_node *nodes.AviJWTProfileNode, cache_obj *avicache.AviAuthProfileCache, key string) *utils.RestOp {
+	name := jwt_profile_node.AuthProfile
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", jwt_profile_node.Tenant)
+	profileType := "AUTH_PROFILE_JWT"
+	jwtProfileRef := fmt.Sprintf("/api/jwtserverprofile/?name=%s", jwt_profile_node.Name)
+
+	authProfile := avimodels.AuthProfile{
+		Name:          &name,
+		TenantRef:     &tenant,
+		Type:          &profileType,
+		JwtProfileRef: &jwtProfileRef,
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "AuthProfile", Data: authProfile}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/authprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: authProfile,
+			Tenant: jwt_profile_node.Tenant, Model: "AuthProfile", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: jwt_profile_node.Tenant, Model: "AuthProfile", Version: utils.CtrlVersion}
+	}
+
+	utils.AviLog.Debug(spew.Sprintf("key: %s, msg: authprofile Restop %v\n", key, utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviJWTProfileBuild(jwt_profile_node *nodes.AviJWTProfileNode, cache_obj *avicache.AviJWTProfileCache, key string) *utils.RestOp {
+	name := jwt_profile_node.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", jwt_profile_node.Tenant)
+	issuer, jwksKeys := jwt_profile_node.Issuer, jwt_profile_node.JwksKeys
+
+	jwtProfile := avimodels.JWTServerProfile{
+		Name:      &name,
+		TenantRef: &tenant,
+		Issuer:    &issuer,
+		JwksKeys:  &jwksKeys,
+	}
+
+	macro := utils.AviRestObjMacro{ModelName: "JWTServerProfile", Data: jwtProfile}
+
+	var path string
+	var rest_op utils.RestOp
+	if cache_obj != nil {
+		path = "/api/jwtserverprofile/" + cache_obj.Uuid
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPut, Obj: jwtProfile,
+			Tenant: jwt_profile_node.Tenant, Model: "JWTServerProfile", Version: utils.CtrlVersion}
+	} else {
+		path = "/api/macro"
+		rest_op = utils.RestOp{Path: path, Method: utils.RestPost, Obj: macro,
+			Tenant: jwt_profile_node.Tenant, Model: "JWTServerProfile", Version: utils.CtrlVersion}
+	}
+
+	// the JWKS are not logged
+	utils.AviLog.Debugf("key: %s, msg: jwtserverprofile Restop for %s, method %s", key, name, rest_op.Method)
+	return &rest_op
+}
+
+func (rest *RestOperations) AviSSOPolicyDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/ssopolicy/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "SSOPolicy", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: ssopolicy DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviAuthProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/authprofile/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "AuthProfile", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: authprofile DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviJWTProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/jwtserverprofile/" + uuid
+	rest_op := utils.RestOp{Path: path, Method: "DELETE",
+		Tenant: tenant, Model: "JWTServerProfile", Version: utils.CtrlVersion}
+	utils.AviLog.Info(spew.Sprintf("key: %s, msg: jwtserverprofile DELETE Restop %v \n", key,
+		utils.Stringify(rest_op)))
+	return &rest_op
+}
+
+func (rest *RestOperations) AviSSOPolicyCacheAdd(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for ssopolicy, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "ssopolicy", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find ssopolicy obj in resp %v", key, rest_op.Response)
+		return errors.New("ssopolicy not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var ssoPolicy avimodels.SSOPolicy
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			ssoPolicy = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.SSOPolicy)
+		case avimodels.SSOPolicy:
+			ssoPolicy = rest_op.Obj.(avimodels.SSOPolicy)
+		}
+
+		sso_policy_cache_obj := avicache.AviSSOPolicyCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.AviSSOPolicyChecksum(&ssoPolicy),
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.SSOPolicyCache.AviCacheAdd(k, &sso_policy_cache_obj)
+		// Update the VS object
+		if vsKey != (avicache.NamespaceName{}) {
+			vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+			if ok {
+				vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+				if found {
+					vs_cache_obj.AddToSSOPolicyCollection(k)
+					utils.AviLog.Debugf("key: %s, msg: modified the VS cache object for ssopolicy collection, the cache now is: %v", key, utils.Stringify(vs_cache_obj))
+				}
+			} else {
+				vs_cache_obj := rest.cache.VsCacheMeta.AviCacheAddVS(vsKey)
+				vs_cache_obj.AddToSSOPolicyCollection(k)
+				utils.AviLog.Info(spew.Sprintf("key: %s, msg: added VS cache key during ssopolicy update %v val %v\n", key, vsKey,
+					vs_cache_obj))
+			}
+		}
+		utils.AviLog.Info(spew.Sprintf("key: %s, msg: added ssopolicy cache k %v val %v\n", key, k,
+			sso_policy_cache_obj))
+	}
+
+	return nil
+}
+
+// AviAuthProfileCacheAdd adds the auth profile created by AKO to the cache, it is not tracked in the
+// VS cache as its name is derived from the name of the SSO policy of the VS
+func (rest *RestOperations) AviAuthProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for authprofile, err: %s, response: %s", key, rest_op.Err, rest_op.Response)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "authprofile", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find authprofile obj in resp %v", key, rest_op.Response)
+		return errors.New("authprofile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in response %v", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in response %v", key, resp)
+			continue
+		}
+
+		var authProfile avimodels.AuthProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			authProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.AuthProfile)
+		case avimodels.AuthProfile:
+			authProfile = rest_op.Obj.(avimodels.AuthProfile)
+		}
+
+		auth_profile_cache_obj := avicache.AviAuthProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.AviAuthProfileChecksum(&authProfile),
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.AuthProfileCache.AviCacheAdd(k, &auth_profile_cache_obj)
+		utils.AviLog.Info(spew.Sprintf("key: %s, msg: added authprofile cache k %v val %v\n", key, k,
+			auth_profile_cache_obj))
+	}
+
+	return nil
+}
+
+// AviJWTProfileCacheAdd adds the JWT server profile created by AKO to the cache, it is not tracked in the
+// VS cache as its name is derived from the name of the SSO policy of the VS
+func (rest *RestOperations) AviJWTProfileCacheAdd(rest_op *utils.RestOp, key string) error {
+	if (rest_op.Err != nil) || (rest_op.Response == nil) {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for jwtserverprofile, err: %s", key, rest_op.Err)
+		return errors.New("Errored rest_op")
+	}
+
+	resp_elems, ok := RestRespArrToObjByType(rest_op, "jwtserverprofile", key)
+	if ok != nil || resp_elems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find jwtserverprofile obj in resp", key)
+		return errors.New("jwtserverprofile not found")
+	}
+
+	for _, resp := range resp_elems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: name not present in jwtserverprofile response", key)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: uuid not present in jwtserverprofile response", key)
+			continue
+		}
+
+		var jwtProfile avimodels.JWTServerProfile
+		switch rest_op.Obj.(type) {
+		case utils.AviRestObjMacro:
+			jwtProfile = rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.JWTServerProfile)
+		case avimodels.JWTServerProfile:
+			jwtProfile = rest_op.Obj.(avimodels.JWTServerProfile)
+		}
+
+		jwt_profile_cache_obj := avicache.AviJWTProfileCache{
+			Name:             name,
+			Tenant:           rest_op.Tenant,
+			Uuid:             uuid,
+			CloudConfigCksum: avicache.AviJWTProfileChecksum(&jwtProfile),
+		}
+
+		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
+		rest.cache.JWTProfileCache.AviCacheAdd(k, &jwt_profile_cache_obj)
+		utils.AviLog.Infof("key: %s, msg: added jwtserverprofile cache k %v uuid %s", key, k, uuid)
+	}
+
+	return nil
+}
+
+func (rest *RestOperations) AviSSOPolicyCacheDel(rest_op *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	ssoPolicyKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting ssopolicy with key: %s", key, ssoPolicyKey)
+	rest.cache.SSOPolicyCache.AviCacheDelete(ssoPolicyKey)
+	if vsKey != (avicache.NamespaceName{}) {
+		vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+		if ok {
+			vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+			if found {
+				vs_cache_obj.RemoveFromSSOPolicyCollection(ssoPolicyKey)
+			}
+		}
+	}
+	return nil
+}
+
+func (rest *RestOperations) AviAuthProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	authProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting authprofile with key: %s", key, authProfileKey)
+	rest.cache.AuthProfileCache.AviCacheDelete(authProfileKey)
+	return nil
+}
+
+func (rest *RestOperations) AviJWTProfileCacheDel(rest_op *utils.RestOp, key string) error {
+	jwtProfileKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.ObjName}
+	utils.AviLog.Debugf("key: %s, msg: deleting jwtserverprofile with key: %s", key, jwtProfileKey)
+	rest.cache.JWTProfileCache.AviCacheDelete(jwtProfileKey)
+	return nil
+}
+
+// ssoPolicyProfileNames returns the names of the auth profile and the JWT server profile
+// which AKO creates along with an SSO policy of type JWT
+func ssoPolicyProfileNames(ssoPolicyName string) (string, string) {
+	vsName := strings.TrimSuffix(ssoPolicyName, lib.GetVsSSOPolicyName(""))
+	return lib.GetVsAuthProfileName(vsName), lib.GetVsJWTProfileName(vsName)
+}
@@ -106,9 +106,14 @@ func (rest *RestOperations) AviVsBuild(vs_meta *nodes.AviVsNode, rest_method uti
 		}
 		// TODO other fields like cloud_ref, mix of TCP & UDP protocols, etc.
 
+		enableHTTP2 := vs_meta.HasHTTP2Pools()
 		for i, pp := range vs_meta.PortProto {
 			port := pp.Port
 			svc := avimodels.Service{Port: &port, EnableSsl: &vs_meta.PortProto[i].EnableSSL}
+			if enableHTTP2 && pp.EnableSSL {
+				// the clients of the HTTP/2 pools, such as gRPC, negotiate HTTP/2 on the SSL ports
+				svc.EnableHttp2 = &enableHTTP2
+			}
 			vs.Services = append(vs.Services, &svc)
 		}
 
@@ -254,11 +259,22 @@ func (rest *RestOperations) AviVsSniBuild(vs_meta *nodes.AviVsNode, rest_method
 	}
 	sniChild.VsDatascripts = datascriptCollection
 
+	// from authrule CRD
+	if vs_meta.SSOPolicyNode != nil {
+		ssoPolicyRef := "/api/ssopolicy/?name=" + vs_meta.SSOPolicyNode.Name
+		sniChild.SsoPolicyRef = &ssoPolicyRef
+		sniChild.JwtConfig = vs_meta.SSOPolicyNode.JwtConfig
+		sniChild.SamlSpConfig = vs_meta.SSOPolicyNode.SamlSpConfig
+	}
+
 	// No need of HTTP rules for TLS passthrough.
 	if vs_meta.TLSType != utils.TLS_PASSTHROUGH {
 		// this overwrites the sslkeycert created from the Secret object, with the one mentioned in HostRule.TLS
 		if vs_meta.SSLKeyCertAviRef != "" {
 			sniChild.SslKeyAndCertificateRefs = append(sniChild.SslKeyAndCertificateRefs, vs_meta.SSLKeyCertAviRef)
+			if vs_meta.AltSSLKeyCertAviRef != "" {
+				sniChild.SslKeyAndCertificateRefs = append(sniChild.SslKeyAndCertificateRefs, vs_meta.AltSSLKeyCertAviRef)
+			}
 		} else {
 			for _, sslkeycert := range vs_meta.SSLKeyCertRefs {
 				certName := "/api/sslkeyandcertificate/?name=" + sslkeycert.Name
@@ -402,6 +418,9 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 									vs_cache_obj.Vip = vip
 									utils.AviLog.Info(spew.Sprintf("key: %s, msg: updated vsvip to the cache: %s", key, vip))
 								}
+								if len(vsvip_cache_obj.V6Vips) > 0 {
+									vs_cache_obj.V6Vip = vsvip_cache_obj.V6Vips[0]
+								}
 							}
 						}
 					}
@@ -412,6 +431,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 				if vhParentKey != nil {
 					vs_cache_obj.ParentVSRef = vhParentKey.(avicache.NamespaceName)
 				}
+				rest.cache.VsCacheMeta.AviCacheReindex(k)
 
 				vs_cache_obj.LastModified = lastModifiedStr
 				if lastModifiedStr == "" {
@@ -425,12 +445,14 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 					if lib.UseServicesAPI() {
 						status.UpdateSvcApiGatewayStatusAddress([]status.UpdateOptions{{
 							Vip:             vs_cache_obj.Vip,
+							V6Vip:           vs_cache_obj.V6Vip,
 							ServiceMetadata: svc_mdata_obj,
 							Key:             key,
 						}}, false)
 					} else {
 						status.UpdateGatewayStatusAddress([]status.UpdateOptions{{
 							Vip:             vs_cache_obj.Vip,
+							V6Vip:           vs_cache_obj.V6Vip,
 							ServiceMetadata: svc_mdata_obj,
 							Key:             key,
 						}}, false)
@@ -439,6 +461,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 					// This service needs an update of the status
 					status.UpdateL4LBStatus([]status.UpdateOptions{{
 						Vip:                vs_cache_obj.Vip,
+						V6Vip:              vs_cache_obj.V6Vip,
 						ServiceMetadata:    svc_mdata_obj,
 						Key:                key,
 						VirtualServiceUUID: vs_cache_obj.Uuid,
@@ -446,6 +469,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 				} else if (svc_mdata_obj.IngressName != "" || len(svc_mdata_obj.NamespaceIngressName) > 0) && svc_mdata_obj.Namespace != "" && parentVsObj != nil {
 					status.UpdateRouteIngressStatus([]status.UpdateOptions{{
 						Vip:                parentVsObj.Vip,
+						V6Vip:              parentVsObj.V6Vip,
 						ServiceMetadata:    svc_mdata_obj,
 						Key:                key,
 						VirtualServiceUUID: vs_cache_obj.Uuid,
@@ -464,6 +488,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 								if pool_cache_obj.ServiceMetadataObj.Namespace != "" {
 									status.UpdateRouteIngressStatus([]status.UpdateOptions{{
 										Vip:                vs_cache_obj.Vip,
+										V6Vip:              vs_cache_obj.V6Vip,
 										ServiceMetadata:    pool_cache_obj.ServiceMetadataObj,
 										Key:                key,
 										VirtualServiceUUID: vs_cache_obj.Uuid,
@@ -496,6 +521,9 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 								vs_cache_obj.Vip = vip
 								utils.AviLog.Info(spew.Sprintf("key: %s, msg: added vsvip to the cache: %s", key, vip))
 							}
+							if len(vsvip_cache_obj.V6Vips) > 0 {
+								vs_cache_obj.V6Vip = vsvip_cache_obj.V6Vips[0]
+							}
 						}
 					}
 				}
@@ -505,6 +533,7 @@ func (rest *RestOperations) AviVsCacheAdd(rest_op *utils.RestOp, key string) err
 				// This service needs an update of the status
 				status.UpdateL4LBStatus([]status.UpdateOptions{{
 					Vip:             vs_cache_obj.Vip,
+					V6Vip:           vs_cache_obj.V6Vip,
 					ServiceMetadata: svc_mdata_obj,
 					Key:             key,
 				}}, false)
@@ -65,6 +65,10 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 			return nil, err
 		}
 		for i, fqdn := range vsvip_meta.FQDNs {
+			// wildcard hosts are not registered with the Avi DNS
+			if lib.IsWildcardHost(fqdn) {
+				continue
+			}
 			dns_info := avimodels.DNSInfo{Fqdn: &vsvip_meta.FQDNs[i]}
 			foundFQDN := false
 			// Verify this FQDN is already in the list or not.
@@ -87,9 +91,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 		}
 
 		// This would throw an error for advl4 the error is propagated to the gateway status.
-		if vsvip_meta.IPAddress != "" {
-			vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
-		}
+		setVipAddresses(vip, vsvip_meta)
 
 		if networkName != "" {
 			if lib.IsPublicCloud() && lib.GetCloudType() != lib.CLOUD_GCP {
@@ -125,7 +127,9 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 		}
 
 		// setting IPAMNetworkSubnet.Subnet value in case subnetCIDR is provided
-		if lib.GetSubnetPrefix() == "" || subnetAddress == "" {
+		if !lib.IsIPv4Family(vsvip_meta.IPFamily) {
+			utils.AviLog.Debugf("key: %s, msg: IPv4 VIP not requested for vsvip %s, will not use subnetIP", key, name)
+		} else if lib.GetSubnetPrefix() == "" || subnetAddress == "" {
 			utils.AviLog.Warnf("Incomplete values provided for subnetIP, will not use IPAMNetworkSubnet in vsvip")
 		} else if lib.IsPublicCloud() && lib.GetCloudType() == lib.CLOUD_GCP {
 			// add the IPAMNetworkSubnet
@@ -144,11 +148,24 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 			}
 		}
 
-		// configuring static IP, from gateway.Addresses (advl4) and service.loadBalancerIP (l4)
-		if vsvip_meta.IPAddress != "" {
-			vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
+		// setting IPAMNetworkSubnet.Subnet6 value in case subnet6CIDR is provided for IPv6 VIPs
+		subnet6Address := lib.GetSubnet6IP()
+		if lib.IsIPv6Family(vsvip_meta.IPFamily) && subnet6Address != "" && lib.GetSubnet6Prefix() != "" &&
+			((lib.IsPublicCloud() && lib.GetCloudType() == lib.CLOUD_GCP) || !lib.GetAdvancedL4()) {
+			ip6Type := "V6"
+			subnet6Mask := lib.GetSubnet6PrefixInt()
+			if vip.IPAMNetworkSubnet == nil {
+				vip.IPAMNetworkSubnet = &avimodels.IPNetworkSubnet{}
+			}
+			vip.IPAMNetworkSubnet.Subnet6 = &avimodels.IPAddrPrefix{
+				IPAddr: &avimodels.IPAddr{Type: &ip6Type, Addr: &subnet6Address},
+				Mask:   &subnet6Mask,
+			}
 		}
 
+		// configuring static IP, from gateway.Addresses (advl4) and service.loadBalancerIP (l4)
+		setVipAddresses(&vip, vsvip_meta)
+
 		// selecting network with user input, in case user input is not provided AKO relies on
 		// usable network configuration in ipamdnsproviderprofile
 		if networkName != "" {
@@ -180,6 +197,10 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 		}
 
 		for i, fqdn := range vsvip_meta.FQDNs {
+			// wildcard hosts are not registered with the Avi DNS
+			if lib.IsWildcardHost(fqdn) {
+				continue
+			}
 			dns_info := avimodels.DNSInfo{Fqdn: &vsvip_meta.FQDNs[i]}
 			foundFQDN := false
 			// Verify this FQDN is already in the list or not.
@@ -230,6 +251,10 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 				return nil, err
 			}
 			for i, fqdn := range vsvip_meta.FQDNs {
+				// wildcard hosts are not registered with the Avi DNS
+				if lib.IsWildcardHost(fqdn) {
+					continue
+				}
 				dns_info := avimodels.DNSInfo{Fqdn: &vsvip_meta.FQDNs[i]}
 				foundFQDN := false
 				// Verify this FQDN is already in the list or not.
@@ -270,6 +295,23 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, cache_
 	return &rest_op, nil
 }
 
+// setVipAddresses configures the IP family of the vip along with the static IPv4 and IPv6 addresses.
+func setVipAddresses(vip *avimodels.Vip, vsvip_meta *nodes.AviVSVIPNode) {
+	ipFamily := vsvip_meta.IPFamily
+	if ipFamily != "" && ipFamily != lib.IPFamilyV4 {
+		autoAllocateIPType := lib.GetAutoAllocateIPType(ipFamily)
+		vip.AutoAllocateIPType = &autoAllocateIPType
+	}
+	if vsvip_meta.IPAddress != "" && lib.IsIPv4Family(ipFamily) {
+		ipType := "V4"
+		vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
+	}
+	if vsvip_meta.IPv6Address != "" && lib.IsIPv6Family(ipFamily) {
+		ip6Type := "V6"
+		vip.Ip6Address = &avimodels.IPAddr{Type: &ip6Type, Addr: &vsvip_meta.IPv6Address}
+	}
+}
+
 func (rest *RestOperations) AviVsVipGet(key, uuid, name string) (*avimodels.VsVip, error) {
 	if rest.aviRestPoolClient == nil {
 		utils.AviLog.Warnf("key: %s, msg: aviRestPoolClient during vsvip not initialized\n", key)
@@ -408,7 +450,7 @@ func (rest *RestOperations) AviVsVipCacheAdd(rest_op *utils.RestOp, vsKey avicac
 			}
 		}
 
-		var vsvipVips []string
+		var vsvipVips, vsvipV6Vips []string
 		var networkName string
 		if _, found := resp["vip"]; found {
 			if vips, ok := resp["vip"].([]interface{}); ok {
@@ -418,9 +460,21 @@ func (rest *RestOperations) AviVsVipCacheAdd(rest_op *utils.RestOp, vsKey avicac
 						utils.AviLog.Infof("key: %s, msg: invalid type for vip in vsvip: %s", key, name)
 						continue
 					}
+					if ipamNetworkSubnet, ipamOk := vip["ipam_network_subnet"].(map[string]interface{}); ipamOk {
+						if networkRef, netRefOk := ipamNetworkSubnet["network_ref"].(string); netRefOk {
+							if networRefName := strings.Split(networkRef, "#"); len(networRefName) == 2 {
+								networkName = strings.Split(networkRef, "#")[1]
+							}
+						}
+					}
+					if ip6_address, valid := vip["ip6_address"].(map[string]interface{}); valid {
+						if addr, valid := ip6_address["addr"].(string); valid {
+							vsvipV6Vips = append(vsvipV6Vips, addr)
+						}
+					}
 					ip_address, valid := vip["ip_address"].(map[string]interface{})
 					if !valid {
-						utils.AviLog.Infof("key: %s, msg: invalid type for ip_address in vsvip: %s", key, name)
+						utils.AviLog.Infof("key: %s, msg: ipv4 address not found for vip in vsvip: %s", key, name)
 						continue
 					}
 					addr, valid := ip_address["addr"].(string)
@@ -429,13 +483,6 @@ func (rest *RestOperations) AviVsVipCacheAdd(rest_op *utils.RestOp, vsKey avicac
 						continue
 					}
 					vsvipVips = append(vsvipVips, addr)
-					if ipamNetworkSubnet, ipamOk := vip["ipam_network_subnet"].(map[string]interface{}); ipamOk {
-						if networkRef, netRefOk := ipamNetworkSubnet["network_ref"].(string); netRefOk {
-							if networRefName := strings.Split(networkRef, "#"); len(networRefName) == 2 {
-								networkName = strings.Split(networkRef, "#")[1]
-							}
-						}
-					}
 				}
 			}
 		}
@@ -447,6 +494,7 @@ func (rest *RestOperations) AviVsVipCacheAdd(rest_op *utils.RestOp, vsKey avicac
 			LastModified: lastModifiedStr,
 			FQDNs:        vsvipFQDNs,
 			Vips:         vsvipVips,
+			V6Vips:       vsvipV6Vips,
 			NetworkName:  networkName,
 		}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
@@ -68,6 +69,7 @@ func (rest *RestOperations) DeQueueNodes(key string) {
 			close(lib.StaticRouteSyncChan)
 			lib.StaticRouteSyncChan = nil
 		}
+		objects.SharedFullSyncLister().DeleteModelChecksum(key)
 		if vs_cache_obj != nil {
 			utils.AviLog.Infof("key: %s, msg: nil model found, this is a vs deletion case", key)
 			rest.deleteVSOper(vsKey, vs_cache_obj, namespace, key, false, false)
@@ -90,21 +92,27 @@ func (rest *RestOperations) DeQueueNodes(key string) {
 		}
 		utils.AviLog.Debugf("key: %s, msg: VS create/update.", key)
 
+		// the checksum of the model is recorded once all its objects are pushed successfully,
+		// the incremental full syncs push the model again until then
+		objects.SharedFullSyncLister().DeleteModelChecksum(key)
+		var success bool
 		if strings.Contains(name, "-EVH-") && lib.IsEvhEnabled() {
 			if len(avimodel.GetAviEvhVS()) != 1 {
 				utils.AviLog.Warnf("key: %s, msg: virtualservice in the model is not equal to 1:%v", key, avimodel.GetAviEvhVS())
 				return
 			}
-			rest.RestOperationForEvh(name, namespace, avimodel, false, vs_cache_obj, key)
+			success = rest.RestOperationForEvh(name, namespace, avimodel, false, vs_cache_obj, key)
 
 		} else {
 			if len(avimodel.GetAviVS()) != 1 {
 				utils.AviLog.Warnf("key: %s, msg: virtualservice in the model is not equal to 1:%v", key, avimodel.GetAviVS())
 				return
 			}
-			rest.RestOperation(name, namespace, avimodel, vs_cache_obj, key)
+			success = rest.RestOperation(name, namespace, avimodel, vs_cache_obj, key)
+		}
+		if success {
+			objects.SharedFullSyncLister().SaveModelChecksum(key, avimodel.GetContentCheckSum())
 		}
-
 	}
 
 }
@@ -207,7 +215,7 @@ func (rest *RestOperations) CheckAndPublishForRetry(err error, publishKey, key s
 	return false
 }
 
-func (rest *RestOperations) RestOperation(vsName string, namespace string, avimodel *nodes.AviObjectGraph, vs_cache_obj *avicache.AviVsCache, key string) {
+func (rest *RestOperations) RestOperation(vsName string, namespace string, avimodel *nodes.AviObjectGraph, vs_cache_obj *avicache.AviVsCache, key string) bool {
 	var pools_to_delete []avicache.NamespaceName
 	var pgs_to_delete []avicache.NamespaceName
 	var ds_to_delete []avicache.NamespaceName
@@ -236,7 +244,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 		vsvip_to_delete, rest_ops, vsvipErr = rest.VSVipCU(aviVsNode.VSVIPRefs, vs_cache_obj, namespace, rest_ops, key)
 		if vsvipErr != nil {
 			if rest.CheckAndPublishForRetry(vsvipErr, publishKey, key, avimodel) {
-				return
+				return false
 			}
 		}
 		pools_to_delete, rest_ops = rest.PoolCU(aviVsNode.PoolRefs, vs_cache_obj, namespace, rest_ops, key)
@@ -255,14 +263,14 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 
 		}
 		if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
-			return
+			return false
 		}
 	} else {
 		var rest_ops []*utils.RestOp
 		_, rest_ops, vsvipErr = rest.VSVipCU(aviVsNode.VSVIPRefs, nil, namespace, rest_ops, key)
 		if vsvipErr != nil {
 			if rest.CheckAndPublishForRetry(vsvipErr, publishKey, key, avimodel) {
-				return
+				return false
 			}
 		}
 
@@ -278,7 +286,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 		utils.AviLog.Debugf("POST key: %s, vsKey: %s", key, vsKey)
 		utils.AviLog.Debugf("POST restops %s", utils.Stringify(rest_ops))
 		if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
-			return
+			return false
 		}
 	}
 	if vs_cache_obj != nil {
@@ -300,7 +308,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, key)
 	if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
-		return
+		return false
 	}
 
 	for _, sni_node := range aviVsNode.SniNodes {
@@ -314,7 +322,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 			_, rest_ops = rest.SNINodeCU(sni_node, nil, namespace, sni_to_delete, rest_ops, key)
 		}
 		if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
-			return
+			return false
 		}
 	}
 
@@ -325,7 +333,7 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 		for _, del_sni := range sni_to_delete {
 			rest.SNINodeDelete(del_sni, namespace, rest_ops, avimodel, key)
 			if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
-				return
+				return false
 			}
 		}
 	}
@@ -342,9 +350,10 @@ func (rest *RestOperations) RestOperation(vsName string, namespace string, avimo
 			rest_ops = rest.PassthroughChildCU(passChildNode, nil, namespace, rest_ops, key)
 		}
 		if success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false); !success {
-			return
+			return false
 		}
 	}
+	return true
 }
 
 func (rest *RestOperations) PassthroughChildCU(passChildNode *nodes.AviVsNode, vsCacheObj *avicache.AviVsCache, namespace string, restOps []*utils.RestOp, key string) []*utils.RestOp {
@@ -429,6 +438,9 @@ func (rest *RestOperations) deleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 		rest_ops = rest.L4PolicyDelete(vs_cache_obj.L4PolicyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.AppProfileDelete(vs_cache_obj.AppProfileCollection, namespace, rest_ops, key)
+		rest_ops = rest.SSLProfileDelete(vs_cache_obj.SSLProfileCollection, namespace, rest_ops, key)
+		rest_ops = rest.SSOPolicyDelete(vs_cache_obj.SSOPolicyCollection, namespace, rest_ops, key)
 		success := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, nil, key, false)
 		if success {
 			vsKeysPending := rest.cache.VsCacheMeta.AviGetAllKeys()
@@ -468,6 +480,9 @@ func (rest *RestOperations) deleteSniVs(vsKey avicache.NamespaceName, vs_cache_o
 		rest_ops = rest.HTTPPolicyDelete(vs_cache_obj.HTTPKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.AppProfileDelete(vs_cache_obj.AppProfileCollection, namespace, rest_ops, key)
+		rest_ops = rest.SSLProfileDelete(vs_cache_obj.SSLProfileCollection, namespace, rest_ops, key)
+		rest_ops = rest.SSOPolicyDelete(vs_cache_obj.SSOPolicyCollection, namespace, rest_ops, key)
 		return rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false)
 	}
 	return true
@@ -598,6 +613,20 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 		utils.AviLog.Infof("key: %s, msg: creating/updating %s cache, method: %s", key, rest_op.Model, rest_op.Method)
 		if rest_op.Model == "PKIprofile" {
 			rest.AviPkiProfileAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheAdd(rest_op, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheAdd(rest_op, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviAppProfileCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "SSLProfile" {
+			rest.AviSSLProfileCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "SSOPolicy" {
+			rest.AviSSOPolicyCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "AuthProfile" {
+			rest.AviAuthProfileCacheAdd(rest_op, key)
+		} else if rest_op.Model == "JWTServerProfile" {
+			rest.AviJWTProfileCacheAdd(rest_op, key)
 		} else if rest_op.Model == "Pool" {
 			rest.AviPoolCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VirtualService" {
@@ -622,6 +651,20 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 		utils.AviLog.Infof("key: %s, msg: deleting %s cache", key, rest_op.Model)
 		if rest_op.Model == "PKIprofile" {
 			rest.AviPkiProfileCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "HealthMonitor" {
+			rest.AviHealthMonitorCacheDel(rest_op, key)
+		} else if rest_op.Model == "ApplicationPersistenceProfile" {
+			rest.AviPersistenceProfileCacheDel(rest_op, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviAppProfileCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "SSLProfile" {
+			rest.AviSSLProfileCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "SSOPolicy" {
+			rest.AviSSOPolicyCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "AuthProfile" {
+			rest.AviAuthProfileCacheDel(rest_op, key)
+		} else if rest_op.Model == "JWTServerProfile" {
+			rest.AviJWTProfileCacheDel(rest_op, key)
 		} else if rest_op.Model == "Pool" {
 			rest.AviPoolCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "VirtualService" {
@@ -687,6 +730,31 @@ func (rest *RestOperations) AviRestOperateWrapper(aviClient *clients.AviClient,
 	}
 }
 
+// removeVSReferences drops an object which is gone from the controller from the collections of all the
+// virtual services still referencing it, not only the one whose model is being processed.
+func (rest *RestOperations) removeVSReferences(refType avicache.VSRefType, objKey avicache.NamespaceName, key string) {
+	for _, vsKey := range rest.cache.VsCacheMeta.AviCacheGetVSKeysReferencing(refType, objKey) {
+		vsCache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+		if !ok {
+			continue
+		}
+		vsCacheObj, found := vsCache.(*avicache.AviVsCache)
+		if !found {
+			continue
+		}
+		// the VS may belong to a model processed by another worker
+		vsCacheObj.VSCacheLock.Lock()
+		switch refType {
+		case avicache.VSRefPool:
+			vsCacheObj.RemoveFromPoolKeyCollection(objKey)
+		case avicache.VSRefSSLKeyCert:
+			vsCacheObj.RemoveFromSSLKeyCertCollection(objKey)
+		}
+		vsCacheObj.VSCacheLock.Unlock()
+		utils.AviLog.Infof("key: %s, msg: removed %s %s from the cache of VS %s", key, refType, objKey, vsKey)
+	}
+}
+
 func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObjKey avicache.NamespaceName, rest_op *utils.RestOp, aviError session.AviError, c *clients.AviClient, avimodel *nodes.AviObjectGraph, key string, isEvh bool) (bool, bool) {
 	var fastRetry bool
 	statuscode := aviError.HttpStatusCode
@@ -713,6 +781,7 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 				}
 				rest_op.ObjName = poolObjName
 				rest.AviPoolCacheDel(rest_op, aviObjKey, key)
+				rest.removeVSReferences(avicache.VSRefPool, avicache.NamespaceName{Namespace: rest_op.Tenant, Name: poolObjName}, key)
 			case "PoolGroup":
 				var pgObjName string
 				switch rest_op.Obj.(type) {
@@ -812,6 +881,7 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 				}
 				rest_op.ObjName = SSLKeyAndCertificate
 				rest.AviSSLCacheDel(rest_op, aviObjKey, key)
+				rest.removeVSReferences(avicache.VSRefSSLKeyCert, avicache.NamespaceName{Namespace: rest_op.Tenant, Name: SSLKeyAndCertificate}, key)
 			case "PKIprofile":
 				var PKIprofile string
 				switch rest_op.Obj.(type) {
@@ -822,6 +892,76 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 				}
 				rest_op.ObjName = PKIprofile
 				rest.AviPkiProfileCacheDel(rest_op, aviObjKey, key)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				rest_op.ObjName = HealthMonitor
+				rest.AviHealthMonitorCacheDel(rest_op, key)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				rest_op.ObjName = ApplicationPersistenceProfile
+				rest.AviPersistenceProfileCacheDel(rest_op, key)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				rest_op.ObjName = ApplicationProfile
+				rest.AviAppProfileCacheDel(rest_op, aviObjKey, key)
+			case "SSLProfile":
+				var SSLProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					SSLProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.SSLProfile).Name
+				case avimodels.SSLProfile:
+					SSLProfile = *rest_op.Obj.(avimodels.SSLProfile).Name
+				}
+				rest_op.ObjName = SSLProfile
+				rest.AviSSLProfileCacheDel(rest_op, aviObjKey, key)
+			case "SSOPolicy":
+				var SSOPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					SSOPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.SSOPolicy).Name
+				case avimodels.SSOPolicy:
+					SSOPolicy = *rest_op.Obj.(avimodels.SSOPolicy).Name
+				}
+				rest_op.ObjName = SSOPolicy
+				rest.AviSSOPolicyCacheDel(rest_op, aviObjKey, key)
+			case "AuthProfile":
+				var AuthProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					AuthProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.AuthProfile).Name
+				case avimodels.AuthProfile:
+					AuthProfile = *rest_op.Obj.(avimodels.AuthProfile).Name
+				}
+				rest_op.ObjName = AuthProfile
+				rest.AviAuthProfileCacheDel(rest_op, key)
+			case "JWTServerProfile":
+				var JWTServerProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					JWTServerProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.JWTServerProfile).Name
+				case avimodels.JWTServerProfile:
+					JWTServerProfile = *rest_op.Obj.(avimodels.JWTServerProfile).Name
+				}
+				rest_op.ObjName = JWTServerProfile
+				rest.AviJWTProfileCacheDel(rest_op, key)
 			case "VirtualService":
 				rest.AviVsCacheDel(rest_op, aviObjKey, key)
 			case "VSDataScriptSet":
@@ -905,6 +1045,69 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					PKIprofile = *rest_op.Obj.(avimodels.PKIprofile).Name
 				}
 				aviObjCache.AviPopulateOnePKICache(c, utils.CloudName, PKIprofile)
+			case "HealthMonitor":
+				var HealthMonitor string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					HealthMonitor = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.HealthMonitor).Name
+				case avimodels.HealthMonitor:
+					HealthMonitor = *rest_op.Obj.(avimodels.HealthMonitor).Name
+				}
+				aviObjCache.AviPopulateOneHealthMonitorCache(c, utils.CloudName, HealthMonitor)
+			case "ApplicationPersistenceProfile":
+				var ApplicationPersistenceProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationPersistenceProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationPersistenceProfile).Name
+				case avimodels.ApplicationPersistenceProfile:
+					ApplicationPersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
+				}
+				aviObjCache.AviPopulateOnePersistenceProfileCache(c, utils.CloudName, ApplicationPersistenceProfile)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				aviObjCache.AviPopulateOneAppProfileCache(c, utils.CloudName, ApplicationProfile)
+			case "SSLProfile":
+				var SSLProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					SSLProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.SSLProfile).Name
+				case avimodels.SSLProfile:
+					SSLProfile = *rest_op.Obj.(avimodels.SSLProfile).Name
+				}
+				aviObjCache.AviPopulateOneSSLProfileCache(c, utils.CloudName, SSLProfile)
+			case "SSOPolicy":
+				var SSOPolicy string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					SSOPolicy = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.SSOPolicy).Name
+				case avimodels.SSOPolicy:
+					SSOPolicy = *rest_op.Obj.(avimodels.SSOPolicy).Name
+				}
+				aviObjCache.AviPopulateOneSSOPolicyCache(c, utils.CloudName, SSOPolicy)
+			case "AuthProfile":
+				var AuthProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					AuthProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.AuthProfile).Name
+				case avimodels.AuthProfile:
+					AuthProfile = *rest_op.Obj.(avimodels.AuthProfile).Name
+				}
+				aviObjCache.AviPopulateOneAuthProfileCache(c, utils.CloudName, AuthProfile)
+			case "JWTServerProfile":
+				var JWTServerProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					JWTServerProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.JWTServerProfile).Name
+				case avimodels.JWTServerProfile:
+					JWTServerProfile = *rest_op.Obj.(avimodels.JWTServerProfile).Name
+				}
+				aviObjCache.AviPopulateOneJWTProfileCache(c, utils.CloudName, JWTServerProfile)
 			case "VirtualService":
 				aviObjCache.AviObjOneVSCachePopulate(c, utils.CloudName, aviObjKey.Name)
 				vsObjMeta, ok := rest.cache.VsCacheMeta.AviCacheGet(aviObjKey)
@@ -968,6 +1171,13 @@ func (rest *RestOperations) PoolDelete(pools_to_delete []avicache.NamespaceName,
 			if pkiProfile.Name != "" {
 				rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{pkiProfile}, namespace, rest_ops, key)
 			}
+			if pool_cache_obj.SSLKeyCertCollection.Name != "" {
+				rest_ops = rest.SSLKeyCertDelete([]avicache.NamespaceName{pool_cache_obj.SSLKeyCertCollection}, namespace, rest_ops, key)
+			}
+			rest_ops = rest.HealthMonitorDelete(pool_cache_obj.HealthMonitorCollection, namespace, rest_ops, key)
+			if pool_cache_obj.PersistenceProfileCollection.Name != "" {
+				rest_ops = rest.PersistenceProfileDelete([]avicache.NamespaceName{pool_cache_obj.PersistenceProfileCollection}, namespace, rest_ops, key)
+			}
 		}
 	}
 	return rest_ops
@@ -1036,18 +1246,24 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 				pool_key := avicache.NamespaceName{Namespace: namespace, Name: pool.Name}
 				found := utils.HasElem(cache_pool_nodes, pool_key)
 				utils.AviLog.Debugf("key: %s, msg: processing pool key: %v", key, pool_key)
+				var pool_hm_delete, pool_sslkeycert_delete, pool_persistence_delete []avicache.NamespaceName
 				if found {
 					cache_pool_nodes = Remove(cache_pool_nodes, pool_key)
 					utils.AviLog.Debugf("key: %s, key: the cache pool nodes are: %v", key, cache_pool_nodes)
 					pool_cache, ok := rest.cache.PoolCache.AviCacheGet(pool_key)
 					if ok {
 						pool_cache_obj, _ := pool_cache.(*avicache.AviPoolCache)
-						pool_pkiprofile_delete, rest_ops = rest.PkiProfileCU(pool.PkiProfile, pool_cache_obj, namespace, rest_ops, key)
+						pool_pkiprofile_delete, rest_ops = rest.PkiProfileCU(pool.PkiProfile, &pool_cache_obj.PkiProfileCollection, namespace, rest_ops, key)
+						pool_sslkeycert_delete, rest_ops = rest.PoolSSLKeyCertCU(pool.Name, pool.SSLKeyCert, pool_cache_obj.SSLKeyCertCollection, namespace, rest_ops, key)
+						pool_hm_delete, rest_ops = rest.HealthMonitorCU(pool.HealthMonitorNodes, pool_cache_obj, namespace, rest_ops, key)
+						pool_persistence_delete, rest_ops = rest.PersistenceProfileCU(pool.PersistenceProfile, pool_cache_obj.PersistenceProfileCollection, namespace, rest_ops, key)
 
 						// Cache found. Let's compare the checksums
 						utils.AviLog.Debugf("key: %s, msg: poolcache: %v", key, pool_cache_obj)
 						if pool_cache_obj.CloudConfigCksum == strconv.Itoa(int(pool.GetCheckSum())) {
 							utils.AviLog.Debugf("key: %s, msg: the checksums are same for pool %s, not doing anything", key, pool.Name)
+							// Pods which came back with an unchanged server list still need the readiness gate.
+							status.UpdatePoolReadinessGates(pool.Name, pool_cache_obj.Uuid, key)
 						} else {
 							utils.AviLog.Debugf("key: %s, msg: the checksums are different for pool %s, operation: PUT", key, pool.Name)
 							// The checksums are different, so it should be a PUT call.
@@ -1058,6 +1274,9 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 				} else {
 					utils.AviLog.Debugf("key: %s, msg: pool %s not found in cache, operation: POST", key, pool.Name)
 					_, rest_ops = rest.PkiProfileCU(pool.PkiProfile, nil, namespace, rest_ops, key)
+					_, rest_ops = rest.PoolSSLKeyCertCU(pool.Name, pool.SSLKeyCert, avicache.NamespaceName{}, namespace, rest_ops, key)
+					_, rest_ops = rest.HealthMonitorCU(pool.HealthMonitorNodes, nil, namespace, rest_ops, key)
+					_, rest_ops = rest.PersistenceProfileCU(pool.PersistenceProfile, avicache.NamespaceName{}, namespace, rest_ops, key)
 					// Not found - it should be a POST call.
 					restOp := rest.AviPoolBuild(pool, nil, key)
 					rest_ops = append(rest_ops, restOp)
@@ -1065,12 +1284,19 @@ func (rest *RestOperations) PoolCU(pool_nodes []*nodes.AviPoolNode, vs_cache_obj
 				if len(pool_pkiprofile_delete) > 0 {
 					rest_ops = rest.PkiProfileDelete(pool_pkiprofile_delete, namespace, rest_ops, key)
 				}
+				rest_ops = rest.SSLKeyCertDelete(pool_sslkeycert_delete, namespace, rest_ops, key)
+				// healthmonitors removed from the pool are deleted after the pool update
+				rest_ops = rest.HealthMonitorDelete(pool_hm_delete, namespace, rest_ops, key)
+				rest_ops = rest.PersistenceProfileDelete(pool_persistence_delete, namespace, rest_ops, key)
 			}
 		}
 	} else {
 		// Everything is a POST call
 		for _, pool := range pool_nodes {
 			_, rest_ops = rest.PkiProfileCU(pool.PkiProfile, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.PoolSSLKeyCertCU(pool.Name, pool.SSLKeyCert, avicache.NamespaceName{}, namespace, rest_ops, key)
+			_, rest_ops = rest.HealthMonitorCU(pool.HealthMonitorNodes, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.PersistenceProfileCU(pool.PersistenceProfile, avicache.NamespaceName{}, namespace, rest_ops, key)
 
 			utils.AviLog.Debugf("key: %s, msg: pool cache does not exist %s, operation: POST", key, pool.Name)
 			restOp := rest.AviPoolBuild(pool, nil, key)
@@ -1125,6 +1351,9 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 	var sni_pgs_to_delete []avicache.NamespaceName
 	var http_policies_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
+	var app_profiles_to_delete []avicache.NamespaceName
+	var ssl_profiles_to_delete []avicache.NamespaceName
+	var sso_policies_to_delete []avicache.NamespaceName
 	if vs_cache_obj != nil {
 		sni_key := avicache.NamespaceName{Namespace: namespace, Name: sni_node.Name}
 		// Search the VS cache and obtain the UUID of this VS. Then see if this UUID is part of the SNIChildCollection or not.
@@ -1143,6 +1372,9 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 				sni_pools_to_delete, rest_ops = rest.PoolCU(sni_node.PoolRefs, sni_cache_obj, namespace, rest_ops, key)
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				app_profiles_to_delete, rest_ops = rest.AppProfileCU(sni_node.AppProfileNode, sni_cache_obj, namespace, rest_ops, key)
+				ssl_profiles_to_delete, rest_ops = rest.SSLProfileCU(sni_node.SSLProfileNode, sni_cache_obj, namespace, rest_ops, key)
+				sso_policies_to_delete, rest_ops = rest.SSOPolicyCU(sni_node.SSOPolicyNode, sni_cache_obj, namespace, rest_ops, key)
 
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
@@ -1159,6 +1391,9 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 			_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.AppProfileCU(sni_node.AppProfileNode, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.SSLProfileCU(sni_node.SSLProfileNode, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.SSOPolicyCU(sni_node.SSOPolicyNode, nil, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuild(sni_node, utils.RestPost, nil, key)
@@ -1168,6 +1403,9 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 		rest_ops = rest.HTTPPolicyDelete(http_policies_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.AppProfileDelete(app_profiles_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.SSLProfileDelete(ssl_profiles_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.SSOPolicyDelete(sso_policies_to_delete, namespace, rest_ops, key)
 		utils.AviLog.Debugf("key: %s, msg: the SNI VSes to be deleted are: %s", key, cache_sni_nodes)
 	} else {
 		utils.AviLog.Debugf("key: %s, msg: sni child %s not found in cache and SNI parent also does not exist in cache", key, sni_node.Name)
@@ -1176,6 +1414,9 @@ func (rest *RestOperations) SNINodeCU(sni_node *nodes.AviVsNode, vs_cache_obj *a
 		_, rest_ops = rest.PoolCU(sni_node.PoolRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.AppProfileCU(sni_node.AppProfileNode, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.SSLProfileCU(sni_node.SSLProfileNode, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.SSOPolicyCU(sni_node.SSOPolicyNode, nil, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuild(sni_node, utils.RestPost, nil, key)
@@ -1444,6 +1685,25 @@ func (rest *RestOperations) SSLKeyCertCU(sslkeyNodes []*nodes.AviTLSKeyCertNode,
 	return rest.KeyCertCU(sslkeyNodes, certKeys, namespace, rest_ops, key)
 }
 
+// PoolSSLKeyCertCU computes the rest ops for the client key/cert of a pool, and returns the cached key/cert which
+// is no longer used by the pool. The key/cert is tracked by the pool and not the VS.
+func (rest *RestOperations) PoolSSLKeyCertCU(poolName string, sslkeyNode *nodes.AviTLSKeyCertNode, cacheKey avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var sslkeyNodes []*nodes.AviTLSKeyCertNode
+	if sslkeyNode != nil {
+		sslkeyNodes = append(sslkeyNodes, sslkeyNode)
+	}
+	var certKeys []avicache.NamespaceName
+	if cacheKey.Name != "" {
+		certKeys = append(certKeys, cacheKey)
+	}
+	numOps := len(rest_ops)
+	sslKeyCertDelete, rest_ops := rest.KeyCertCU(sslkeyNodes, certKeys, namespace, rest_ops, key)
+	for _, restOp := range rest_ops[numOps:] {
+		restOp.PoolName = poolName
+	}
+	return sslKeyCertDelete, rest_ops
+}
+
 func (rest *RestOperations) L4PolicyDelete(l4_to_delete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
 	utils.AviLog.Infof("key: %s, msg: about to delete l4 policies %s", key, utils.Stringify(l4_to_delete))
 	for _, del_l4 := range l4_to_delete {
@@ -1510,6 +1770,7 @@ func (rest *RestOperations) SSLKeyCertDelete(ssl_to_delete []avicache.NamespaceN
 			ssl_cache_obj, _ := ssl_cache.(*avicache.AviSSLCache)
 			restOp := rest.AviSSLKeyCertDel(ssl_cache_obj.Uuid, namespace)
 			restOp.ObjName = del_ssl.Name
+			lib.SharedCertExpiryStore().Delete(del_ssl.Name)
 			//Objects with a CA ref should be deleted first
 			if !ssl_cache_obj.HasCARef {
 				noCARefRestOps = append(noCARefRestOps, restOp)
@@ -1522,12 +1783,12 @@ func (rest *RestOperations) SSLKeyCertDelete(ssl_to_delete []avicache.NamespaceN
 	return rest_ops
 }
 
-func (rest *RestOperations) PkiProfileCU(pki_node *nodes.AviPkiProfileNode, pool_cache_obj *avicache.AviPoolCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+func (rest *RestOperations) PkiProfileCU(pki_node *nodes.AviPkiProfileNode, cache_pki_key *avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
 	// Default is POST
 	var cache_pki_nodes []avicache.NamespaceName
-	if pool_cache_obj != nil {
+	if cache_pki_key != nil {
 		cache_pki_nodes = make([]avicache.NamespaceName, 1)
-		copy(cache_pki_nodes, []avicache.NamespaceName{pool_cache_obj.PkiProfileCollection})
+		copy(cache_pki_nodes, []avicache.NamespaceName{*cache_pki_key})
 
 		if pki_node != nil {
 			pki_key := avicache.NamespaceName{Namespace: namespace, Name: pki_node.Name}
@@ -1564,13 +1825,214 @@ func (rest *RestOperations) PkiProfileCU(pki_node *nodes.AviPkiProfileNode, pool
 
 func (rest *RestOperations) PkiProfileDelete(pkiProfileDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
 	utils.AviLog.Debugf("key: %s, msg: about to delete pki profile %s", key, utils.Stringify(pkiProfileDelete))
-	for _, delPki := range pkiProfileDelete {
-		pkiProfile := avicache.NamespaceName{Namespace: namespace, Name: delPki.Name}
-		pkiCache, ok := rest.cache.PKIProfileCache.AviCacheGet(pkiProfile)
+	pkiProfileDel := func(uuid, tenant, key string) *utils.RestOp {
+		return rest.AviPkiProfileDel(uuid, tenant)
+	}
+	return rest.profileDelete(pkiProfileDelete, rest.cache.PKIProfileCache, pkiProfileDel, namespace, rest_ops, key)
+}
+
+func (rest *RestOperations) AppProfileCU(app_profile_node *nodes.AviAppProfileNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_app_profiles []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cache_app_profiles = make([]avicache.NamespaceName, len(vs_cache_obj.AppProfileCollection))
+		copy(cache_app_profiles, vs_cache_obj.AppProfileCollection)
+	}
+	if app_profile_node == nil {
+		return cache_app_profiles, rest_ops
+	}
+
+	app_profile_key := avicache.NamespaceName{Namespace: namespace, Name: app_profile_node.Name}
+	cache_app_profiles = Remove(cache_app_profiles, app_profile_key)
+	app_profile_cache, ok := rest.cache.AppProfileCache.AviCacheGet(app_profile_key)
+
+	// the pki profile has to be in place before the application profile which refers to it
+	if app_profile_node.PkiProfile != nil {
+		var cache_pki_key *avicache.NamespaceName
 		if ok {
-			pkiCacheObj, _ := pkiCache.(*avicache.AviPkiProfileCache)
-			restOp := rest.AviPkiProfileDel(pkiCacheObj.Uuid, namespace)
-			restOp.ObjName = delPki.Name
+			if app_profile_cache_obj, _ := app_profile_cache.(*avicache.AviAppProfileCache); app_profile_cache_obj.PkiProfileCollection.Name != "" {
+				cache_pki_key = &app_profile_cache_obj.PkiProfileCollection
+			}
+		}
+		_, rest_ops = rest.PkiProfileCU(app_profile_node.PkiProfile, cache_pki_key, namespace, rest_ops, key)
+	}
+
+	rest_ops = rest.profileCU(rest.cache.AppProfileCache, "applicationprofile", app_profile_node.Name, app_profile_node.GetCheckSum(), func(cacheObj interface{}) *utils.RestOp {
+		app_profile_cache_obj, _ := cacheObj.(*avicache.AviAppProfileCache)
+		return rest.AviAppProfileBuild(app_profile_node, app_profile_cache_obj, key)
+	}, namespace, rest_ops, key)
+
+	return cache_app_profiles, rest_ops
+}
+
+func (rest *RestOperations) SSLProfileCU(ssl_profile_node *nodes.AviSSLProfileNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_ssl_profiles []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cache_ssl_profiles = make([]avicache.NamespaceName, len(vs_cache_obj.SSLProfileCollection))
+		copy(cache_ssl_profiles, vs_cache_obj.SSLProfileCollection)
+	}
+	if ssl_profile_node == nil {
+		return cache_ssl_profiles, rest_ops
+	}
+
+	ssl_profile_key := avicache.NamespaceName{Namespace: namespace, Name: ssl_profile_node.Name}
+	cache_ssl_profiles = Remove(cache_ssl_profiles, ssl_profile_key)
+	rest_ops = rest.profileCU(rest.cache.SSLProfileCache, "sslprofile", ssl_profile_node.Name, ssl_profile_node.GetCheckSum(), func(cacheObj interface{}) *utils.RestOp {
+		ssl_profile_cache_obj, _ := cacheObj.(*avicache.AviSSLProfileCache)
+		return rest.AviSSLProfileBuild(ssl_profile_node, ssl_profile_cache_obj, key)
+	}, namespace, rest_ops, key)
+
+	return cache_ssl_profiles, rest_ops
+}
+
+func (rest *RestOperations) SSLProfileDelete(sslProfileDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	return rest.profileDelete(sslProfileDelete, rest.cache.SSLProfileCache, rest.AviSSLProfileDel, namespace, rest_ops, key)
+}
+
+// SSOPolicyCU creates or updates the SSO policy of a virtualservice, along with the auth profile and the
+// JWT server profile it refers to for JWT validation, these are created before the SSO policy
+func (rest *RestOperations) SSOPolicyCU(sso_policy_node *nodes.AviSSOPolicyNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_sso_policies []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cache_sso_policies = make([]avicache.NamespaceName, len(vs_cache_obj.SSOPolicyCollection))
+		copy(cache_sso_policies, vs_cache_obj.SSOPolicyCollection)
+	}
+	if sso_policy_node == nil {
+		return cache_sso_policies, rest_ops
+	}
+
+	if jwt_profile_node := sso_policy_node.JWTProfile; jwt_profile_node != nil {
+		rest_ops = rest.profileCU(rest.cache.JWTProfileCache, "jwtserverprofile", jwt_profile_node.Name, jwt_profile_node.GetCheckSum(), func(cacheObj interface{}) *utils.RestOp {
+			jwt_profile_cache_obj, _ := cacheObj.(*avicache.AviJWTProfileCache)
+			return rest.AviJWTProfileBuild(jwt_profile_node, jwt_profile_cache_obj, key)
+		}, namespace, rest_ops, key)
+		rest_ops = rest.profileCU(rest.cache.AuthProfileCache, "authprofile", jwt_profile_node.AuthProfile, lib.AuthProfileChecksum(jwt_profile_node.AuthProfile, jwt_profile_node.Name), func(cacheObj interface{}) *utils.RestOp {
+			auth_profile_cache_obj, _ := cacheObj.(*avicache.AviAuthProfileCache)
+			return rest.AviAuthProfileBuild(jwt_profile_node, auth_profile_cache_obj, key)
+		}, namespace, rest_ops, key)
+	}
+
+	sso_policy_key := avicache.NamespaceName{Namespace: namespace, Name: sso_policy_node.Name}
+	cache_sso_policies = Remove(cache_sso_policies, sso_policy_key)
+	rest_ops = rest.profileCU(rest.cache.SSOPolicyCache, "ssopolicy", sso_policy_node.Name, sso_policy_node.GetCheckSum(), func(cacheObj interface{}) *utils.RestOp {
+		sso_policy_cache_obj, _ := cacheObj.(*avicache.AviSSOPolicyCache)
+		return rest.AviSSOPolicyBuild(sso_policy_node, sso_policy_cache_obj, key)
+	}, namespace, rest_ops, key)
+
+	if sso_policy_node.JWTProfile == nil {
+		// the SSO policy does not refer to the profiles created for JWT validation anymore
+		rest_ops = rest.ssoPolicyProfilesDelete(sso_policy_node.Name, namespace, rest_ops, key)
+	}
+	return cache_sso_policies, rest_ops
+}
+
+// SSOPolicyDelete deletes the SSO policies, followed by the profiles created by AKO for them
+func (rest *RestOperations) SSOPolicyDelete(ssoPolicyDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	rest_ops = rest.profileDelete(ssoPolicyDelete, rest.cache.SSOPolicyCache, rest.AviSSOPolicyDel, namespace, rest_ops, key)
+	for _, delSSOPolicy := range ssoPolicyDelete {
+		rest_ops = rest.ssoPolicyProfilesDelete(delSSOPolicy.Name, namespace, rest_ops, key)
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) ssoPolicyProfilesDelete(ssoPolicyName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	authProfileName, jwtProfileName := ssoPolicyProfileNames(ssoPolicyName)
+	authProfileDelete := []avicache.NamespaceName{{Namespace: namespace, Name: authProfileName}}
+	rest_ops = rest.profileDelete(authProfileDelete, rest.cache.AuthProfileCache, rest.AviAuthProfileDel, namespace, rest_ops, key)
+	jwtProfileDelete := []avicache.NamespaceName{{Namespace: namespace, Name: jwtProfileName}}
+	return rest.profileDelete(jwtProfileDelete, rest.cache.JWTProfileCache, rest.AviJWTProfileDel, namespace, rest_ops, key)
+}
+
+func (rest *RestOperations) AppProfileDelete(appProfileDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	var pkiProfileDelete []avicache.NamespaceName
+	for _, delAppProfile := range appProfileDelete {
+		appProfileKey := avicache.NamespaceName{Namespace: namespace, Name: delAppProfile.Name}
+		if appProfileCache, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey); ok {
+			if appProfileCacheObj, _ := appProfileCache.(*avicache.AviAppProfileCache); appProfileCacheObj.PkiProfileCollection.Name != "" {
+				pkiProfileDelete = append(pkiProfileDelete, appProfileCacheObj.PkiProfileCollection)
+			}
+		}
+	}
+	rest_ops = rest.profileDelete(appProfileDelete, rest.cache.AppProfileCache, rest.AviAppProfileDel, namespace, rest_ops, key)
+	// the pki profiles are deleted once the application profiles referring to them are gone
+	if len(pkiProfileDelete) > 0 {
+		rest_ops = rest.PkiProfileDelete(pkiProfileDelete, namespace, rest_ops, key)
+	}
+	return rest_ops
+}
+
+func (rest *RestOperations) HealthMonitorCU(hm_nodes []*nodes.AviHealthMonitorNode, pool_cache_obj *avicache.AviPoolCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_hm_nodes []avicache.NamespaceName
+	if pool_cache_obj != nil {
+		cache_hm_nodes = make([]avicache.NamespaceName, len(pool_cache_obj.HealthMonitorCollection))
+		copy(cache_hm_nodes, pool_cache_obj.HealthMonitorCollection)
+	}
+
+	for _, hm := range hm_nodes {
+		hm_key := avicache.NamespaceName{Namespace: namespace, Name: hm.Name}
+		cache_hm_nodes = Remove(cache_hm_nodes, hm_key)
+		rest_ops = rest.profileCU(rest.cache.HealthMonitorCache, "healthmonitor", hm.Name, hm.GetCheckSum(), func(cacheObj interface{}) *utils.RestOp {
+			hm_cache_obj, _ := cacheObj.(*avicache.AviHealthMonitorCache)
+			return rest.AviHealthMonitorBuild(hm, hm_cache_obj, key)
+		}, namespace, rest_ops, key)
+	}
+
+	return cache_hm_nodes, rest_ops
+}
+
+func (rest *RestOperations) HealthMonitorDelete(hmToDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	return rest.profileDelete(hmToDelete, rest.cache.HealthMonitorCache, rest.AviHealthMonitorDel, namespace, rest_ops, key)
+}
+
+// PersistenceProfileCU creates or updates the persistence profile created by AKO for a pool, the profile
+// previously used by the pool is returned for deletion when it is no longer referred
+func (rest *RestOperations) PersistenceProfileCU(persistence_node *nodes.AviPersistenceProfileNode, cacheKey avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cache_persistence_profiles []avicache.NamespaceName
+	if cacheKey.Name != "" {
+		cache_persistence_profiles = append(cache_persistence_profiles, cacheKey)
+	}
+	if persistence_node == nil {
+		return cache_persistence_profiles, rest_ops
+	}
+
+	persistence_key := avicache.NamespaceName{Namespace: namespace, Name: persistence_node.Name}
+	cache_persistence_profiles = Remove(cache_persistence_profiles, persistence_key)
+	rest_ops = rest.profileCU(rest.cache.PersistenceProfileCache, "applicationpersistenceprofile", persistence_node.Name, persistence_node.GetCheckSum(), func(cacheObj interface{}) *utils.RestOp {
+		persistence_cache_obj, _ := cacheObj.(*avicache.AviPersistenceProfileCache)
+		return rest.AviPersistenceProfileBuild(persistence_node, persistence_cache_obj, key)
+	}, namespace, rest_ops, key)
+
+	return cache_persistence_profiles, rest_ops
+}
+
+func (rest *RestOperations) PersistenceProfileDelete(persistenceProfileDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	return rest.profileDelete(persistenceProfileDelete, rest.cache.PersistenceProfileCache, rest.AviPersistenceProfileDel, namespace, rest_ops, key)
+}
+
+// profileCU appends the rest op which creates a profile created by AKO when it is not found in the cache,
+// or updates it when its checksum differs from the one in the cache. build gets the cache object, nil for a POST.
+func (rest *RestOperations) profileCU(aviCache *avicache.AviCache, objType, name string, checksum uint32, build func(cacheObj interface{}) *utils.RestOp, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	profileKey := avicache.NamespaceName{Namespace: namespace, Name: name}
+	profileCache, ok := aviCache.AviCacheGet(profileKey)
+	if !ok {
+		utils.AviLog.Debugf("key: %s, msg: %s %s not found in cache, operation: POST", key, objType, name)
+		return append(rest_ops, build(nil))
+	}
+	if _, _, cksum, _ := profileCache.(avicache.AviProfileCacheObj).GetCacheMeta(); cksum == strconv.FormatUint(uint64(checksum), 10) {
+		utils.AviLog.Debugf("key: %s, msg: the checksums are same for %s %s, not doing anything", key, objType, name)
+		return rest_ops
+	}
+	// The checksums are different, so it should be a PUT call.
+	return append(rest_ops, build(profileCache))
+}
+
+// profileDelete appends the rest ops which delete the profiles created by AKO that are found in the cache
+func (rest *RestOperations) profileDelete(profileDelete []avicache.NamespaceName, aviCache *avicache.AviCache, buildDel func(uuid, tenant, key string) *utils.RestOp, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	for _, delProfile := range profileDelete {
+		profileKey := avicache.NamespaceName{Namespace: namespace, Name: delProfile.Name}
+		if profileCache, ok := aviCache.AviCacheGet(profileKey); ok {
+			_, uuid, _, _ := profileCache.(avicache.AviProfileCacheObj).GetCacheMeta()
+			restOp := buildDel(uuid, namespace, key)
+			restOp.ObjName = delProfile.Name
 			rest_ops = append(rest_ops, restOp)
 		}
 	}
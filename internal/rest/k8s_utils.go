@@ -53,6 +53,7 @@ func (rest *RestOperations) SyncObjectStatuses() {
 			allGatewayUpdateOptions = append(allGatewayUpdateOptions,
 				status.UpdateOptions{
 					Vip:             vsCacheObj.Vip,
+					V6Vip:           vsCacheObj.V6Vip,
 					ServiceMetadata: vsSvcMetadataObj,
 					Key:             "syncstatus",
 				})
@@ -68,6 +69,7 @@ func (rest *RestOperations) SyncObjectStatuses() {
 				allIngressUpdateOptions = append(allIngressUpdateOptions,
 					status.UpdateOptions{
 						Vip:                parentVsObj.Vip,
+						V6Vip:              parentVsObj.V6Vip,
 						ServiceMetadata:    vsSvcMetadataObj,
 						Key:                "syncstatus",
 						VirtualServiceUUID: vsCacheObj.Uuid,
@@ -78,6 +80,7 @@ func (rest *RestOperations) SyncObjectStatuses() {
 			allServiceLBUpdateOptions = append(allServiceLBUpdateOptions,
 				status.UpdateOptions{
 					Vip:                vsCacheObj.Vip,
+					V6Vip:              vsCacheObj.V6Vip,
 					ServiceMetadata:    vsSvcMetadataObj,
 					Key:                "syncstatus",
 					VirtualServiceUUID: vsCacheObj.Uuid,
@@ -100,6 +103,7 @@ func (rest *RestOperations) SyncObjectStatuses() {
 					allIngressUpdateOptions = append(allIngressUpdateOptions,
 						status.UpdateOptions{
 							Vip:                vsCacheObj.Vip,
+							V6Vip:              vsCacheObj.V6Vip,
 							ServiceMetadata:    poolCacheObj.ServiceMetadataObj,
 							Key:                "syncstatus",
 							VirtualServiceUUID: vsCacheObj.Uuid,
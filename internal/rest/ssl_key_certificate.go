@@ -130,6 +130,16 @@ func (rest *RestOperations) AviSSLKeyCertAdd(rest_op *utils.RestOp, vsKey avicac
 
 		k := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: name}
 		rest.cache.SSLKeyCache.AviCacheAdd(k, &ssl_cache_obj)
+		// the client key/cert of a pool is tracked by the pool
+		if rest_op.PoolName != "" {
+			poolKey := avicache.NamespaceName{Namespace: rest_op.Tenant, Name: rest_op.PoolName}
+			if pool_cache, ok := rest.cache.PoolCache.AviCacheGet(poolKey); ok {
+				if pool_cache_obj, found := pool_cache.(*avicache.AviPoolCache); found {
+					pool_cache_obj.SSLKeyCertCollection = k
+				}
+			}
+			continue
+		}
 		// Update the VS object
 		if vsKey != (avicache.NamespaceName{}) {
 			vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
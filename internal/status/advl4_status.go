@@ -52,20 +52,24 @@ func UpdateGatewayStatusAddress(options []UpdateOptions, bulk bool) {
 	gatewayMap := getGateways(gatewaysToUpdate, bulk)
 	for _, option := range updateGWOptions {
 		updateServiceOptions = append(updateServiceOptions, UpdateOptions{
-			Vip: option.Vip,
-			Key: option.Key,
+			Vip:   option.Vip,
+			V6Vip: option.V6Vip,
+			Key:   option.Key,
 			ServiceMetadata: avicache.ServiceMetadataObj{
 				NamespaceServiceName: option.ServiceMetadata.NamespaceServiceName,
 			},
 		})
 
 		if gw := gatewayMap[option.IngSvc]; gw != nil {
-			// assuming 1 IP per gateway
+			// one IP per family for a gateway
 			gwStatus := gw.Status.DeepCopy()
-			gwStatus.Addresses = []advl4v1alpha1pre1.GatewayAddress{{
-				Value: option.Vip,
-				Type:  advl4v1alpha1pre1.IPAddressType,
-			}}
+			gwStatus.Addresses = []advl4v1alpha1pre1.GatewayAddress{}
+			for _, vip := range getVips(option) {
+				gwStatus.Addresses = append(gwStatus.Addresses, advl4v1alpha1pre1.GatewayAddress{
+					Value: vip,
+					Type:  advl4v1alpha1pre1.IPAddressType,
+				})
+			}
 
 			// when statuses are synced during bootup
 			InitializeGatewayConditions(gwStatus, &gw.Spec, true)
@@ -27,8 +27,9 @@ import (
 
 // UpdateCRDStatusOptions CRD Status Update Options
 type UpdateCRDStatusOptions struct {
-	Status string
-	Error  string
+	Status  string
+	Error   string
+	Warning string
 }
 
 // UpdateHostRuleStatus HostRule status updates
@@ -76,6 +77,7 @@ func UpdateHTTPRuleStatus(key string, rr *akov1alpha1.HTTPRule, updateStatus Upd
 
 	rr.Status.Status = updateStatus.Status
 	rr.Status.Error = updateStatus.Error
+	rr.Status.Warning = updateStatus.Warning
 
 	_, err := lib.GetCRDClientset().AkoV1alpha1().HTTPRules(rr.Namespace).UpdateStatus(context.TODO(), rr, metav1.UpdateOptions{})
 	if err != nil {
@@ -95,6 +97,38 @@ func UpdateHTTPRuleStatus(key string, rr *akov1alpha1.HTTPRule, updateStatus Upd
 	return
 }
 
+// UpdateAuthRuleStatus AuthRule status updates
+func UpdateAuthRuleStatus(key string, ar *akov1alpha1.AuthRule, updateStatus UpdateCRDStatusOptions, retryNum ...int) {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 3 {
+			utils.AviLog.Errorf("key: %s, msg: UpdateAuthRuleStatus retried 3 times, aborting", key)
+			return
+		}
+	}
+
+	ar.Status.Status = updateStatus.Status
+	ar.Status.Error = updateStatus.Error
+
+	_, err := lib.GetCRDClientset().AkoV1alpha1().AuthRules(ar.Namespace).UpdateStatus(context.TODO(), ar, metav1.UpdateOptions{})
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: %d there was an error in updating the authrule status: %+v", key, retry, err)
+		updatedAr, err := lib.GetCRDClientset().AkoV1alpha1().AuthRules(ar.Namespace).Get(context.TODO(), ar.Name, metav1.GetOptions{})
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: authrule not found %v", key, err)
+			if strings.Contains(err.Error(), utils.K8S_ETIMEDOUT) {
+				UpdateAuthRuleStatus(key, updatedAr, updateStatus, retry+1)
+			}
+			return
+		}
+		UpdateAuthRuleStatus(key, updatedAr, updateStatus, retry+1)
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the authrule %s/%s status %+v", key, ar.Namespace, ar.Name, utils.Stringify(updateStatus))
+	return
+}
+
 // UpdateAviInfraSettingStatus AviInfraSetting status updates
 func UpdateAviInfraSettingStatus(key string, infraSetting *akov1alpha1.AviInfraSetting, updateStatus UpdateCRDStatusOptions, retryNum ...int) {
 	retry := 0
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ type UpdateOptions struct {
 	// IngSvc format: namespace/name, not supposed to be provided by the caller
 	IngSvc             string
 	Vip                string
+	V6Vip              string
 	ServiceMetadata    avicache.ServiceMetadataObj
 	Key                string
 	VirtualServiceUUID string
@@ -68,8 +70,21 @@ func UpdateIngressStatus(options []UpdateOptions, bulk bool) {
 	return
 }
 
+// getVips returns the IPv4 and IPv6 VIPs of the virtualservice, which are set in the object status.
+func getVips(updateOption UpdateOptions) []string {
+	var vips []string
+	if updateOption.Vip != "" {
+		vips = append(vips, updateOption.Vip)
+	}
+	if updateOption.V6Vip != "" {
+		vips = append(vips, updateOption.V6Vip)
+	}
+	return vips
+}
+
 func updateObject(mIngress *networkingv1beta1.Ingress, updateOption UpdateOptions, retryNum ...int) error {
-	if updateOption.Vip == "" {
+	vips := getVips(updateOption)
+	if len(vips) == 0 {
 		return nil
 	}
 
@@ -99,10 +114,10 @@ func updateObject(mIngress *networkingv1beta1.Ingress, updateOption UpdateOption
 	}
 
 	// Handle fresh hostname update
-	if updateOption.Vip != "" {
-		for _, host := range hostnames {
+	for _, host := range hostnames {
+		for _, vip := range vips {
 			lbIngress := corev1.LoadBalancerIngress{
-				IP:       updateOption.Vip,
+				IP:       vip,
 				Hostname: host,
 			}
 			mIngress.Status.LoadBalancer.Ingress = append(mIngress.Status.LoadBalancer.Ingress, lbIngress)
@@ -314,7 +329,8 @@ func deleteObject(svc_mdata_obj avicache.ServiceMetadataObj, key string, isVSDel
 		hostListIng = append(hostListIng, rule.Host)
 	}
 
-	for i, status := range mIngress.Status.LoadBalancer.Ingress {
+	for i := len(mIngress.Status.LoadBalancer.Ingress) - 1; i >= 0; i-- {
+		status := mIngress.Status.LoadBalancer.Ingress[i]
 		for _, host := range svc_mdata_obj.HostNames {
 			if status.Hostname == host {
 				// Check if this host is still present in the spec, if so - don't delete it
@@ -412,6 +428,75 @@ func deleteIngressAnnotation(ingObj *networkingv1beta1.Ingress, svcMeta avicache
 	return nil
 }
 
+// UpdateIngressHostnameConflicts records the hosts of an Ingress which are owned by other namespaces in the
+// ako.vmware.com/hostname-conflicts annotation, and removes these hosts from the status of the Ingress.
+func UpdateIngressHostnameConflicts(key, namespace, name string, conflicts map[string]string) {
+	ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(name)
+	if err != nil {
+		return
+	}
+	mClient := utils.GetInformers().ClientSet
+
+	annotations := make(map[string]interface{})
+	if len(conflicts) > 0 {
+		conflictsStr, _ := json.Marshal(conflicts)
+		if ingObj.Annotations[lib.HostnameConflictAnnotation] != string(conflictsStr) {
+			annotations[lib.HostnameConflictAnnotation] = string(conflictsStr)
+		}
+		vsAnnotations := make(map[string]string)
+		if value, ok := ingObj.Annotations[VSAnnotation]; ok && json.Unmarshal([]byte(value), &vsAnnotations) == nil {
+			var lostHost bool
+			for host := range conflicts {
+				if _, ok := vsAnnotations[host]; ok {
+					delete(vsAnnotations, host)
+					lostHost = true
+				}
+			}
+			if lostHost {
+				vsAnnotationsStr, _ := json.Marshal(vsAnnotations)
+				annotations[VSAnnotation] = string(vsAnnotationsStr)
+			}
+		}
+	} else if _, ok := ingObj.Annotations[lib.HostnameConflictAnnotation]; ok {
+		// a nil value removes the annotation with a merge patch
+		annotations[lib.HostnameConflictAnnotation] = nil
+	}
+	if len(annotations) > 0 {
+		patchPayload, _ := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": annotations,
+			},
+		})
+		if _, err := mClient.NetworkingV1beta1().Ingresses(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: there was an error in updating the hostname conflicts of ingress %s/%s: %v", key, namespace, name, err)
+		}
+	}
+
+	lbStatus := ingObj.Status.LoadBalancer.DeepCopy()
+	for i := len(lbStatus.Ingress) - 1; i >= 0; i-- {
+		if _, ok := conflicts[lbStatus.Ingress[i].Hostname]; ok {
+			lbStatus.Ingress = append(lbStatus.Ingress[:i], lbStatus.Ingress[i+1:]...)
+		}
+	}
+	if len(lbStatus.Ingress) == len(ingObj.Status.LoadBalancer.Ingress) {
+		return
+	}
+	// the ingress list is set explicitly, an empty loadBalancer status would leave the list untouched
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"loadBalancer": map[string]interface{}{
+				"ingress": lbStatus.Ingress,
+			},
+		},
+	})
+	if _, err := mClient.NetworkingV1beta1().Ingresses(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status"); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in removing the conflicting hosts from the status of ingress %s/%s: %v", key, namespace, name, err)
+		return
+	}
+	utils.AviLog.Infof("key: %s, msg: removed the hosts %v owned by other namespaces from the status of ingress %s/%s",
+		key, utils.Stringify(conflicts), namespace, name)
+}
+
 // compareLBStatus returns true if status objects are same, so status update is not required
 func compareLBStatus(oldStatus, newStatus *corev1.LoadBalancerStatus) bool {
 	if len(oldStatus.Ingress) != len(newStatus.Ingress) {
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/avinetworks/sdk/go/clients"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	poolRuntimeCheckInterval = 10 // seconds
+	poolRuntimeCheckRetries  = 12
+)
+
+type poolServerRuntime struct {
+	IPAddr struct {
+		Addr string `json:"addr"`
+	} `json:"ip_addr"`
+	Port       int32 `json:"port"`
+	OperStatus struct {
+		State string `json:"state"`
+	} `json:"oper_status"`
+}
+
+// UpdatePoolReadinessGates sets the pool readiness gate on the Pods backing the servers of a pool,
+// once the pool is present on the controller. If the runtime check is enabled, the gate is set
+// only after the controller reports the corresponding pool servers up.
+func UpdatePoolReadinessGates(poolName, poolUuid, key string) {
+	if !lib.IsPodReadinessGateEnabled() {
+		return
+	}
+	found, serverPods := objects.SharedPoolPodLister().Get(poolName)
+	if !found || len(getWaitingServerPods(serverPods)) == 0 {
+		return
+	}
+	if !lib.IsPodReadinessRuntimeCheckEnabled() {
+		UpdatePodReadinessGates(serverPods.AllPods(), key)
+		return
+	}
+	sharedPoolRuntimeChecker().add(poolName, poolUuid, key)
+}
+
+// getWaitingServerPods returns the pool servers fronting Pods which still wait on the readiness gate.
+func getWaitingServerPods(serverPods objects.PoolServerPods) objects.PoolServerPods {
+	waiting := make(objects.PoolServerPods)
+	for server, pods := range serverPods {
+		for _, podNSName := range pods {
+			pod, err := utils.GetInformers().PodInformer.Lister().Pods(podNSName.Namespace).Get(podNSName.Name)
+			if err == nil && lib.HasPodReadinessGate(pod) && !lib.IsPodReadinessGateSet(pod) {
+				waiting[server] = append(waiting[server], podNSName)
+			}
+		}
+	}
+	return waiting
+}
+
+type poolRuntimeCheck struct {
+	uuid    string
+	key     string
+	retries int
+}
+
+// poolRuntimeChecker polls the runtime of the pools whose Pods wait on the readiness gate.
+// The checks run on a single worker with a dedicated Avi client, so that they do not share
+// the sessions of the rest layer workers, nor pile up with the number of pool updates.
+type poolRuntimeChecker struct {
+	lock    sync.Mutex
+	pools   map[string]*poolRuntimeCheck
+	trigger chan struct{}
+}
+
+var poolRuntimeCheckerInstance *poolRuntimeChecker
+var poolRuntimeCheckerOnce sync.Once
+
+func sharedPoolRuntimeChecker() *poolRuntimeChecker {
+	poolRuntimeCheckerOnce.Do(func() {
+		poolRuntimeCheckerInstance = &poolRuntimeChecker{
+			pools:   make(map[string]*poolRuntimeCheck),
+			trigger: make(chan struct{}, 1),
+		}
+		go poolRuntimeCheckerInstance.run()
+	})
+	return poolRuntimeCheckerInstance
+}
+
+func (c *poolRuntimeChecker) add(poolName, poolUuid, key string) {
+	c.lock.Lock()
+	c.pools[poolName] = &poolRuntimeCheck{uuid: poolUuid, key: key}
+	c.lock.Unlock()
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *poolRuntimeChecker) run() {
+	ticker := time.NewTicker(poolRuntimeCheckInterval * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.trigger:
+		}
+		c.checkPools()
+	}
+}
+
+func (c *poolRuntimeChecker) checkPools() {
+	c.lock.Lock()
+	pools := make(map[string]*poolRuntimeCheck, len(c.pools))
+	for poolName, check := range c.pools {
+		pools[poolName] = check
+	}
+	c.lock.Unlock()
+	if len(pools) == 0 {
+		return
+	}
+
+	aviClientPool := avicache.SharedAVIClients()
+	clientIndex := int(lib.GetshardSize()) + 1
+	if aviClientPool == nil || len(aviClientPool.AviClient) <= clientIndex {
+		utils.AviLog.Warnf("msg: no avi client available for the pool runtime checks")
+		return
+	}
+	aviClient := aviClientPool.AviClient[clientIndex]
+
+	for poolName, check := range pools {
+		done := checkPoolServersUp(aviClient, poolName, check)
+		c.lock.Lock()
+		// the check may have been added again for a newer update of the pool meanwhile
+		if current, ok := c.pools[poolName]; ok && current == check {
+			check.retries++
+			if done {
+				delete(c.pools, poolName)
+			} else if check.retries >= poolRuntimeCheckRetries {
+				utils.AviLog.Warnf("key: %s, msg: servers of pool %s are not up, readiness gate not set for pods", check.key, poolName)
+				delete(c.pools, poolName)
+			}
+		}
+		c.lock.Unlock()
+	}
+}
+
+// checkPoolServersUp sets the readiness gate on the Pods of the pool servers which are up,
+// and returns true if no Pod of the pool is left waiting on the gate.
+func checkPoolServersUp(aviClient *clients.AviClient, poolName string, check *poolRuntimeCheck) bool {
+	found, serverPods := objects.SharedPoolPodLister().Get(poolName)
+	if !found {
+		return true
+	}
+	pending := getWaitingServerPods(serverPods)
+	if len(pending) == 0 {
+		return true
+	}
+
+	var serversRuntime []poolServerRuntime
+	uri := fmt.Sprintf("/api/pool/%s/runtime/server", check.uuid)
+	if err := lib.AviGet(aviClient, uri, &serversRuntime); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get runtime for pool %s: %v", check.key, poolName, err)
+		return false
+	}
+
+	upServers := make(objects.PoolServerPods)
+	for _, serverRuntime := range serversRuntime {
+		if serverRuntime.OperStatus.State != "OPER_UP" {
+			continue
+		}
+		serverKey := fmt.Sprintf("%s:%d", serverRuntime.IPAddr.Addr, serverRuntime.Port)
+		if pods, ok := pending[serverKey]; ok {
+			upServers[serverKey] = pods
+			delete(pending, serverKey)
+		}
+		if pods, ok := pending[objects.AnyPoolServer]; ok {
+			upServers[objects.AnyPoolServer] = pods
+			delete(pending, objects.AnyPoolServer)
+		}
+	}
+	if len(upServers) > 0 {
+		UpdatePodReadinessGates(upServers.AllPods(), check.key)
+	}
+	return len(pending) == 0
+}
+
+// UpdatePodReadinessGates sets the pool readiness condition to true on the Pods
+// which declare the readiness gate, and do not have the condition set already.
+func UpdatePodReadinessGates(pods []utils.NamespaceName, key string) {
+	for _, podNSName := range pods {
+		pod, err := utils.GetInformers().PodInformer.Lister().Pods(podNSName.Namespace).Get(podNSName.Name)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				utils.AviLog.Warnf("key: %s, msg: unable to get pod %s/%s: %v", key, podNSName.Namespace, podNSName.Name, err)
+			}
+			continue
+		}
+		if !lib.HasPodReadinessGate(pod) || lib.IsPodReadinessGateSet(pod) {
+			continue
+		}
+		updatePodReadinessCondition(pod.DeepCopy(), key)
+	}
+}
+
+func updatePodReadinessCondition(pod *corev1.Pod, key string) {
+	condition := corev1.PodCondition{
+		Type:               lib.PodReadinessGateCondition,
+		Status:             corev1.ConditionTrue,
+		Reason:             lib.PodReadinessGateReason,
+		Message:            "Pod is added as a server in the Avi pool",
+		LastTransitionTime: metav1.Now(),
+	}
+
+	var foundCondition bool
+	for i, c := range pod.Status.Conditions {
+		if c.Type == lib.PodReadinessGateCondition {
+			pod.Status.Conditions[i] = condition
+			foundCondition = true
+			break
+		}
+	}
+	if !foundCondition {
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	}
+
+	_, err := utils.GetInformers().ClientSet.CoreV1().Pods(pod.Namespace).UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in setting the readiness condition for pod %s/%s: %v", key, pod.Namespace, pod.Name, err)
+		return
+	}
+	utils.AviLog.Infof("key: %s, msg: readiness condition %s set for pod %s/%s", key, lib.PodReadinessGateCondition, pod.Namespace, pod.Name)
+}
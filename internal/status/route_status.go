@@ -158,6 +158,12 @@ func getRoutes(routeNSNames []string, bulk bool, retryNum ...int) map[string]*ro
 }
 
 func UpdateRouteStatusWithErrMsg(key, routeName, namespace, msg string, retryNum ...int) {
+	UpdateRouteStatusWithErrReason(key, routeName, namespace, msg, "", retryNum...)
+}
+
+// UpdateRouteStatusWithErrReason marks the route as not admitted, with the reason and a
+// human readable message describing why AKO did not program it.
+func UpdateRouteStatusWithErrReason(key, routeName, namespace, reason, message string, retryNum ...int) {
 	retry := 0
 	if len(retryNum) > 0 {
 		retry = retryNum[0]
@@ -179,7 +185,8 @@ func UpdateRouteStatusWithErrMsg(key, routeName, namespace, msg string, retryNum
 	condition := routev1.RouteIngressCondition{
 		Status:             corev1.ConditionFalse,
 		LastTransitionTime: &now,
-		Reason:             msg,
+		Reason:             reason,
+		Message:            message,
 		Type:               routev1.RouteAdmitted,
 	}
 
@@ -207,12 +214,26 @@ func UpdateRouteStatusWithErrMsg(key, routeName, namespace, msg string, retryNum
 		// fetch updated route and feed for update status
 		mRoutes := getRoutes([]string{mRoute.Namespace + "/" + mRoute.Name}, false)
 		if len(mRoutes) > 0 {
-			UpdateRouteStatusWithErrMsg(key, routeName, namespace, msg, retry+1)
+			UpdateRouteStatusWithErrReason(key, routeName, namespace, reason, message, retry+1)
 		}
 	}
 	return
 }
 
+// routeStatusHosts maps the hostnames programmed for a route to the hosts reported in the route status,
+// routes with wildcardPolicy Subdomain are programmed with a wildcard host but reported with the spec host.
+func routeStatusHosts(mRoute *routev1.Route, hostnames []string) []string {
+	routeHost := lib.GetRouteHostName(mRoute.Spec)
+	statusHosts := make([]string, 0, len(hostnames))
+	for _, host := range hostnames {
+		if host == routeHost {
+			host = mRoute.Spec.Host
+		}
+		statusHosts = append(statusHosts, host)
+	}
+	return statusHosts
+}
+
 func routeStatusCheck(key string, oldStatus []routev1.RouteIngress, hostname string) bool {
 	for _, status := range oldStatus {
 		if len(status.Conditions) < 1 {
@@ -233,7 +254,8 @@ func routeStatusCheck(key string, oldStatus []routev1.RouteIngress, hostname str
 }
 
 func updateRouteObject(mRoute *routev1.Route, updateOption UpdateOptions, retryNum ...int) error {
-	if updateOption.Vip == "" {
+	vips := getVips(updateOption)
+	if len(vips) == 0 {
 		return nil
 	}
 
@@ -246,7 +268,7 @@ func updateRouteObject(mRoute *routev1.Route, updateOption UpdateOptions, retryN
 	}
 
 	var err error
-	hostnames, key := updateOption.ServiceMetadata.HostNames, updateOption.Key
+	hostnames, key := routeStatusHosts(mRoute, updateOption.ServiceMetadata.HostNames), updateOption.Key
 	oldRouteStatus := mRoute.Status.DeepCopy()
 
 	// Clean up all hosts that are not part of the route spec.
@@ -260,12 +282,12 @@ func updateRouteObject(mRoute *routev1.Route, updateOption UpdateOptions, retryN
 		}
 	}
 
-	// Handle fresh hostname update
-	if updateOption.Vip != "" {
+	// Handle fresh hostname update, dual-stack VIPs are set as a comma separated list
+	if len(vips) > 0 {
 		for _, host := range hostnames {
 			now := metav1.Now()
 			condition := routev1.RouteIngressCondition{
-				Message:            updateOption.Vip,
+				Message:            strings.Join(vips, ","),
 				Status:             corev1.ConditionTrue,
 				LastTransitionTime: &now,
 				Type:               routev1.RouteAdmitted,
@@ -277,6 +299,9 @@ func updateRouteObject(mRoute *routev1.Route, updateOption UpdateOptions, retryN
 					condition,
 				},
 			}
+			if mRoute.Spec.WildcardPolicy == routev1.WildcardPolicySubdomain {
+				rtIngress.WildcardPolicy = routev1.WildcardPolicySubdomain
+			}
 			mRoute.Status.Ingress = append(mRoute.Status.Ingress, rtIngress)
 		}
 	}
@@ -458,6 +483,7 @@ func deleteRouteObject(svc_mdata_obj avicache.ServiceMetadataObj, key string, is
 		utils.AviLog.Warnf("key: %s, msg: Could not get the ingress object for DeleteStatus: %s", key, err)
 		return err
 	}
+	svc_mdata_obj.HostNames = routeStatusHosts(mRoute, svc_mdata_obj.HostNames)
 
 	oldRouteStatus := mRoute.Status.DeepCopy()
 	if len(svc_mdata_obj.HostNames) > 0 {
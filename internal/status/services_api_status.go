@@ -49,20 +49,24 @@ func UpdateSvcApiGatewayStatusAddress(options []UpdateOptions, bulk bool) {
 	gatewayMap := getSvcApiGateways(gatewaysToUpdate, bulk)
 	for _, option := range updateGWOptions {
 		updateServiceOptions = append(updateServiceOptions, UpdateOptions{
-			Vip: option.Vip,
-			Key: option.Key,
+			Vip:   option.Vip,
+			V6Vip: option.V6Vip,
+			Key:   option.Key,
 			ServiceMetadata: avicache.ServiceMetadataObj{
 				NamespaceServiceName: option.ServiceMetadata.NamespaceServiceName,
 			},
 		})
 
 		if gw := gatewayMap[option.IngSvc]; gw != nil {
-			// assuming 1 IP per gateway
+			// one IP per family for a gateway
 			gwStatus := gw.Status.DeepCopy()
-			gwStatus.Addresses = []svcapiv1alpha1.GatewayAddress{{
-				Value: option.Vip,
-				Type:  svcapiv1alpha1.IPAddressType,
-			}}
+			gwStatus.Addresses = []svcapiv1alpha1.GatewayAddress{}
+			for _, vip := range getVips(option) {
+				gwStatus.Addresses = append(gwStatus.Addresses, svcapiv1alpha1.GatewayAddress{
+					Value: vip,
+					Type:  svcapiv1alpha1.IPAddressType,
+				})
+			}
 
 			// when statuses are synced during bootup
 			InitializeSvcApiGatewayConditions(gwStatus, &gw.Spec, true)
@@ -34,13 +34,30 @@ type RestStatusError struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// CachePopulateStatus holds the progress of the population of the cache from the controller
+type CachePopulateStatus struct {
+	Status      string                       `json:"status"`
+	StartTime   time.Time                    `json:"start_time"`
+	EndTime     time.Time                    `json:"end_time"`
+	Error       string                       `json:"error,omitempty"`
+	Collections map[string]*CollectionStatus `json:"collections"`
+}
+
+// CollectionStatus holds the pages of a collection fetched from the controller, out of its total
+type CollectionStatus struct {
+	Objects      int `json:"objects"`
+	Pages        int `json:"pages"`
+	PagesFetched int `json:"pages_fetched"`
+}
+
 var RestStatus *StatusModel
 var reststatusonce sync.Once
 
 // StatusModel implements ApiModel
 type StatusModel struct {
-	AviApi     AviApiRestStatus `json:"avi_api"`
-	statusLock sync.RWMutex
+	AviApi        AviApiRestStatus    `json:"avi_api"`
+	CachePopulate CachePopulateStatus `json:"cache_populate"`
+	statusLock    sync.RWMutex
 }
 
 func (a *StatusModel) InitModel() {
@@ -50,6 +67,10 @@ func (a *StatusModel) InitModel() {
 				ConnectionStatus: utils.AVIAPI_INITIATING,
 				Errors:           []RestStatusError{},
 			},
+			CachePopulate: CachePopulateStatus{
+				Status:      utils.CACHE_POPULATE_PENDING,
+				Collections: map[string]*CollectionStatus{},
+			},
 		}
 	})
 }
@@ -61,6 +82,8 @@ func (a *StatusModel) ApiOperationMap() []OperationMap {
 		Route:  "/api/status",
 		Method: "GET",
 		Handler: func(w http.ResponseWriter, r *http.Request) {
+			RestStatus.statusLock.RLock()
+			defer RestStatus.statusLock.RUnlock()
 			response := &RestStatus
 			utils.Respond(w, response)
 		},
@@ -104,3 +127,43 @@ func (a *StatusModel) UpdateAviApiRestStatus(connectionStatus string, err error)
 
 	return
 }
+
+// StartCachePopulate resets the progress of the population of the cache, when it starts
+func (a *StatusModel) StartCachePopulate() {
+	a.statusLock.Lock()
+	defer a.statusLock.Unlock()
+	a.CachePopulate = CachePopulateStatus{
+		Status:      utils.CACHE_POPULATE_IN_PROGRESS,
+		StartTime:   time.Now(),
+		Collections: map[string]*CollectionStatus{},
+	}
+}
+
+// UpdateCachePopulate records a page of a collection fetched from the controller
+func (a *StatusModel) UpdateCachePopulate(objType string, objects, pages int) {
+	a.statusLock.Lock()
+	defer a.statusLock.Unlock()
+	if a.CachePopulate.Collections == nil {
+		a.CachePopulate.Collections = map[string]*CollectionStatus{}
+	}
+	collection, ok := a.CachePopulate.Collections[objType]
+	if !ok {
+		collection = &CollectionStatus{}
+		a.CachePopulate.Collections[objType] = collection
+	}
+	collection.Objects = objects
+	collection.Pages = pages
+	collection.PagesFetched++
+}
+
+// FinishCachePopulate records the end of the population of the cache, and its error if it failed
+func (a *StatusModel) FinishCachePopulate(err error) {
+	a.statusLock.Lock()
+	defer a.statusLock.Unlock()
+	a.CachePopulate.EndTime = time.Now()
+	a.CachePopulate.Status = utils.CACHE_POPULATE_DONE
+	if err != nil {
+		a.CachePopulate.Status = utils.CACHE_POPULATE_FAILED
+		a.CachePopulate.Error = err.Error()
+	}
+}
@@ -60,6 +60,7 @@ const (
 	L7_PG_PREFIX                  = "-PG-l7"
 	VS_DATASCRIPT_EVT_HTTP_REQ    = "VS_DATASCRIPT_EVT_HTTP_REQ"
 	HTTP_DS_SCRIPT                = "host = avi.http.get_host_tokens(1)\npath = avi.http.get_path_tokens(1)\nif host and path then\nlbl = host..\"/\"..path\nelse\nlbl = host..\"/\"\nend\navi.poolgroup.select(\"POOLGROUP\", string.lower(lbl) )"
+	HTTP_DS_WILDCARD_SCRIPT       = "host = avi.http.get_host_tokens(1)\npath = avi.http.get_path_tokens(1)\nif host then\nhost = string.lower(host)\nif not exact_hosts[host] then\nfor _, suffix in ipairs(wildcard_suffixes) do\nif string.len(host) > string.len(suffix) and string.sub(host, -string.len(suffix)) == suffix then\nhost = \"*\"..suffix\nbreak\nend\nend\nend\nend\nif host and path then\nlbl = host..\"/\"..path\nelse\nlbl = host..\"/\"\nend\navi.poolgroup.select(\"POOLGROUP\", string.lower(lbl) )"
 	ADMIN_NS                      = "admin"
 	TLS_PASSTHROUGH               = "TLS_PASSTHROUGH"
 	VS_TYPE_VH_PARENT             = "VS_TYPE_VH_PARENT"
@@ -77,4 +78,10 @@ const (
 	AVIAPI_INITIATING   = "INITIATING"
 	AVIAPI_CONNECTED    = "CONNECTED"
 	AVIAPI_DISCONNECTED = "DISCONNECTED"
+
+	// states of the population of the cache from the controller
+	CACHE_POPULATE_PENDING     = "PENDING"
+	CACHE_POPULATE_IN_PROGRESS = "IN_PROGRESS"
+	CACHE_POPULATE_DONE        = "DONE"
+	CACHE_POPULATE_FAILED      = "FAILED"
 )
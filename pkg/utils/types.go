@@ -110,6 +110,7 @@ type RestOp struct {
 	Model    string
 	Version  string
 	ObjName  string // Optional field - right only to be used for delete.
+	PoolName string // Optional field - set for the objects which are tracked by a pool and not the VS.
 }
 
 type ServiceMetadataObj struct {
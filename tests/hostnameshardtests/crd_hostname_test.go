@@ -16,15 +16,18 @@ package hostnameshardtests
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 
 	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -97,6 +100,59 @@ func TestHostnameCreateDeleteHostRule(t *testing.T) {
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
 
+func TestHostnameHostRuleAlternateCertificate(t *testing.T) {
+	// hostrule with an sslKeyCertificate and an alternateCertificate, both refs are set on the sni child
+	// an alternateCertificate same as the sslKeyCertificate rejects the hostrule
+	// delete hostrule, both refs are removed
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-foo"
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate = akov1alpha1.HostRuleSecret{
+		Name: "thisisaviref-sslkey-ecdsa",
+		Type: "ref",
+	}
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.VerifyMetadataHostRule(g, sniVSKey, "default/samplehr-foo", true)
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].SSLKeyCertAviRef).To(gomega.Equal("/api/sslkeyandcertificate?name=thisisaviref-sslkey"))
+	g.Expect(nodes[0].SniNodes[0].AltSSLKeyCertAviRef).To(gomega.Equal("/api/sslkeyandcertificate?name=thisisaviref-sslkey-ecdsa"))
+
+	hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Name = "thisisaviref-sslkey"
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Error
+	}, 10*time.Second).Should(gomega.ContainSubstring("must differ from the sslKeyCertificate"))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].SSLKeyCertAviRef).To(gomega.Equal(""))
+	g.Expect(nodes[0].SniNodes[0].AltSSLKeyCertAviRef).To(gomega.Equal(""))
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
 func TestHostnameCreateHostRuleBeforeIngress(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -348,178 +404,1959 @@ func TestHostnameGoodToBadHostRule(t *testing.T) {
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
 
-func TestHostnameInsecureHostAndHostrule(t *testing.T) {
-	// create insecure ingress, insecure hostrule, nothing should be applied
+func TestHostnameHostRuleClientCertificate(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	modelName := "admin/cluster--Shared-L7-0"
 	hrname := "samplehr-foo"
-	SetUpIngressForCacheSyncCheck(t, modelName, false, false)
-	integrationtest.SetupHostRule(t, hrname, "foo.com", false)
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
 
-	g.Eventually(func() int {
+	// a clientCertificate referring to a missing CA bundle must be rejected
+	hostrule := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.ClientCertificate = akov1alpha1.HostRuleClientCertificate{
+		CABundle: akov1alpha1.HostRuleCABundle{Kind: "Secret", Name: "client-ca"},
+		Mode:     "request",
+		Headers: []akov1alpha1.HostRuleClientCertHeader{
+			{Name: "X-Client-Subject", Value: "subject"},
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// adding the CA secret revalidates the hostrule
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-ca", Namespace: "default"},
+		Data:       map[string][]byte{"ca.crt": []byte("-----BEGIN CERTIFICATE-----\nclientca\n-----END CERTIFICATE-----")},
+	}
+	if _, err := KubeClient.CoreV1().Secrets("default").Create(context.TODO(), caSecret, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Secret: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	g.Eventually(func() bool {
 		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
 		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-		return len(nodes[0].PoolRefs)
-	}, 10*time.Second).Should(gomega.Equal(1))
+		return len(nodes[0].SniNodes) == 1 && nodes[0].SniNodes[0].AppProfileNode != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
 	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
 	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-	g.Expect(nodes[0].SniNodes).To(gomega.HaveLen(0))
+	appProfile := nodes[0].SniNodes[0].AppProfileNode
+	g.Expect(appProfile.Name).To(gomega.Equal("cluster--foo.com-appprofile"))
+	g.Expect(appProfile.ClientCertMode).To(gomega.Equal("SSL_CLIENT_CERTIFICATE_REQUEST"))
+	g.Expect(appProfile.ClientCertHeaders).To(gomega.HaveLen(1))
+	g.Expect(*appProfile.ClientCertHeaders[0].RequestHeader).To(gomega.Equal("X-Client-Subject"))
+	g.Expect(*appProfile.ClientCertHeaders[0].RequestHeaderValue).To(gomega.Equal("HTTP_POLICY_VAR_SSL_CLIENT_SUBJECT"))
+	g.Expect(appProfile.PkiProfile).NotTo(gomega.BeNil())
+	g.Expect(appProfile.PkiProfile.Name).To(gomega.Equal("cluster--foo.com-clientca-pkiprofile"))
+	g.Expect(appProfile.PkiProfile.CACert).To(gomega.ContainSubstring("clientca"))
+	g.Expect(nodes[0].SniNodes[0].AppProfileRef).To(gomega.Equal("/api/applicationprofile?name=cluster--foo.com-appprofile"))
+
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(func() int {
+		sniCache, found := mcache.VsCacheMeta.AviCacheGet(sniVSKey)
+		if !found {
+			return 0
+		}
+		return len(sniCache.(*cache.AviVsCache).AppProfileCollection)
+	}, 10*time.Second).Should(gomega.Equal(1))
+	appProfileKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com-appprofile"}
+	appProfileCache, found := mcache.AppProfileCache.AviCacheGet(appProfileKey)
+	g.Expect(found).To(gomega.Equal(true))
+	g.Expect(appProfileCache.(*cache.AviAppProfileCache).PkiProfileCollection.Name).To(gomega.Equal("cluster--foo.com-clientca-pkiprofile"))
+	pkiProfileKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com-clientca-pkiprofile"}
+	g.Eventually(func() bool {
+		_, found := mcache.PKIProfileCache.AviCacheGet(pkiProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	// a custom applicationProfile cannot be combined with clientCertificate
+	hrUpdate := hostrule.DeepCopy()
+	hrUpdate.Spec.VirtualHost.ApplicationProfile = "thisisaviref-appprof"
+	hrUpdate.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
 
-	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
 	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes[0].SniNodes) == 1 && nodes[0].SniNodes[0].AppProfileNode == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.AppProfileCache.AviCacheGet(appProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	g.Eventually(func() bool {
+		_, found := mcache.PKIProfileCache.AviCacheGet(pkiProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "client-ca", metav1.DeleteOptions{})
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
 
-func TestHostnameValidToInvalidHostSwitch(t *testing.T) {
-	// create insecure host foo.com, attach hostrule, change hostrule to non existing bar.com
-	// foo.com should become insecure again
-	// change hostrule back to foo.com and it should become secure again
+func TestHostnameHostRuleRateLimit(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	modelName := "admin/cluster--Shared-L7-0"
 	hrname := "samplehr-foo"
-	SetUpIngressForCacheSyncCheck(t, modelName, false, false)
-	integrationtest.SetupHostRule(t, hrname, "foo.com", true)
-
 	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
-	integrationtest.VerifyMetadataHostRule(g, sniVSKey, "default/samplehr-foo", true)
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
 
-	hrUpdate := integrationtest.FakeHostRule{
+	// a header key without the header name must be rejected
+	hostrule := integrationtest.FakeHostRule{
 		Name:              hrname,
 		Namespace:         "default",
-		Fqdn:              "bar.com",
+		Fqdn:              "foo.com",
 		SslKeyCertificate: "thisisaviref-sslkey",
 	}.HostRule()
-	hrUpdate.ResourceVersion = "2"
-	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
+	hostrule.Spec.VirtualHost.RateLimit = akov1alpha1.RateLimit{
+		RequestsPerSecond: 100,
+		Burst:             20,
+		Key:               "header",
+	}
+	hostrule.Spec.VirtualHost.MaxConnectionsPerServer = 50
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	hostrule.Spec.VirtualHost.RateLimit.Header = "X-Api-Key"
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
 		t.Fatalf("error in updating HostRule: %v", err)
 	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
 
-	g.Eventually(func() int {
+	g.Eventually(func() bool {
 		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
 		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-		return len(nodes[0].PoolRefs)
-	}, 10*time.Second).Should(gomega.Equal(1))
+		return len(nodes[0].SniNodes) == 1 && nodes[0].SniNodes[0].AppProfileNode != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
 	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
 	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-	g.Expect(nodes[0].PoolRefs[0].Name).To(gomega.Equal("cluster--foo.com_foo-default-foo-with-targets"))
+	appProfile := nodes[0].SniNodes[0].AppProfileNode
+	g.Expect(appProfile.Name).To(gomega.Equal("cluster--foo.com-appprofile"))
+	g.Expect(appProfile.PkiProfile).To(gomega.BeNil())
+	g.Expect(appProfile.RateLimit).NotTo(gomega.BeNil())
+	g.Expect(appProfile.RateLimit.RequestsPerSecond).To(gomega.Equal(int32(100)))
+	g.Expect(appProfile.RateLimit.Header).To(gomega.Equal("X-Api-Key"))
+	g.Expect(nodes[0].SniNodes[0].AppProfileRef).To(gomega.Equal("/api/applicationprofile?name=cluster--foo.com-appprofile"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs).NotTo(gomega.BeEmpty())
+	for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+		g.Expect(pool.MaxConcurrentConnectionsPerServer).To(gomega.Equal(int32(50)))
+	}
 
-	// change back to good host
-	hrUpdate = integrationtest.FakeHostRule{
-		Name:              hrname,
-		Namespace:         "default",
-		Fqdn:              "foo.com",
-		SslKeyCertificate: "thisisaviref-sslkey",
-	}.HostRule()
+	mcache := cache.SharedAviObjCache()
+	appProfileKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com-appprofile"}
+	g.Eventually(func() bool {
+		_, found := mcache.AppProfileCache.AviCacheGet(appProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	// a custom applicationProfile cannot be combined with rateLimit
+	hrUpdate := hostrule.DeepCopy()
+	hrUpdate.Spec.VirtualHost.ApplicationProfile = "thisisaviref-appprof"
 	hrUpdate.ResourceVersion = "3"
 	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
 		t.Fatalf("error in updating HostRule: %v", err)
 	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
 
-	g.Eventually(func() int {
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(func() bool {
 		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
 		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-		return len(nodes[0].PoolRefs)
-	}, 10*time.Second).Should(gomega.Equal(0))
-	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
-	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].Name).To(gomega.Equal("cluster--default-foo.com_foo-foo-with-targets"))
+		return len(nodes[0].SniNodes) == 1 && nodes[0].SniNodes[0].AppProfileNode == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.AppProfileCache.AviCacheGet(appProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
 
-	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
 
-// HttpRule tests
-
-func TestHostnameHTTPRuleCreateDelete(t *testing.T) {
-	// ingress secure foo.com/foo /bar
-	// create httprule /foo, nothing happens
-	// create hostrule, httprule gets attached check on /foo /bar
-	// delete hostrule, httprule gets detached
+func TestHostnameHostRuleAccessControl(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	modelName := "admin/cluster--Shared-L7-0"
-	rrname := "samplerr-foo"
+	hrname := "samplehr-foo"
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	accessPolicyName := "cluster--foo.com-hostpolicy"
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
 
-	SetupDomain()
-	SetUpTestForIngress(t, modelName)
-	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
-	integrationtest.PollForCompletion(t, modelName, 5)
-	ingressObject := integrationtest.FakeIngress{
-		Name:        "foo-with-targets",
-		Namespace:   "default",
-		DnsNames:    []string{"foo.com"},
-		Ips:         []string{"8.8.8.8"},
-		HostNames:   []string{"v1"},
-		Paths:       []string{"/foo", "/bar"},
-		ServiceName: "avisvc",
-		TlsSecretDNS: map[string][]string{
-			"my-secret": {"foo.com"},
-		},
+	getAccessPolicy := func() *avinodes.AviHttpPolicySetNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		for _, policy := range nodes[0].SniNodes[0].HttpPolicyRefs {
+			if policy.Name == accessPolicyName {
+				return policy
+			}
+		}
+		return nil
 	}
 
-	ingrFake := ingressObject.Ingress(true)
-	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
-		t.Fatalf("error in adding Ingress: %v", err)
+	// an invalid CIDR must be rejected
+	hostrule := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.AccessControl = akov1alpha1.AccessControl{
+		AllowCIDRs: []string{"10.10.0.0/16", "10.20.0.0"},
 	}
-	integrationtest.PollForCompletion(t, modelName, 5)
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
 
-	poolFooKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets"}
-	poolBarKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_bar-foo-with-targets"}
-	integrationtest.SetupHTTPRule(t, rrname, "foo.com", "/")
-	integrationtest.VerifyMetadataHTTPRule(g, poolFooKey, "default/"+rrname, true)
-	integrationtest.VerifyMetadataHTTPRule(g, poolBarKey, "default/"+rrname, true)
-	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
-	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].LbAlgorithm).To(gomega.Equal("LB_ALGORITHM_CONSISTENT_HASH"))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].LbAlgorithmHash).To(gomega.Equal("LB_ALGORITHM_CONSISTENT_HASH_SOURCE_IP_ADDRESS"))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].SslProfileRef).To(gomega.ContainSubstring("thisisaviref-sslprofile"))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].PkiProfile.CACert).To(gomega.Equal("httprule-destinationCA"))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors).To(gomega.HaveLen(2))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors[0]).To(gomega.ContainSubstring("thisisaviref-hm2"))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors[1]).To(gomega.ContainSubstring("thisisaviref-hm1"))
+	hostrule.Spec.VirtualHost.AccessControl = akov1alpha1.AccessControl{
+		AllowCIDRs:   []string{"10.10.0.0/16", "2001:db8::/32"},
+		DenyIPGroups: []string{"thisisaviref-ipgroup"},
+	}
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
 
-	// delete httprule deletes refs as well
-	integrationtest.TeardownHTTPRule(t, rrname)
-	integrationtest.VerifyMetadataHTTPRule(g, poolFooKey, "default/"+rrname, false)
-	integrationtest.VerifyMetadataHTTPRule(g, poolBarKey, "default/"+rrname, false)
-	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
-	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].LbAlgorithm).To(gomega.Equal(""))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].SslProfileRef).To(gomega.Equal(""))
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].PkiProfile).To(gomega.BeNil())
-	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors).To(gomega.HaveLen(0))
+	g.Eventually(func() bool {
+		return getAccessPolicy() != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	accessControl := getAccessPolicy().AccessControl
+	g.Expect(accessControl).NotTo(gomega.BeNil())
+	g.Expect(accessControl.AllowCIDRs).To(gomega.Equal([]string{"10.10.0.0/16", "2001:db8::/32"}))
+	g.Expect(accessControl.DenyIPGroups).To(gomega.Equal([]string{"thisisaviref-ipgroup"}))
+
+	mcache := cache.SharedAviObjCache()
+	accessPolicyKey := cache.NamespaceName{Namespace: "admin", Name: accessPolicyName}
+	g.Eventually(func() bool {
+		_, found := mcache.HTTPPolicyCache.AviCacheGet(accessPolicyKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	// removing the access control from the hostrule removes the httppolicyset
+	hostrule.Spec.VirtualHost.AccessControl = akov1alpha1.AccessControl{}
+	hostrule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		return getAccessPolicy() == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.HTTPPolicyCache.AviCacheGet(accessPolicyKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
 
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
 
-func TestHostNameHTTPRuleHostSwitch(t *testing.T) {
-	// ingress foo.com/foo voo.com/foo
-	// hr1: foo.com (secure), hr2: voo.com (insecure)
-	// rr1: hr1/foo ALGO1
-	// switch rr1 host to hr2
+func TestHostnameHostRuleTLSControls(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	modelName := "admin/cluster--Shared-L7-0"
-	hrnameFoo := "samplehr-foo"
-	hrnameVoo := "samplehr-voo"
-	rrnameFoo := "samplerr-foo"
-
-	// creates foo.com insecure
-	SetUpIngressForCacheSyncCheck(t, modelName, false, false)
+	hrname := "samplehr-foo"
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	hostPolicyName := "cluster--foo.com-hostpolicy"
+	sslProfileName := "cluster--foo.com-sslprofile"
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
 
-	// creates voo.com insecure
-	ingressObject := integrationtest.FakeIngress{
-		Name:        "voo-with-targets",
-		Namespace:   "default",
-		DnsNames:    []string{"voo.com"},
-		Paths:       []string{"/foo"},
-		ServiceName: "avisvc",
-	}
-	ingrFake := ingressObject.Ingress()
-	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
-		t.Fatalf("error in updating Ingress: %v", err)
+	getRedirectPorts := func() []avinodes.AviRedirectPort {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return nil
+		}
+		var redirectPorts []avinodes.AviRedirectPort
+		for _, policy := range nodes[0].HttpPolicyRefs {
+			redirectPorts = append(redirectPorts, policy.RedirectPorts...)
+		}
+		return redirectPorts
+	}
+	getSniNode := func() *avinodes.AviVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		return nodes[0].SniNodes[0]
+	}
+
+	// an unsupported redirect status code must be rejected
+	hostrule := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.Redirect = akov1alpha1.HostRuleTLSRedirect{StatusCode: 308}
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// the sslProfile can not be combined with the TLS versions
+	hostrule.Spec.VirtualHost.TLS.Redirect = akov1alpha1.HostRuleTLSRedirect{StatusCode: 301}
+	hostrule.Spec.VirtualHost.TLS.SSLProfile = "thisisaviref-sslprofile"
+	hostrule.Spec.VirtualHost.TLS.MinVersion = "1.2"
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	hostrule.Spec.VirtualHost.TLS.SSLProfile = ""
+	hostrule.Spec.VirtualHost.TLS.HSTS = akov1alpha1.HostRuleHSTS{Enabled: true, IncludeSubDomains: true}
+	hostrule.Spec.VirtualHost.TLS.Ciphers = "ECDHE-ECDSA-AES256-GCM-SHA384"
+	hostrule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	g.Eventually(func() string {
+		redirectPorts := getRedirectPorts()
+		if len(redirectPorts) != 1 {
+			return ""
+		}
+		return redirectPorts[0].StatusCode
+	}, 10*time.Second).Should(gomega.Equal("HTTP_REDIRECT_STATUS_CODE_301"))
+	g.Expect(getRedirectPorts()[0].Hosts).To(gomega.Equal([]string{"foo.com"}))
+
+	g.Eventually(func() bool {
+		sniNode := getSniNode()
+		return sniNode != nil && sniNode.SSLProfileNode != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	sniNode := getSniNode()
+	g.Expect(sniNode.SSLProfileNode.Name).To(gomega.Equal(sslProfileName))
+	g.Expect(sniNode.SSLProfileNode.AcceptedVersions).To(gomega.Equal([]string{"SSL_VERSION_TLS1_2", "SSL_VERSION_TLS1_3"}))
+	g.Expect(sniNode.SSLProfileNode.AcceptedCiphers).To(gomega.Equal("ECDHE-ECDSA-AES256-GCM-SHA384"))
+	g.Expect(sniNode.SSLProfileRef).To(gomega.Equal("/api/sslprofile?name=" + sslProfileName))
+	var hsts *akov1alpha1.HostRuleHSTS
+	for _, policy := range sniNode.HttpPolicyRefs {
+		if policy.Name == hostPolicyName {
+			hsts = policy.HSTS
+		}
+	}
+	g.Expect(hsts).NotTo(gomega.BeNil())
+	g.Expect(hsts.IncludeSubDomains).To(gomega.Equal(true))
+
+	mcache := cache.SharedAviObjCache()
+	sslProfileKey := cache.NamespaceName{Namespace: "admin", Name: sslProfileName}
+	g.Eventually(func() bool {
+		_, found := mcache.SSLProfileCache.AviCacheGet(sslProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	// disabling the redirect removes the host from the redirect policy of the parent VS,
+	// and dropping the TLS versions and ciphers removes the sslprofile
+	disabled := false
+	hostrule.Spec.VirtualHost.TLS.Redirect = akov1alpha1.HostRuleTLSRedirect{Enabled: &disabled}
+	hostrule.Spec.VirtualHost.TLS.MinVersion = ""
+	hostrule.Spec.VirtualHost.TLS.Ciphers = ""
+	hostrule.ResourceVersion = "4"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getRedirectPorts())
+	}, 10*time.Second).Should(gomega.Equal(0))
+	g.Eventually(func() bool {
+		sniNode := getSniNode()
+		return sniNode != nil && sniNode.SSLProfileNode == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.SSLProfileCache.AviCacheGet(sslProfileKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+
+	// wait for the child vs to go away before the model is removed, so that its
+	// ssl key and certificate does not outlive this test
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(sniVSKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestHostnameHostRulePassthrough(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	passModelName := "admin/cluster--Shared-Passthrough-0"
+	hrname := "samplehr-foo"
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	passVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--Shared-Passthrough-0"}
+	objects.SharedAviGraphLister().Delete(passModelName)
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
+
+	getSniNodes := func() []*avinodes.AviVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 {
+			return nil
+		}
+		return nodes[0].SniNodes
+	}
+	g.Eventually(getSniNodes, 10*time.Second).Should(gomega.HaveLen(1))
+
+	// the sslKeyCertificate can not be used with passthrough
+	hostrule := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hostrule.Spec.VirtualHost.TLS.Termination = "passthrough"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+	g.Expect(getSniNodes()).To(gomega.HaveLen(1))
+
+	hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate = akov1alpha1.HostRuleSSLKeyCertificate{}
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	// the host moves from the child VS of the L7 shard to the passthrough shard
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com-avisvc"}))
+	g.Eventually(getSniNodes, 10*time.Second).Should(gomega.HaveLen(0))
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(passVSKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(sniVSKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+
+	// removing the hostrule brings the child VS back
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	g.Eventually(getSniNodes, 10*time.Second).Should(gomega.HaveLen(1))
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(sniVSKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName, passModelName)
+}
+
+func TestHostnameAuthRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	arname := "samplear-foo"
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	ssoPolicyKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com-ssopolicy"}
+	authProfileKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com-authprofile"}
+	jwtProfileKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com-jwtprofile"}
+	SetUpIngressForCacheSyncCheck(t, modelName, true, true)
+
+	getSniNode := func() *avinodes.AviVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		return nodes[0].SniNodes[0]
+	}
+	getStatus := func() string {
+		authrule, _ := CRDClient.AkoV1alpha1().AuthRules("default").Get(context.TODO(), arname, metav1.GetOptions{})
+		return authrule.Status.Status
+	}
+	inCache := func(c *cache.AviCache, k cache.NamespaceName) func() bool {
+		return func() bool {
+			_, found := c.AviCacheGet(k)
+			return found
+		}
+	}
+
+	// the authrule is rejected until the secret with the JWKS is available
+	authrule := integrationtest.FakeAuthRule{
+		Name:      arname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		Paths:     []string{"/api"},
+		JWKS:      "foo-jwks",
+	}.AuthRule()
+	if _, err := CRDClient.AkoV1alpha1().AuthRules("default").Create(context.TODO(), authrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding AuthRule: %v", err)
+	}
+	g.Eventually(getStatus, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	jwksSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-jwks"},
+		Data:       map[string][]byte{"jwks": []byte(`{"keys":[]}`)},
+	}
+	if _, err := KubeClient.CoreV1().Secrets("default").Create(context.TODO(), jwksSecret, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Secret: %v", err)
+	}
+	g.Eventually(getStatus, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	g.Eventually(func() bool {
+		sniNode := getSniNode()
+		return sniNode != nil && sniNode.SSOPolicyNode != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	ssoPolicyNode := getSniNode().SSOPolicyNode
+	g.Expect(ssoPolicyNode.Type).To(gomega.Equal("SSO_TYPE_JWT"))
+	g.Expect(ssoPolicyNode.AuthProfile).To(gomega.Equal(authProfileKey.Name))
+	g.Expect(ssoPolicyNode.Paths).To(gomega.Equal([]string{"/api"}))
+	g.Expect(ssoPolicyNode.JWTProfile.JwksKeys).To(gomega.Equal(`{"keys":[]}`))
+	g.Expect(*ssoPolicyNode.JwtConfig.Audience).To(gomega.Equal("foo.com"))
+	g.Expect(*ssoPolicyNode.JwtConfig.JwtLocation).To(gomega.Equal("JWT_LOCATION_AUTHORIZATION_HEADER"))
+
+	mcache := cache.SharedAviObjCache()
+	g.Eventually(inCache(mcache.SSOPolicyCache, ssoPolicyKey), 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(inCache(mcache.AuthProfileCache, authProfileKey), 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(inCache(mcache.JWTProfileCache, jwtProfileKey), 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() []cache.NamespaceName {
+		vsCache, found := mcache.VsCacheMeta.AviCacheGet(sniVSKey)
+		if !found {
+			return nil
+		}
+		return vsCache.(*cache.AviVsCache).SSOPolicyCollection
+	}, 10*time.Second).Should(gomega.Equal([]cache.NamespaceName{ssoPolicyKey}))
+
+	// deleting the JWKS secret rejects the authrule, the authentication of the VS stays
+	if err := KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "foo-jwks", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting Secret: %v", err)
+	}
+	g.Eventually(func() string {
+		authrule, _ := CRDClient.AkoV1alpha1().AuthRules("default").Get(context.TODO(), arname, metav1.GetOptions{})
+		return authrule.Status.Error
+	}, 10*time.Second).Should(gomega.ContainSubstring("invalid jwt jwks"))
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}).Ingress()
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Consistently(func() bool {
+		sniNode := getSniNode()
+		return sniNode != nil && sniNode.SSOPolicyNode != nil && sniNode.SSOPolicyNode.JWTProfile != nil
+	}, 3*time.Second).Should(gomega.Equal(true))
+	g.Expect(inCache(mcache.SSOPolicyCache, ssoPolicyKey)()).To(gomega.Equal(true))
+	g.Expect(inCache(mcache.JWTProfileCache, jwtProfileKey)()).To(gomega.Equal(true))
+
+	jwksSecret.ResourceVersion = ""
+	if _, err := KubeClient.CoreV1().Secrets("default").Create(context.TODO(), jwksSecret, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Secret: %v", err)
+	}
+	g.Eventually(getStatus, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	// jwt and authProfile can not be combined
+	authrule.Spec.AuthProfile = akov1alpha1.AuthRuleAuthProfile{
+		Name:            "thisisaviref-authprofile",
+		EntityID:        "foo.com",
+		SingleSignonURL: "https://foo.com/sso/acs/",
+	}
+	authrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().AuthRules("default").Update(context.TODO(), authrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating AuthRule: %v", err)
+	}
+	g.Eventually(getStatus, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// switching to SAML removes the profiles created for the JWT validation
+	authrule.Spec.JWT = akov1alpha1.AuthRuleJWT{}
+	authrule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().AuthRules("default").Update(context.TODO(), authrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating AuthRule: %v", err)
+	}
+	g.Eventually(getStatus, 10*time.Second).Should(gomega.Equal("Accepted"))
+	g.Eventually(func() string {
+		sniNode := getSniNode()
+		if sniNode == nil || sniNode.SSOPolicyNode == nil {
+			return ""
+		}
+		return sniNode.SSOPolicyNode.Type
+	}, 10*time.Second).Should(gomega.Equal("SSO_TYPE_SAML"))
+	ssoPolicyNode = getSniNode().SSOPolicyNode
+	g.Expect(ssoPolicyNode.AuthProfile).To(gomega.Equal("thisisaviref-authprofile"))
+	g.Expect(ssoPolicyNode.JWTProfile).To(gomega.BeNil())
+	g.Expect(*ssoPolicyNode.SamlSpConfig.SingleSignonURL).To(gomega.Equal("https://foo.com/sso/acs/"))
+	g.Eventually(inCache(mcache.AuthProfileCache, authProfileKey), 10*time.Second).Should(gomega.Equal(false))
+	g.Eventually(inCache(mcache.JWTProfileCache, jwtProfileKey), 10*time.Second).Should(gomega.Equal(false))
+	g.Expect(inCache(mcache.SSOPolicyCache, ssoPolicyKey)()).To(gomega.Equal(true))
+
+	integrationtest.TeardownAuthRule(t, arname)
+	g.Eventually(func() bool {
+		sniNode := getSniNode()
+		return sniNode != nil && sniNode.SSOPolicyNode == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(inCache(mcache.SSOPolicyCache, ssoPolicyKey), 10*time.Second).Should(gomega.Equal(false))
+
+	// wait for the child vs to go away before the model is removed, so that its
+	// ssl key and certificate does not outlive this test
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(sniVSKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "foo-jwks", metav1.DeleteOptions{})
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestHostnameInsecureHostAndHostrule(t *testing.T) {
+	// create insecure ingress, insecure hostrule, nothing should be applied
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-foo"
+	SetUpIngressForCacheSyncCheck(t, modelName, false, false)
+	integrationtest.SetupHostRule(t, hrname, "foo.com", false)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes[0].PoolRefs)
+	}, 10*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes).To(gomega.HaveLen(0))
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameValidToInvalidHostSwitch(t *testing.T) {
+	// create insecure host foo.com, attach hostrule, change hostrule to non existing bar.com
+	// foo.com should become insecure again
+	// change hostrule back to foo.com and it should become secure again
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrname := "samplehr-foo"
+	SetUpIngressForCacheSyncCheck(t, modelName, false, false)
+	integrationtest.SetupHostRule(t, hrname, "foo.com", true)
+
+	sniVSKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"}
+	integrationtest.VerifyMetadataHostRule(g, sniVSKey, "default/samplehr-foo", true)
+
+	hrUpdate := integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "bar.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hrUpdate.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes[0].PoolRefs)
+	}, 10*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs[0].Name).To(gomega.Equal("cluster--foo.com_foo-default-foo-with-targets"))
+
+	// change back to good host
+	hrUpdate = integrationtest.FakeHostRule{
+		Name:              hrname,
+		Namespace:         "default",
+		Fqdn:              "foo.com",
+		SslKeyCertificate: "thisisaviref-sslkey",
+	}.HostRule()
+	hrUpdate.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes[0].PoolRefs)
+	}, 10*time.Second).Should(gomega.Equal(0))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].Name).To(gomega.Equal("cluster--default-foo.com_foo-foo-with-targets"))
+
+	integrationtest.TeardownHostRule(t, g, sniVSKey, hrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+// HttpRule tests
+
+func TestHostnameHTTPRuleCreateDelete(t *testing.T) {
+	// ingress secure foo.com/foo /bar
+	// create httprule /foo, nothing happens
+	// create hostrule, httprule gets attached check on /foo /bar
+	// delete hostrule, httprule gets detached
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolFooKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets"}
+	poolBarKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_bar-foo-with-targets"}
+	integrationtest.SetupHTTPRule(t, rrname, "foo.com", "/")
+	integrationtest.VerifyMetadataHTTPRule(g, poolFooKey, "default/"+rrname, true)
+	integrationtest.VerifyMetadataHTTPRule(g, poolBarKey, "default/"+rrname, true)
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].LbAlgorithm).To(gomega.Equal("LB_ALGORITHM_CONSISTENT_HASH"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].LbAlgorithmHash).To(gomega.Equal("LB_ALGORITHM_CONSISTENT_HASH_SOURCE_IP_ADDRESS"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].SslProfileRef).To(gomega.ContainSubstring("thisisaviref-sslprofile"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].PkiProfile.CACert).To(gomega.Equal("httprule-destinationCA"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors).To(gomega.HaveLen(2))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors[0]).To(gomega.ContainSubstring("thisisaviref-hm2"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors[1]).To(gomega.ContainSubstring("thisisaviref-hm1"))
+
+	// delete httprule deletes refs as well
+	integrationtest.TeardownHTTPRule(t, rrname)
+	integrationtest.VerifyMetadataHTTPRule(g, poolFooKey, "default/"+rrname, false)
+	integrationtest.VerifyMetadataHTTPRule(g, poolBarKey, "default/"+rrname, false)
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].LbAlgorithm).To(gomega.Equal(""))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].SslProfileRef).To(gomega.Equal(""))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].PkiProfile).To(gomega.BeNil())
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].HealthMonitors).To(gomega.HaveLen(0))
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRuleClientCertificate(t *testing.T) {
+	// httprule refers to a missing client cert secret, gets rejected
+	// adding the secret accepts the httprule and the pool presents the key/cert
+	// updating the secret rotates the key/cert of the pool
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+	poolName := "cluster--default-foo.com_foo-foo-with-targets"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingressObject.Ingress(), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path:              "/",
+			DestinationCA:     "httprule-destinationCA",
+			ClientCertificate: "backend-client-cert",
+		}},
+	}.HTTPRule()
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	integrationtest.AddSecret("backend-client-cert", "default", "clientCert", "clientKey")
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	getPoolClientCert := func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 || len(nodes[0].SniNodes[0].PoolRefs) == 0 {
+			return ""
+		}
+		if sslKeyCert := nodes[0].SniNodes[0].PoolRefs[0].SSLKeyCert; sslKeyCert != nil {
+			return string(sslKeyCert.Cert)
+		}
+		return ""
+	}
+	g.Eventually(getPoolClientCert, 10*time.Second).Should(gomega.Equal("clientCert"))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].SSLKeyCert.Name).To(gomega.Equal(poolName + "-clientcert"))
+	g.Expect(string(nodes[0].SniNodes[0].PoolRefs[0].SSLKeyCert.Key)).To(gomega.Equal("clientKey"))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].PkiProfile.CACert).To(gomega.Equal("httprule-destinationCA"))
+
+	// the client key/cert is tracked by the pool and not the SNI VS
+	mcache := cache.SharedAviObjCache()
+	poolKey := cache.NamespaceName{Namespace: "admin", Name: poolName}
+	certKey := cache.NamespaceName{Namespace: "admin", Name: poolName + "-clientcert"}
+	g.Eventually(func() string {
+		if poolCache, found := mcache.PoolCache.AviCacheGet(poolKey); found {
+			return poolCache.(*cache.AviPoolCache).SSLKeyCertCollection.Name
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal(certKey.Name))
+	sniCache, found := mcache.VsCacheMeta.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"})
+	g.Expect(found).To(gomega.Equal(true))
+	g.Expect(sniCache.(*cache.AviVsCache).SSLKeyCertCollection).NotTo(gomega.ContainElement(certKey))
+
+	// rotate the client key/cert
+	secretUpdate := integrationtest.FakeSecret{
+		Cert:      "rotatedClientCert",
+		Key:       "rotatedClientKey",
+		Namespace: "default",
+		Name:      "backend-client-cert",
+	}.Secret()
+	secretUpdate.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Secrets("default").Update(context.TODO(), secretUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Secret: %v", err)
+	}
+	g.Eventually(getPoolClientCert, 10*time.Second).Should(gomega.Equal("rotatedClientCert"))
+	g.Eventually(func() string {
+		if sslCache, found := mcache.SSLKeyCache.AviCacheGet(certKey); found {
+			return sslCache.(*cache.AviSSLCache).Uuid
+		}
+		return ""
+	}, 10*time.Second).ShouldNot(gomega.BeEmpty())
+	sniCache, _ = mcache.VsCacheMeta.AviCacheGet(cache.NamespaceName{Namespace: "admin", Name: "cluster--foo.com"})
+	g.Expect(sniCache.(*cache.AviVsCache).SSLKeyCertCollection).NotTo(gomega.ContainElement(certKey))
+
+	// delete httprule removes the key/cert from the pool
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return nodes[0].SniNodes[0].PoolRefs[0].SSLKeyCert == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(func() bool {
+		_, found := mcache.SSLKeyCache.AviCacheGet(certKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "backend-client-cert", metav1.DeleteOptions{})
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func getSniPathActions(modelName, httpPolName string) *avinodes.AviHTTPPathActions {
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+		return nil
+	}
+	for _, policy := range nodes[0].SniNodes[0].HttpPolicyRefs {
+		if policy.Name == httpPolName && len(policy.HppMap) > 0 {
+			return policy.HppMap[0].PathActions
+		}
+	}
+	return nil
+}
+
+func TestHostnameHTTPRulePathActions(t *testing.T) {
+	// ingress secure foo.com/foo /bar
+	// create httprule /foo with header and rewrite actions, attached to the /foo httppolicyset only
+	// update httprule /foo to a redirect, delete httprule removes the actions
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httpPolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+	httpPolBar := "cluster--default-foo.com_bar-foo-with-targets"
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].RequestHeaders = akov1alpha1.HTTPRuleHeaders{
+		Add:    []akov1alpha1.HTTPRuleHeader{{Name: "X-Forwarded-Prefix", Value: "/foo"}},
+		Remove: []string{"X-Debug"},
+	}
+	httprule.Spec.Paths[0].ResponseHeaders = akov1alpha1.HTTPRuleHeaders{
+		Replace: []akov1alpha1.HTTPRuleHeader{{Name: "Server", Value: "ako"}},
+	}
+	httprule.Spec.Paths[0].Rewrite = akov1alpha1.HTTPRuleRewrite{StripPathPrefix: true, Host: "internal.foo.com"}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		pathActions := getSniPathActions(modelName, httpPolFoo)
+		return pathActions != nil && pathActions.Rewrite.StripPathPrefix
+	}, 10*time.Second).Should(gomega.Equal(true))
+	pathActions := getSniPathActions(modelName, httpPolFoo)
+	g.Expect(pathActions.Target).To(gomega.Equal("/foo"))
+	g.Expect(pathActions.Rewrite.Host).To(gomega.Equal("internal.foo.com"))
+	g.Expect(pathActions.RequestHeaders.Add).To(gomega.HaveLen(1))
+	g.Expect(pathActions.RequestHeaders.Remove).To(gomega.Equal([]string{"X-Debug"}))
+	g.Expect(pathActions.ResponseHeaders.Replace[0].Value).To(gomega.Equal("ako"))
+	g.Expect(getSniPathActions(modelName, httpPolBar)).To(gomega.BeNil())
+
+	// rewrite and redirect are mutually exclusive, the httprule is rejected
+	httprule.Spec.Paths[0].Redirect = akov1alpha1.HTTPRuleRedirect{Host: "bar.com", StatusCode: 301}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	httprule.Spec.Paths[0].Rewrite = akov1alpha1.HTTPRuleRewrite{}
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int32 {
+		if pathActions := getSniPathActions(modelName, httpPolFoo); pathActions != nil {
+			return pathActions.Redirect.StatusCode
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(int32(301)))
+	g.Expect(getSniPathActions(modelName, httpPolFoo).Redirect.Host).To(gomega.Equal("bar.com"))
+
+	// delete httprule removes the actions
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(func() bool {
+		return getSniPathActions(modelName, httpPolFoo) == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRulePathActionsInsecureHost(t *testing.T) {
+	// ingress insecure foo.com/foo
+	// create httprule /foo with header actions, the httprule is rejected
+	// update ingress to secure, the httprule is accepted and the actions attached to the /foo httppolicyset
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetUpIngressForCacheSyncCheck(t, modelName, false, true)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].RequestHeaders = akov1alpha1.HTTPRuleHeaders{
+		Add: []akov1alpha1.HTTPRuleHeader{{Name: "X-Forwarded-Prefix", Value: "/foo"}},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+	httprule, _ = CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+	g.Expect(httprule.Status.Error).To(gomega.ContainSubstring("host foo.com is insecure"))
+
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+	ingrFake := ingressObject.Ingress()
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+	g.Eventually(func() bool {
+		return getSniPathActions(modelName, "cluster--default-foo.com_foo-foo-with-targets") != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRuleRateLimit(t *testing.T) {
+	// ingress secure foo.com/foo /bar
+	// create httprule /foo with a rate limit and connection limit, attached to the /foo pool and httppolicyset only
+	// update httprule /foo with access control, attached to the /foo httppolicyset only
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingressObject.Ingress(true), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httpPolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+	httpPolBar := "cluster--default-foo.com_bar-foo-with-targets"
+	poolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+
+	// the header key is supported on hosts only, the httprule is rejected
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].RateLimit = akov1alpha1.RateLimit{
+		RequestsPerSecond: 10,
+		Key:               "header",
+		Header:            "X-Api-Key",
+	}
+	httprule.Spec.Paths[0].MaxConnectionsPerServer = 25
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	httprule.Spec.Paths[0].RateLimit = akov1alpha1.RateLimit{
+		RequestsPerSecond: 10,
+		Key:               "clientIP",
+		Action:            akov1alpha1.RateLimitAction{Type: "drop"},
+	}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int32 {
+		if pathActions := getSniPathActions(modelName, httpPolFoo); pathActions != nil {
+			return pathActions.RateLimit.RequestsPerSecond
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(int32(10)))
+	g.Expect(getSniPathActions(modelName, httpPolFoo).RateLimit.Action.Type).To(gomega.Equal("drop"))
+	g.Expect(getSniPathActions(modelName, httpPolBar)).To(gomega.BeNil())
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes).To(gomega.HaveLen(1))
+	for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+		if pool.Name == poolFoo {
+			g.Expect(pool.MaxConcurrentConnectionsPerServer).To(gomega.Equal(int32(25)))
+		} else {
+			g.Expect(pool.MaxConcurrentConnectionsPerServer).To(gomega.Equal(int32(0)))
+		}
+	}
+
+	// access control is set on the /foo httppolicyset along with the rate limit
+	httprule.Spec.Paths[0].AccessControl = akov1alpha1.AccessControl{DenyCIDRs: []string{"192.168.1.0/24"}}
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int {
+		if pathActions := getSniPathActions(modelName, httpPolFoo); pathActions != nil {
+			return len(pathActions.AccessControl.DenyCIDRs)
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(1))
+	g.Expect(getSniPathActions(modelName, httpPolBar)).To(gomega.BeNil())
+
+	// delete httprule removes the limits
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(func() bool {
+		return getSniPathActions(modelName, httpPolFoo) == nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRuleWeightedBackends(t *testing.T) {
+	// ingress secure foo.com/foo
+	// create httprule /foo splitting traffic 80:20 with avisvc2 and a header canary to avisvc3
+	// remove the backends and canary from the httprule, the extra pools get removed
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "default", "avisvc2", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc2", false, false, "2.2.2")
+	integrationtest.CreateSVC(t, "default", "avisvc3", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc3", false, false, "3.3.3")
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingressObject.Ingress(), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+	getSniNode := func() *avinodes.AviVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		return nodes[0].SniNodes[0]
+	}
+	getSniPoolNames := func() []string {
+		var poolNames []string
+		if sniNode := getSniNode(); sniNode != nil {
+			for _, pool := range sniNode.PoolRefs {
+				poolNames = append(poolNames, pool.Name)
+			}
+		}
+		sort.Strings(poolNames)
+		return poolNames
+	}
+	g.Eventually(getSniPoolNames, 10*time.Second).Should(gomega.HaveLen(1))
+
+	weight, backendWeight := int32(80), int32(20)
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].Weight = &weight
+	httprule.Spec.Paths[0].Backends = []akov1alpha1.HTTPRuleBackend{{ServiceName: "avisvc2", Weight: &backendWeight}}
+	httprule.Spec.Paths[0].Canary = akov1alpha1.HTTPRuleCanary{
+		ServiceName: "avisvc3",
+		Header:      akov1alpha1.HTTPRuleHeader{Name: "X-Canary", Value: "always"},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	g.Eventually(getSniPoolNames, 10*time.Second).Should(gomega.Equal([]string{
+		poolFoo,
+		poolFoo + "-avisvc2",
+		poolFoo + "-avisvc3",
+	}))
+	// the services referred to only by the httprule map to the ingress
+	_, ingresses := objects.SharedSvcLister().IngressMappings("default").GetSvcToIng("avisvc3")
+	g.Expect(ingresses).To(gomega.ContainElement("foo-with-targets"))
+	sniNode := getSniNode()
+	pgNode := sniNode.GetPGForVSByName(poolFoo)
+	g.Expect(pgNode.Members).To(gomega.HaveLen(2))
+	var ratios []int32
+	for _, member := range pgNode.Members {
+		ratios = append(ratios, *member.Ratio)
+	}
+	g.Expect(ratios).To(gomega.ConsistOf(int32(80), int32(20)))
+	g.Expect(sniNode.GetPGForVSByName(poolFoo + "-avisvc3").Members).To(gomega.HaveLen(1))
+	for _, pool := range sniNode.PoolRefs {
+		if pool.Name == poolFoo+"-avisvc2" {
+			g.Expect(pool.Servers).To(gomega.HaveLen(1))
+			g.Expect(*pool.Servers[0].Ip.Addr).To(gomega.Equal("2.2.2.1"))
+		}
+	}
+	for _, policy := range sniNode.HttpPolicyRefs {
+		if policy.Name == poolFoo {
+			g.Expect(policy.HppMap).To(gomega.HaveLen(2))
+			g.Expect(policy.HppMap[0].Canary.Header.Name).To(gomega.Equal("X-Canary"))
+			g.Expect(policy.HppMap[0].PoolGroup).To(gomega.Equal(poolFoo + "-avisvc3"))
+			g.Expect(policy.HppMap[1].Canary).To(gomega.BeNil())
+			g.Expect(policy.HppMap[1].PoolGroup).To(gomega.Equal(poolFoo))
+		}
+	}
+
+	// removing the backends and canary from the httprule removes their pools
+	httprule.Spec.Paths[0].Weight = nil
+	httprule.Spec.Paths[0].Backends = nil
+	httprule.Spec.Paths[0].Canary = akov1alpha1.HTTPRuleCanary{}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(getSniPoolNames, 10*time.Second).Should(gomega.Equal([]string{
+		poolFoo,
+	}))
+	g.Expect(getSniNode().GetPGForVSByName(poolFoo + "-avisvc3")).To(gomega.BeNil())
+	g.Expect(getSniNode().GetPGForVSByName(poolFoo).Members).To(gomega.HaveLen(1))
+	_, ingresses = objects.SharedSvcLister().IngressMappings("default").GetSvcToIng("avisvc3")
+	g.Expect(ingresses).NotTo(gomega.ContainElement("foo-with-targets"))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+	integrationtest.DelSVC(t, "default", "avisvc2")
+	integrationtest.DelEP(t, "default", "avisvc2")
+	integrationtest.DelSVC(t, "default", "avisvc3")
+	integrationtest.DelEP(t, "default", "avisvc3")
+}
+
+func TestHostnameHTTPRuleCanaryInsecureHost(t *testing.T) {
+	// ingress insecure foo.com/foo
+	// create httprule /foo with a weighted backend and a canary, the httprule is rejected and its backends skipped
+	// update ingress to secure, the httprule is accepted and the backends added to the sni child
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	integrationtest.CreateSVC(t, "default", "avisvc2", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc2", false, false, "2.2.2")
+	integrationtest.CreateSVC(t, "default", "avisvc3", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "avisvc3", false, false, "3.3.3")
+	SetUpIngressForCacheSyncCheck(t, modelName, false, true)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].Backends = []akov1alpha1.HTTPRuleBackend{{ServiceName: "avisvc2"}}
+	httprule.Spec.Paths[0].Canary = akov1alpha1.HTTPRuleCanary{
+		ServiceName: "avisvc3",
+		Header:      akov1alpha1.HTTPRuleHeader{Name: "X-Canary", Value: "always"},
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Error
+	}, 10*time.Second).Should(gomega.ContainSubstring("host foo.com is insecure"))
+	g.Consistently(func() []string {
+		return getPoolNames(modelName)
+	}, 2*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_foo-default-foo-with-targets"}))
+
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+	ingrFake := ingressObject.Ingress()
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+	poolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+	g.Eventually(func() []string {
+		var poolNames []string
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 && len(nodes[0].SniNodes) > 0 {
+			for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+				poolNames = append(poolNames, pool.Name)
+			}
+		}
+		sort.Strings(poolNames)
+		return poolNames
+	}, 10*time.Second).Should(gomega.Equal([]string{poolFoo, poolFoo + "-avisvc2", poolFoo + "-avisvc3"}))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+	integrationtest.DelSVC(t, "default", "avisvc2")
+	integrationtest.DelEP(t, "default", "avisvc2")
+	integrationtest.DelSVC(t, "default", "avisvc3")
+	integrationtest.DelEP(t, "default", "avisvc3")
+}
+
+func TestHostnameHTTPRuleHealthMonitorSpecs(t *testing.T) {
+	// ingress secure foo.com/foo /bar
+	// create httprule /foo with a healthmonitor spec, the healthmonitor gets created for the /foo pool
+	// invalid timeout rejects the httprule, removing the spec deletes the healthmonitor
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	mcache := cache.SharedAviObjCache()
+	poolFooKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets"}
+	hmKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets-hm-http-check"}
+	getPoolHMNodes := func(poolName string) []*avinodes.AviHealthMonitorNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+			if pool.Name == poolName {
+				return pool.HealthMonitorNodes
+			}
+		}
+		return nil
+	}
+	getPoolCacheHMs := func() []cache.NamespaceName {
+		if poolCache, found := mcache.PoolCache.AviCacheGet(poolFooKey); found {
+			return poolCache.(*cache.AviPoolCache).HealthMonitorCollection
+		}
+		return nil
+	}
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].HealthMonitorSpecs = []akov1alpha1.HTTPRuleHealthMonitor{{
+		Name:          "http-check",
+		Type:          "HTTP",
+		Path:          "/healthz",
+		ExpectedCodes: []string{"2xx"},
+		Interval:      5,
+	}}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	g.Eventually(func() []*avinodes.AviHealthMonitorNode {
+		return getPoolHMNodes(poolFooKey.Name)
+	}, 10*time.Second).Should(gomega.HaveLen(1))
+	hmNode := getPoolHMNodes(poolFooKey.Name)[0]
+	g.Expect(hmNode.Name).To(gomega.Equal(hmKey.Name))
+	g.Expect(hmNode.Type).To(gomega.Equal("HEALTH_MONITOR_HTTP"))
+	g.Expect(hmNode.HTTPRequest).To(gomega.Equal("GET /healthz HTTP/1.0"))
+	g.Expect(hmNode.HTTPResponseCodes).To(gomega.Equal([]string{"HTTP_2XX"}))
+	g.Expect(hmNode.SendInterval).To(gomega.Equal(int32(5)))
+	g.Expect(hmNode.ReceiveTimeout).To(gomega.Equal(int32(4)))
+	g.Expect(getPoolHMNodes("cluster--default-foo.com_bar-foo-with-targets")).To(gomega.HaveLen(0))
+
+	g.Eventually(func() bool {
+		_, found := mcache.HealthMonitorCache.AviCacheGet(hmKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(getPoolCacheHMs, 10*time.Second).Should(gomega.Equal([]cache.NamespaceName{hmKey}))
+
+	// timeout must be less than the interval, the httprule is rejected
+	httprule.Spec.Paths[0].HealthMonitorSpecs[0].Timeout = 5
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// removing the spec deletes the healthmonitor
+	httprule.Spec.Paths[0].HealthMonitorSpecs = nil
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		_, found := mcache.HealthMonitorCache.AviCacheGet(hmKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	g.Expect(getPoolHMNodes(poolFooKey.Name)).To(gomega.HaveLen(0))
+	g.Eventually(getPoolCacheHMs, 10*time.Second).Should(gomega.HaveLen(0))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRulePersistence(t *testing.T) {
+	// ingress secure foo.com/foo /bar
+	// create httprule /foo with cookie persistence, the persistence profile gets created for the /foo pool
+	// header persistence without a header name rejects the httprule, referring to a profile deletes the created one
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	mcache := cache.SharedAviObjCache()
+	poolFooKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets"}
+	persistenceKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--default-foo.com_foo-foo-with-targets-persistence"}
+	getPoolNode := func(poolName string) *avinodes.AviPoolNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+			if pool.Name == poolName {
+				return pool
+			}
+		}
+		return nil
+	}
+	getPoolCachePersistence := func() cache.NamespaceName {
+		if poolCache, found := mcache.PoolCache.AviCacheGet(poolFooKey); found {
+			return poolCache.(*cache.AviPoolCache).PersistenceProfileCollection
+		}
+		return cache.NamespaceName{}
+	}
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].Persistence = akov1alpha1.HTTPRulePersistence{
+		Type:       "cookie",
+		CookieName: "foo-session",
+		Timeout:    30,
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		pool := getPoolNode(poolFooKey.Name)
+		return pool != nil && pool.PersistenceProfile != nil
+	}, 10*time.Second).Should(gomega.Equal(true))
+	persistenceNode := getPoolNode(poolFooKey.Name).PersistenceProfile
+	g.Expect(persistenceNode.Name).To(gomega.Equal(persistenceKey.Name))
+	g.Expect(persistenceNode.PersistenceType).To(gomega.Equal("PERSISTENCE_TYPE_HTTP_COOKIE"))
+	g.Expect(persistenceNode.CookieName).To(gomega.Equal("foo-session"))
+	g.Expect(persistenceNode.Timeout).To(gomega.Equal(int32(30)))
+	g.Expect(getPoolNode("cluster--default-foo.com_bar-foo-with-targets").PersistenceProfile).To(gomega.BeNil())
+
+	g.Eventually(func() bool {
+		_, found := mcache.PersistenceProfileCache.AviCacheGet(persistenceKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Eventually(getPoolCachePersistence, 10*time.Second).Should(gomega.Equal(persistenceKey))
+
+	// header persistence requires the header name, the httprule is rejected
+	httprule.Spec.Paths[0].Persistence = akov1alpha1.HTTPRulePersistence{Type: "header"}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// referring to a persistence profile on the controller deletes the one created by AKO
+	httprule.Spec.Paths[0].Persistence = akov1alpha1.HTTPRulePersistence{Profile: "thisisaviref-persistence"}
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		pool := getPoolNode(poolFooKey.Name)
+		if pool == nil {
+			return ""
+		}
+		return pool.ApplicationPersistenceProfileRef
+	}, 10*time.Second).Should(gomega.Equal("/api/applicationpersistenceprofile?name=thisisaviref-persistence"))
+	g.Expect(getPoolNode(poolFooKey.Name).PersistenceProfile).To(gomega.BeNil())
+	g.Eventually(func() bool {
+		_, found := mcache.PersistenceProfileCache.AviCacheGet(persistenceKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	g.Eventually(getPoolCachePersistence, 10*time.Second).Should(gomega.Equal(cache.NamespaceName{}))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRuleConnection(t *testing.T) {
+	// ingress secure foo.com/foo /bar
+	// create httprule /foo with timeouts, connection reuse and retries, the /foo pool gets the settings
+	// retryTimeout without retries rejects the httprule, removing the settings resets the pool
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+	poolBar := "cluster--default-foo.com_bar-foo-with-targets"
+	getPoolNode := func(poolName string) *avinodes.AviPoolNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return nil
+		}
+		for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+			if pool.Name == poolName {
+				return pool
+			}
+		}
+		return nil
+	}
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].Connection = akov1alpha1.HTTPRuleConnection{
+		RequestTimeout: 120,
+		IdleTimeout:    600,
+		DisableReuse:   true,
+		Retries:        2,
+		RetryTimeout:   10,
+	}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	g.Eventually(func() int32 {
+		if pool := getPoolNode(poolFoo); pool != nil {
+			return pool.ServerTimeout
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(int32(120000)))
+	pool := getPoolNode(poolFoo)
+	g.Expect(pool.ConnIdleTimeout).To(gomega.Equal(int32(600000)))
+	g.Expect(pool.ConnMaxReuse).To(gomega.Equal(int32(1)))
+	g.Expect(pool.RetryCount).To(gomega.Equal(int32(2)))
+	g.Expect(pool.RetryTimeout).To(gomega.Equal(int32(10000)))
+	g.Expect(getPoolNode(poolBar).ServerTimeout).To(gomega.Equal(int32(0)))
+	g.Expect(getPoolNode(poolBar).RetryCount).To(gomega.Equal(int32(0)))
+
+	// retryTimeout applies to the retries only, the httprule is rejected
+	httprule.Spec.Paths[0].Connection = akov1alpha1.HTTPRuleConnection{RetryTimeout: 10}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// idleTimeout above the controller limit, the httprule is rejected
+	httprule.Spec.Paths[0].Connection = akov1alpha1.HTTPRuleConnection{IdleTimeout: 3601}
+	httprule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Error
+	}, 10*time.Second).Should(gomega.ContainSubstring("connection timeouts exceed 3600 seconds"))
+
+	// removing the settings resets the pool
+	httprule.Spec.Paths[0].Connection = akov1alpha1.HTTPRuleConnection{MaxReuse: 100}
+	httprule.ResourceVersion = "4"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int32 {
+		if pool := getPoolNode(poolFoo); pool != nil {
+			return pool.ConnMaxReuse
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(int32(100)))
+	pool = getPoolNode(poolFoo)
+	g.Expect(pool.ServerTimeout).To(gomega.Equal(int32(0)))
+	g.Expect(pool.ConnIdleTimeout).To(gomega.Equal(int32(0)))
+	g.Expect(pool.RetryCount).To(gomega.Equal(int32(0)))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostnameHTTPRuleBackendProtocol(t *testing.T) {
+	// service avisvc with appProtocol h2c, ingress secure foo.com/foo /bar
+	// the pools speak HTTP/2 to the servers and the SSL port of the parent serves HTTP/2
+	// httprule /foo with backendProtocol http1 overrides the appProtocol for the /foo pool
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	setAppProtocol := func(appProtocol *string, resourceVersion string) {
+		svc, err := KubeClient.CoreV1().Services("default").Get(context.TODO(), "avisvc", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error in getting Service: %v", err)
+		}
+		svc.Spec.Ports[0].AppProtocol = appProtocol
+		svc.ResourceVersion = resourceVersion
+		if _, err := KubeClient.CoreV1().Services("default").Update(context.TODO(), svc, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating Service: %v", err)
+		}
+	}
+	appProtocol := "kubernetes.io/h2c"
+	setAppProtocol(&appProtocol, "2")
+
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	poolFoo := "cluster--default-foo.com_foo-foo-with-targets"
+	poolBar := "cluster--default-foo.com_bar-foo-with-targets"
+	getPoolHTTP2 := func(poolName string) bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].SniNodes) == 0 {
+			return false
+		}
+		for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+			if pool.Name == poolName {
+				return pool.EnableHTTP2
+			}
+		}
+		return false
+	}
+	getParentHTTP2 := func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		return len(nodes) > 0 && nodes[0].HasHTTP2Pools()
+	}
+
+	g.Eventually(func() bool {
+		return getPoolHTTP2(poolFoo)
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Expect(getPoolHTTP2(poolBar)).To(gomega.Equal(true))
+	g.Expect(getParentHTTP2()).To(gomega.Equal(true))
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].BackendProtocol = "http1"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		return getPoolHTTP2(poolFoo)
+	}, 10*time.Second).Should(gomega.Equal(false))
+	g.Expect(getPoolHTTP2(poolBar)).To(gomega.Equal(true))
+
+	// HTTP health monitors can not probe the gRPC servers, the httprule is rejected
+	httprule.Spec.Paths[0].BackendProtocol = "grpc"
+	httprule.Spec.Paths[0].HealthMonitorSpecs = []akov1alpha1.HTTPRuleHealthMonitor{{Name: "foo-hm", Type: "HTTP"}}
+	httprule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	// without the appProtocol only the grpc path speaks HTTP/2
+	integrationtest.TeardownHTTPRule(t, rrname)
+	setAppProtocol(nil, "3")
+	g.Eventually(getParentHTTP2, 10*time.Second).Should(gomega.Equal(false))
+
+	httprule = integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].BackendProtocol = "grpc"
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		return getPoolHTTP2(poolFoo)
+	}, 10*time.Second).Should(gomega.Equal(true))
+	g.Expect(getPoolHTTP2(poolBar)).To(gomega.Equal(false))
+	g.Expect(getParentHTTP2()).To(gomega.Equal(true))
+	// the grpc path is accepted with a warning, the gRPC servers are health checked over TCP only
+	g.Eventually(func() string {
+		httprule, _ := CRDClient.AkoV1alpha1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status + " " + httprule.Status.Warning
+	}, 10*time.Second).Should(gomega.ContainSubstring("Accepted gRPC health checks are not supported"))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, modelName)
+}
+
+func TestHostNameHTTPRuleHostSwitch(t *testing.T) {
+	// ingress foo.com/foo voo.com/foo
+	// hr1: foo.com (secure), hr2: voo.com (insecure)
+	// rr1: hr1/foo ALGO1
+	// switch rr1 host to hr2
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	hrnameFoo := "samplehr-foo"
+	hrnameVoo := "samplehr-voo"
+	rrnameFoo := "samplerr-foo"
+
+	// creates foo.com insecure
+	SetUpIngressForCacheSyncCheck(t, modelName, false, false)
+
+	// creates voo.com insecure
+	ingressObject := integrationtest.FakeIngress{
+		Name:        "voo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"voo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}
+	ingrFake := ingressObject.Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
 	}
 
 	integrationtest.SetupHTTPRule(t, rrnameFoo, "foo.com", "/foo")
@@ -578,3 +2415,101 @@ func TestHostNameHTTPRuleHostSwitch(t *testing.T) {
 	integrationtest.TeardownHTTPRule(t, rrnameFoo)
 	TearDownIngressForCacheSyncCheck(t, modelName)
 }
+
+func TestHostnameWildcardFqdnHostRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// test.bar.com is sharded to Shared-L7-5
+	modelName := "admin/cluster--Shared-L7-5"
+	hrname := "samplehr-wildcard"
+	SetupDomain()
+	SetUpTestForIngress(t, modelName)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"test.bar.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"test.bar.com"},
+		},
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	hostrule := integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "*.bar.com",
+		FqdnType:  "Wildcard",
+		WafPolicy: "thisisaviref-waf",
+	}.HostRule()
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Create(context.TODO(), hostrule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes[0].SniNodes) == 1 {
+			return nodes[0].SniNodes[0].WafPolicyRef
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.ContainSubstring("thisisaviref-waf"))
+
+	// switch to a regex fqdn that does not match the host
+	hostrule = integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "prod-.*\\.bar\\.com",
+		FqdnType:  "Regex",
+		WafPolicy: "thisisaviref-waf",
+	}.HostRule()
+	hostrule.ResourceVersion = "2"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes[0].SniNodes) == 1 {
+			return nodes[0].SniNodes[0].WafPolicyRef
+		}
+		return "unexpected"
+	}, 10*time.Second).Should(gomega.Equal(""))
+
+	// invalid wildcard fqdn must be rejected
+	hostrule = integrationtest.FakeHostRule{
+		Name:      hrname,
+		Namespace: "default",
+		Fqdn:      "test.bar.com",
+		FqdnType:  "Wildcard",
+		WafPolicy: "thisisaviref-waf",
+	}.HostRule()
+	hostrule.ResourceVersion = "3"
+	if _, err := CRDClient.AkoV1alpha1().HostRules("default").Update(context.TODO(), hostrule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HostRule: %v", err)
+	}
+	g.Eventually(func() string {
+		hostrule, _ := CRDClient.AkoV1alpha1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+
+	if err := CRDClient.AkoV1alpha1().HostRules("default").Delete(context.TODO(), hrname, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting HostRule: %v", err)
+	}
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName)
+}
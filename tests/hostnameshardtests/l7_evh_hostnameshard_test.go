@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	utils "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -1994,3 +1996,123 @@ func TestL7WrongSubDomainMultiSNIForEvh(t *testing.T) {
 
 	TearDownTestForIngress(t, modelName)
 }
+
+func TestHostnameIngressPassthroughForEvh(t *testing.T) {
+	integrationtest.EnableEVH()
+	defer integrationtest.DisableEVH()
+
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-EVH-0"
+	passModelName := "admin/cluster--Shared-Passthrough-0"
+	SetUpTestForIngress(t, modelName, passModelName)
+
+	getEvhNodeCount := func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes)
+	}
+	updateIngress := func(annotations map[string]string, resourceVersion string) {
+		ingrFake := integrationtest.FakeIngress{
+			Name:        "foo-passthrough",
+			Namespace:   "default",
+			DnsNames:    []string{"foo.com"},
+			ServiceName: "avisvc",
+		}.Ingress()
+		ingrFake.Annotations = annotations
+		ingrFake.ResourceVersion = resourceVersion
+		if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating Ingress: %v", err)
+		}
+	}
+
+	ingrFake := integrationtest.FakeIngress{
+		Name:        "foo-passthrough",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		ServiceName: "avisvc",
+	}.Ingress()
+	ingrFake.Annotations = map[string]string{lib.PassthroughAnnotation: "true"}
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com-avisvc"}))
+	g.Expect(getEvhNodeCount()).To(gomega.Equal(0))
+
+	// without the annotation the host gets a child VS on the EVH shard
+	updateIngress(nil, "2")
+	g.Eventually(getEvhNodeCount, 10*time.Second).Should(gomega.Equal(1))
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+
+	// and moves back to the passthrough shard along with the annotation
+	updateIngress(map[string]string{lib.PassthroughAnnotation: "true"}, "3")
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com-avisvc"}))
+	g.Eventually(getEvhNodeCount, 10*time.Second).Should(gomega.Equal(0))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-passthrough", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	TearDownTestForIngress(t, modelName, passModelName)
+}
+
+func TestHostnameIngressDualCertForEvh(t *testing.T) {
+	integrationtest.EnableEVH()
+	defer integrationtest.DisableEVH()
+
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-EVH-0"
+	SetUpTestForIngress(t, modelName)
+
+	getCertNames := func() []string {
+		var certNames []string
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS(); len(nodes) > 0 {
+				for _, certNode := range nodes[0].SSLKeyCertRefs {
+					certNames = append(certNames, certNode.Name)
+				}
+			}
+		}
+		sort.Strings(certNames)
+		return certNames
+	}
+
+	rsaCert, rsaKey := integrationtest.GenerateRSACert([]string{"foo.com"}, 241*time.Hour)
+	ecCert, ecKey := integrationtest.GenerateCert([]string{"foo.com"}, 241*time.Hour)
+	integrationtest.AddSecret("rsa-secret", "default", rsaCert, rsaKey)
+	integrationtest.AddSecret("ec-secret", "default", ecCert, ecKey)
+	ingrFake := integrationtest.FakeIngress{
+		Name:         "foo-dual",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"ec-secret": {"foo.com"}},
+	}.Ingress()
+	ingrFake.Spec.TLS = append(ingrFake.Spec.TLS, networking.IngressTLS{Hosts: []string{"foo.com"}, SecretName: "rsa-secret"})
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com", "cluster--foo.com-alt"}))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-dual", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.HaveLen(0))
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "rsa-secret", metav1.DeleteOptions{})
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "ec-secret", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName)
+}
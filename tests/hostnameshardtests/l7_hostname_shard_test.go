@@ -16,14 +16,18 @@ package hostnameshardtests
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/client/v1alpha1/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
@@ -38,8 +42,10 @@ import (
 	"github.com/avinetworks/sdk/go/models"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 var KubeClient *k8sfake.Clientset
@@ -2881,3 +2887,925 @@ func TestAddIngressDefaultCertAddAnnotation(t *testing.T) {
 	}
 	TearDownTestForIngress(t, modelName)
 }
+
+func TestWildcardHostIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// *.bar.com and test.bar.com compute the same hashed shard vs num
+	modelName := "admin/cluster--Shared-L7-5"
+	SetUpTestForIngress(t, modelName)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "ingress-wildcard",
+		Namespace:   "default",
+		DnsNames:    []string{"*.bar.com", "test.bar.com"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	for _, pool := range nodes[0].PoolRefs {
+		if pool.Name == "cluster--_wildcard.bar.com_foo-default-ingress-wildcard" {
+			g.Expect(pool.PriorityLabel).To(gomega.Equal("*.bar.com/foo"))
+		} else if pool.Name == "cluster--test.bar.com_bar-default-ingress-wildcard" {
+			g.Expect(pool.PriorityLabel).To(gomega.Equal("test.bar.com/bar"))
+		} else {
+			t.Fatalf("unexpected pool: %s", pool.Name)
+		}
+	}
+	// the exact host should take precedence over the wildcard host in the datascript
+	g.Expect(nodes[0].HTTPDSrefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].HTTPDSrefs[0].Script).To(gomega.ContainSubstring(`exact_hosts = {["test.bar.com"]=true}`))
+	g.Expect(nodes[0].HTTPDSrefs[0].Script).To(gomega.ContainSubstring(`wildcard_suffixes = {".bar.com"}`))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "ingress-wildcard", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].HTTPDSrefs[0].Script
+	}, 10*time.Second).ShouldNot(gomega.ContainSubstring("wildcard_suffixes"))
+
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestWildcardHostSNIIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	integrationtest.AddSecret("my-secret", "default", "tlsCert", "tlsKey")
+	modelName := "admin/cluster--Shared-L7-5"
+	SetUpTestForIngress(t, modelName)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:      "ingress-wildcard-sni",
+		Namespace: "default",
+		DnsNames:  []string{"*.bar.com"},
+		Ips:       []string{"8.8.8.8"},
+		HostNames: []string{"v1"},
+		TlsSecretDNS: map[string][]string{
+			"my-secret": {"*.bar.com"},
+		},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].SniNodes)
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].SniNodes[0].Name).To(gomega.Equal("cluster--_wildcard.bar.com"))
+	g.Expect(nodes[0].SniNodes[0].VHDomainNames).To(gomega.Equal([]string{"*.bar.com"}))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].SniNodes[0].PoolRefs[0].Name).To(gomega.ContainSubstring("_wildcard.bar.com"))
+	g.Expect(nodes[0].HttpPolicyRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].HttpPolicyRefs[0].RedirectPorts[0].Hosts).To(gomega.Equal([]string{"*.bar.com"}))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "ingress-wildcard-sni", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	VerifySNIIngressDeletion(t, g, aviModel, 0)
+
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestInvalidWildcardHostIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, integrationtest.AllModels...)
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "ingress-invalid-wildcard",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.*.com", "foo.com"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs[0].PriorityLabel).To(gomega.Equal("foo.com/bar"))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "ingress-invalid-wildcard", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+
+	TearDownTestForIngress(t, integrationtest.AllModels...)
+}
+
+func createIngressWithTimestamp(t *testing.T, ingressObject integrationtest.FakeIngress, created time.Time) {
+	ingrFake := ingressObject.Ingress()
+	ingrFake.CreationTimestamp = metav1.NewTime(created)
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses(ingressObject.Namespace).Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+}
+
+func getPoolNames(modelName string) []string {
+	var poolNames []string
+	if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) > 0 {
+			for _, pool := range nodes[0].PoolRefs {
+				poolNames = append(poolNames, pool.Name)
+			}
+		}
+	}
+	sort.Strings(poolNames)
+	return poolNames
+}
+
+// getIngressHostnameConflicts returns the hosts of an ingress lost to other namespaces, along with
+// the hosts in the ingress status.
+func getIngressHostnameConflicts(namespace, name string) (map[string]string, []string) {
+	conflicts := make(map[string]string)
+	var statusHosts []string
+	ingObj, err := KubeClient.NetworkingV1beta1().Ingresses(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return conflicts, statusHosts
+	}
+	if value, ok := ingObj.Annotations[lib.HostnameConflictAnnotation]; ok {
+		json.Unmarshal([]byte(value), &conflicts)
+	}
+	for _, lbIngress := range ingObj.Status.LoadBalancer.Ingress {
+		statusHosts = append(statusHosts, lbIngress.Hostname)
+	}
+	return conflicts, statusHosts
+}
+
+func TestHostnameConflictFirstWins(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	os.Setenv("HOSTNAME_CONFLICT_POLICY", "first-wins")
+	defer os.Unsetenv("HOSTNAME_CONFLICT_POLICY")
+
+	now := time.Now()
+	// the ingress in red is processed first, but the older ingress in default owns the host
+	createIngressWithTimestamp(t, integrationtest.FakeIngress{
+		Name:        "foo-second",
+		Namespace:   "red",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/bar"},
+		ServiceName: "avisvc",
+	}, now)
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_bar-red-foo-second"}))
+	g.Eventually(func() []string {
+		_, statusHosts := getIngressHostnameConflicts("red", "foo-second")
+		return statusHosts
+	}, 10*time.Second).Should(gomega.ContainElement("foo.com"))
+
+	createIngressWithTimestamp(t, integrationtest.FakeIngress{
+		Name:        "foo-first",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}, now.Add(-time.Minute))
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_foo-default-foo-first"}))
+	// the ingress in red records the lost host, which is removed from its status
+	g.Eventually(func() map[string]string {
+		conflicts, _ := getIngressHostnameConflicts("red", "foo-second")
+		return conflicts
+	}, 10*time.Second).Should(gomega.Equal(map[string]string{"foo.com": "ingress default/foo-first"}))
+	g.Eventually(func() []string {
+		_, statusHosts := getIngressHostnameConflicts("red", "foo-second")
+		return statusHosts
+	}, 10*time.Second).ShouldNot(gomega.ContainElement("foo.com"))
+
+	// the host moves to the ingress in red once the owner is deleted
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-first", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_bar-red-foo-second"}))
+	g.Eventually(func() map[string]string {
+		conflicts, _ := getIngressHostnameConflicts("red", "foo-second")
+		return conflicts
+	}, 10*time.Second).Should(gomega.BeEmpty())
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("red").Delete(context.TODO(), "foo-second", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestHostnameConflictReject(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	os.Setenv("HOSTNAME_CONFLICT_POLICY", "reject")
+	defer os.Unsetenv("HOSTNAME_CONFLICT_POLICY")
+
+	now := time.Now()
+	createIngressWithTimestamp(t, integrationtest.FakeIngress{
+		Name:        "foo-first",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}, now.Add(-time.Minute))
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_foo-default-foo-first"}))
+
+	// bar.com is not claimed by anyone else, but the whole ingress is rejected
+	// bar.com maps to Shared-L7-1
+	createIngressWithTimestamp(t, integrationtest.FakeIngress{
+		Name:        "foo-second",
+		Namespace:   "red",
+		DnsNames:    []string{"foo.com", "bar.com"},
+		Paths:       []string{"/bar", "/baz"},
+		ServiceName: "avisvc",
+	}, now)
+	g.Consistently(func() []string {
+		return getPoolNames("admin/cluster--Shared-L7-1")
+	}, 5*time.Second).Should(gomega.HaveLen(0))
+	g.Expect(getPoolNames(modelName)).To(gomega.Equal([]string{"cluster--foo.com_foo-default-foo-first"}))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("red").Delete(context.TODO(), "foo-second", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-first", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	TearDownTestForIngress(t, modelName, "admin/cluster--Shared-L7-1")
+}
+
+func TestHostnameAllowedDomainsAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+
+	createIngressWithTimestamp(t, integrationtest.FakeIngress{
+		Name:        "foo-default",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}, time.Now())
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_foo-default-foo-default"}))
+
+	// granting foo.com to the green namespace revokes it from the default namespace
+	greenNS := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "green",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{lib.AllowedDomainsAnnotation: "foo.com"},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Namespaces().Create(context.TODO(), greenNS, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Namespace: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	g.Eventually(func() map[string]string {
+		conflicts, _ := getIngressHostnameConflicts("default", "foo-default")
+		return conflicts
+	}, 10*time.Second).Should(gomega.Equal(map[string]string{"foo.com": "namespace green"}))
+
+	createIngressWithTimestamp(t, integrationtest.FakeIngress{
+		Name:        "foo-green",
+		Namespace:   "green",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/bar"},
+		ServiceName: "avisvc",
+	}, time.Now())
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_bar-green-foo-green"}))
+
+	// removing the grant makes the host available to all namespaces again
+	greenNS.Annotations = nil
+	greenNS.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Namespaces().Update(context.TODO(), greenNS, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Namespace: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_bar-green-foo-green", "cluster--foo.com_foo-default-foo-default"}))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("green").Delete(context.TODO(), "foo-green", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-default", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	integrationtest.DeleteNamespace("green")
+	TearDownTestForIngress(t, modelName)
+}
+
+func getPoolServerIPs(modelName string) []string {
+	var serverIPs []string
+	if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) > 0 && len(nodes[0].PoolRefs) > 0 {
+			for _, server := range nodes[0].PoolRefs[0].Servers {
+				serverIPs = append(serverIPs, *server.Ip.Addr)
+			}
+		}
+	}
+	sort.Strings(serverIPs)
+	return serverIPs
+}
+
+func TestCrossNamespaceBackendWithGrant(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+	integrationtest.CreateSVC(t, "red", "avisvc", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "red", "avisvc", false, false, "3.3.3")
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	ingrFake.Annotations = map[string]string{lib.BackendNamespacesAnnotation: `{"avisvc":"red"}`}
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	// the backend in red is not used without a grant
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Consistently(func() []string {
+		return getPoolNames(modelName)
+	}, 5*time.Second).Should(gomega.HaveLen(0))
+
+	grant := &akov1alpha1.BackendGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "red",
+			Name:      "allow-default",
+		},
+		Spec: akov1alpha1.BackendGrantSpec{
+			From: []akov1alpha1.BackendGrantFrom{{Kind: "Ingress", Namespace: "default"}},
+			To:   []akov1alpha1.BackendGrantTo{{Name: "avisvc"}},
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().BackendGrants("red").Create(context.TODO(), grant, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding BackendGrant: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com_foo-default-foo-with-targets"}))
+	g.Eventually(func() []string {
+		return getPoolServerIPs(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"3.3.3.1"}))
+
+	// endpoint updates in red are reflected in the pool of the ingress in default
+	epExample := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "red", Name: "avisvc", ResourceVersion: "2"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "3.3.3.1"}, {IP: "3.3.3.2"}},
+			Ports:     []corev1.EndpointPort{{Name: "foo0", Port: 8080, Protocol: "TCP"}},
+		}},
+	}
+	if _, err := KubeClient.CoreV1().Endpoints("red").Update(context.TODO(), epExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Endpoint: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolServerIPs(modelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"3.3.3.1", "3.3.3.2"}))
+
+	// deleting the grant revokes the backend
+	if err := CRDClient.AkoV1alpha1().BackendGrants("red").Delete(context.TODO(), "allow-default", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting BackendGrant: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.DelSVC(t, "red", "avisvc")
+	integrationtest.DelEP(t, "red", "avisvc")
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestHostnameIngressPassthrough(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	passModelName := "admin/cluster--Shared-Passthrough-0"
+	SetUpTestForIngress(t, modelName, passModelName)
+
+	getPassthroughVS := func() *avinodes.AviVsNode {
+		if found, aviModel := objects.SharedAviGraphLister().Get(passModelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				return nodes[0]
+			}
+		}
+		return nil
+	}
+
+	// both the rules of the host go to the same service, which gets a single pool
+	ingrFake := integrationtest.FakeIngress{
+		Name:        "foo-passthrough",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com", "foo.com"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: "avisvc",
+	}.Ingress()
+	ingrFake.Annotations = map[string]string{lib.PassthroughAnnotation: "true"}
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com-avisvc"}))
+	passVS := getPassthroughVS()
+	g.Expect(passVS.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(passVS.PoolGroupRefs[0].Name).To(gomega.Equal("cluster--foo.com"))
+	g.Expect(passVS.PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
+	g.Expect(passVS.HTTPDSrefs[0].PoolGroupRefs).To(gomega.Equal([]string{"cluster--foo.com"}))
+	g.Expect(passVS.VSVIPRefs[0].FQDNs).To(gomega.ContainElement("foo.com"))
+	g.Expect(passVS.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	// the http requests to the host are redirected to https on the insecure passthrough VS
+	g.Expect(passVS.PassthroughChildNodes).To(gomega.HaveLen(1))
+	g.Expect(passVS.PassthroughChildNodes[0].HttpPolicyRefs).To(gomega.HaveLen(1))
+	g.Expect(getPoolNames(modelName)).To(gomega.HaveLen(0))
+
+	// removing the annotation moves the host back to the L7 shard VS
+	ingrFake.Annotations = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(2))
+	g.Eventually(func() []string {
+		return getPoolNames(passModelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	passVS = getPassthroughVS()
+	g.Expect(passVS.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(passVS.VSVIPRefs[0].FQDNs).NotTo(gomega.ContainElement("foo.com"))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-passthrough", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	TearDownTestForIngress(t, modelName, passModelName)
+}
+
+func TestHostnameIngressDefaultCert(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// foo.com is sharded to Shared-L7-0, bar.com to Shared-L7-1 and bar.apps.com to Shared-L7-7
+	modelName := "admin/cluster--Shared-L7-0"
+	barModelName := "admin/cluster--Shared-L7-1"
+	appsModelName := "admin/cluster--Shared-L7-7"
+	SetUpTestForIngress(t, modelName, barModelName, appsModelName)
+	os.Setenv("DEFAULT_INGRESS_CERT", "default-ingress-cert")
+	integrationtest.AddSecret("default-ingress-cert", "avi-system", "defaultCert", "defaultKey")
+
+	getSniCert := func(modelName, host string) string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				for _, sniNode := range nodes[0].SniNodes {
+					if sniNode.Name == lib.GetSniNodeName("", "", "", host) && len(sniNode.SSLKeyCertRefs) > 0 {
+						return string(sniNode.SSLKeyCertRefs[0].Cert)
+					}
+				}
+			}
+		}
+		return ""
+	}
+
+	// the secret of the tls entry is missing, the host is served with the default certificate
+	recorder := record.NewFakeRecorder(10)
+	lib.SetAKOEventRecorder(recorder)
+	ingrFake := integrationtest.FakeIngress{
+		Name:         "foo-default-cert",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"my-secret": {"foo.com"}},
+	}.Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		return getSniCert(modelName, "foo.com")
+	}, 10*time.Second).Should(gomega.Equal("defaultCert"))
+	g.Eventually(func() int {
+		return len(recorder.Events)
+	}, 10*time.Second).Should(gomega.Equal(1))
+	g.Expect(<-recorder.Events).To(gomega.ContainSubstring("DefaultCertificate hosts [foo.com] are served with the default certificate avi-system/default-ingress-cert"))
+
+	// the Event is not repeated when the ingress is processed again with the same hosts
+	ingrFake.Labels = map[string]string{"app": "foo"}
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Consistently(func() int {
+		return len(recorder.Events)
+	}, 3*time.Second).Should(gomega.Equal(0))
+	lib.SetAKOEventRecorder(nil)
+
+	// the secret of the tls entry takes over once it is created
+	integrationtest.AddSecret("my-secret", "default", "fooCert", "fooKey")
+	g.Eventually(func() string {
+		return getSniCert(modelName, "foo.com")
+	}, 10*time.Second).Should(gomega.Equal("fooCert"))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-default-cert", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "my-secret", metav1.DeleteOptions{})
+	g.Eventually(func() []string {
+		return getPoolNames(modelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+
+	// the hosts under the default certificate domain are served securely without a tls entry
+	os.Setenv("DEFAULT_CERT_DOMAIN", "*.apps.com")
+	ingrFake = integrationtest.FakeIngress{
+		Name:        "bar-default-cert",
+		Namespace:   "default",
+		DnsNames:    []string{"bar.apps.com", "bar.com"},
+		Paths:       []string{"/bar", "/bar"},
+		ServiceName: "avisvc",
+	}.Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		return getSniCert(appsModelName, "bar.apps.com")
+	}, 10*time.Second).Should(gomega.Equal("defaultCert"))
+	g.Expect(getSniCert(barModelName, "bar.com")).To(gomega.BeEmpty())
+	g.Expect(getPoolNames(barModelName)).To(gomega.Equal([]string{"cluster--bar.com_bar-default-bar-default-cert"}))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "bar-default-cert", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() []string {
+		return getPoolNames(barModelName)
+	}, 10*time.Second).Should(gomega.HaveLen(0))
+	g.Eventually(func() string {
+		return getSniCert(appsModelName, "bar.apps.com")
+	}, 10*time.Second).Should(gomega.BeEmpty())
+	os.Setenv("DEFAULT_CERT_DOMAIN", "")
+	os.Setenv("DEFAULT_INGRESS_CERT", "")
+	KubeClient.CoreV1().Secrets("avi-system").Delete(context.TODO(), "default-ingress-cert", metav1.DeleteOptions{})
+	TearDownTestForIngress(t, modelName, barModelName, appsModelName)
+}
+
+func TestHostnameIngressCertValidation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+
+	getSniNode := func() *avinodes.AviVsNode {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 && len(nodes[0].SniNodes) > 0 {
+				return nodes[0].SniNodes[0]
+			}
+		}
+		return nil
+	}
+	updateSecret := func(cert, key, version string) {
+		secret := integrationtest.FakeSecret{
+			Cert:      cert,
+			Key:       key,
+			Namespace: "default",
+			Name:      "cert-secret",
+		}.Secret()
+		secret.ResourceVersion = version
+		if _, err := KubeClient.CoreV1().Secrets("default").Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating Secret: %v", err)
+		}
+	}
+
+	fooCert, fooKey := integrationtest.GenerateCert([]string{"foo.com"}, 241*time.Hour)
+	integrationtest.AddSecret("cert-secret", "default", fooCert, fooKey)
+	ingrFake := integrationtest.FakeIngress{
+		Name:         "foo-cert",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"cert-secret": {"foo.com"}},
+	}.Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() bool {
+		return getSniNode() != nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+	g.Expect(getSniNode().SSLKeyCertRefs).To(gomega.HaveLen(1))
+	certInfo, found := lib.SharedCertExpiryStore().Get("cluster--foo.com")
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(certInfo.Secret).To(gomega.Equal("default/cert-secret"))
+	g.Expect(certInfo.Hosts).To(gomega.Equal([]string{"foo.com"}))
+	g.Expect(certInfo.DaysToExpiry).To(gomega.Equal(10))
+
+	// the certificates are listed by the debug API
+	certsAPI := (&lib.CertificatesModel{}).ApiOperationMap()[0]
+	g.Expect(certsAPI.Route).To(gomega.Equal("/api/certificates"))
+	rr := httptest.NewRecorder()
+	certsAPI.Handler(rr, httptest.NewRequest("GET", certsAPI.Route, nil))
+	var certs []lib.CertExpiryInfo
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &certs)).To(gomega.Succeed())
+	g.Expect(certs).To(gomega.HaveLen(1))
+	g.Expect(certs[0].Name).To(gomega.Equal("cluster--foo.com"))
+
+	// a certificate which does not cover the host is rejected
+	barCert, barKey := integrationtest.GenerateCert([]string{"bar.com"}, 241*time.Hour)
+	updateSecret(barCert, barKey, "2")
+	g.Eventually(func() bool {
+		return getSniNode() == nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+	_, found = lib.SharedCertExpiryStore().Get("cluster--foo.com")
+	g.Expect(found).To(gomega.BeFalse())
+
+	// a key which does not belong to the certificate is rejected
+	updateSecret(fooCert, barKey, "3")
+	g.Consistently(func() bool {
+		return getSniNode() == nil
+	}, 3*time.Second).Should(gomega.BeTrue())
+
+	updateSecret(fooCert, fooKey, "4")
+	g.Eventually(func() bool {
+		return getSniNode() != nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	// the expiry Events are raised again by the periodic scan
+	recorder := record.NewFakeRecorder(10)
+	lib.SetAKOEventRecorder(recorder)
+	avinodes.ScanCertExpiry()
+	lib.SetAKOEventRecorder(nil)
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	g.Expect(events).To(gomega.ContainElement(gomega.ContainSubstring("CertificateExpiring certificate of default/cert-secret expires in 10 days")))
+
+	// a certificate without SANs is matched by its CN
+	cnCert, cnKey := integrationtest.GenerateCNCert("foo.com", 241*time.Hour)
+	updateSecret(cnCert, cnKey, "5")
+	g.Eventually(func() string {
+		if sniNode := getSniNode(); sniNode != nil && len(sniNode.SSLKeyCertRefs) == 1 {
+			return string(sniNode.SSLKeyCertRefs[0].Cert)
+		}
+		return ""
+	}, 10*time.Second).Should(gomega.Equal(cnCert))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-cert", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "cert-secret", metav1.DeleteOptions{})
+	g.Eventually(func() *avinodes.AviVsNode {
+		return getSniNode()
+	}, 10*time.Second).Should(gomega.BeNil())
+	TearDownTestForIngress(t, modelName)
+}
+
+func TestHostnameIngressDualCert(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	SetUpTestForIngress(t, modelName)
+
+	getCertNames := func() []string {
+		var certNames []string
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 && len(nodes[0].SniNodes) > 0 {
+				for _, certNode := range nodes[0].SniNodes[0].SSLKeyCertRefs {
+					certNames = append(certNames, certNode.Name)
+				}
+			}
+		}
+		sort.Strings(certNames)
+		return certNames
+	}
+	updateSecret := func(name, cert, key, version string) {
+		secret := integrationtest.FakeSecret{
+			Cert:      cert,
+			Key:       key,
+			Namespace: "default",
+			Name:      name,
+		}.Secret()
+		secret.ResourceVersion = version
+		if _, err := KubeClient.CoreV1().Secrets("default").Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("error in updating Secret: %v", err)
+		}
+	}
+
+	rsaCert, rsaKey := integrationtest.GenerateRSACert([]string{"foo.com"}, 241*time.Hour)
+	ecCert, ecKey := integrationtest.GenerateCert([]string{"foo.com"}, 241*time.Hour)
+	integrationtest.AddSecret("rsa-secret", "default", rsaCert, rsaKey)
+	integrationtest.AddSecret("ec-secret", "default", ecCert, ecKey)
+	ingrFake := integrationtest.FakeIngress{
+		Name:         "foo-dual",
+		Namespace:    "default",
+		DnsNames:     []string{"foo.com"},
+		Paths:        []string{"/foo"},
+		ServiceName:  "avisvc",
+		TlsSecretDNS: map[string][]string{"rsa-secret": {"foo.com"}},
+	}.Ingress()
+	ingrFake.Spec.TLS = append(ingrFake.Spec.TLS, networking.IngressTLS{Hosts: []string{"foo.com"}, SecretName: "ec-secret"})
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com", "cluster--foo.com-alt"}))
+
+	// both certificates using RSA keys, the alternate one is dropped
+	rsaCert2, rsaKey2 := integrationtest.GenerateRSACert([]string{"foo.com"}, 241*time.Hour)
+	updateSecret("ec-secret", rsaCert2, rsaKey2, "2")
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com"}))
+
+	updateSecret("ec-secret", ecCert, ecKey, "3")
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com", "cluster--foo.com-alt"}))
+
+	// removing the second TLS entry removes the alternate certificate
+	ingrFake.Spec.TLS = ingrFake.Spec.TLS[:1]
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.Equal([]string{"cluster--foo.com"}))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-dual", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "rsa-secret", metav1.DeleteOptions{})
+	KubeClient.CoreV1().Secrets("default").Delete(context.TODO(), "ec-secret", metav1.DeleteOptions{})
+	g.Eventually(getCertNames, 10*time.Second).Should(gomega.HaveLen(0))
+	TearDownTestForIngress(t, modelName)
+}
+
+func getFullSyncCount(t *testing.T, objType, result string) int {
+	metricsAPI := (&lib.CertificatesModel{}).ApiOperationMap()[1]
+	rr := httptest.NewRecorder()
+	metricsAPI.Handler(rr, httptest.NewRequest("GET", metricsAPI.Route, nil))
+	prefix := `ako_full_sync_total{result="` + result + `",type="` + objType + `"} `
+	for _, line := range strings.Split(rr.Body.String(), "\n") {
+		if strings.HasPrefix(line, prefix) {
+			count, err := strconv.Atoi(strings.TrimPrefix(line, prefix))
+			if err != nil {
+				t.Fatalf("unexpected full sync metric %s: %v", line, err)
+			}
+			return count
+		}
+	}
+	return 0
+}
+
+func TestHostnameIncrementalFullSync(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--Shared-L7-0"
+	vsKey := cache.NamespaceName{Namespace: "admin", Name: "cluster--Shared-L7-0"}
+	SetUpTestForIngress(t, modelName)
+	os.Setenv("INCREMENTAL_FULL_SYNC", "true")
+	defer os.Unsetenv("INCREMENTAL_FULL_SYNC")
+
+	ingrFake := integrationtest.FakeIngress{
+		Name:        "foo-fullsync",
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Paths:       []string{"/foo"},
+		ServiceName: "avisvc",
+	}.Ingress()
+	ingrFake.ResourceVersion = "1"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+	g.Eventually(func() bool {
+		_, found := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	// the first full sync evaluates everything, the second one skips what did not change
+	ctrl.FullSyncK8s()
+	objectsSkipped := getFullSyncCount(t, lib.FullSyncObject, lib.FullSyncSkipped)
+	modelsSkipped := getFullSyncCount(t, lib.FullSyncModel, lib.FullSyncSkipped)
+	ctrl.FullSyncK8s()
+	g.Expect(getFullSyncCount(t, lib.FullSyncObject, lib.FullSyncSkipped)).To(gomega.BeNumerically(">", objectsSkipped))
+	g.Expect(getFullSyncCount(t, lib.FullSyncModel, lib.FullSyncSkipped)).To(gomega.BeNumerically(">", modelsSkipped))
+
+	// an updated Ingress is evaluated again
+	objectsSynced := getFullSyncCount(t, lib.FullSyncObject, lib.FullSyncSynced)
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	ctrl.FullSyncK8s()
+	g.Expect(getFullSyncCount(t, lib.FullSyncObject, lib.FullSyncSynced)).To(gomega.BeNumerically(">", objectsSynced))
+
+	// the VS removed on the controller is detected by its last_modified, and created again
+	integrationtest.AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.Contains(r.URL.RawQuery, "_last_modified") {
+			var results []map[string]string
+			for _, key := range cache.SharedAviObjCache().VsCacheMeta.AviGetAllKeys() {
+				lastModified := "drifted"
+				if vsCache, found := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(key); found && key != vsKey {
+					lastModified = vsCache.(*cache.AviVsCache).LastModified
+				}
+				results = append(results, map[string]string{"name": key.Name, "_last_modified": lastModified})
+			}
+			data, _ := json.Marshal(map[string]interface{}{"count": len(results), "results": results})
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+		} else if r.Method == "GET" && strings.Contains(r.URL.RawQuery, "name="+vsKey.Name+"&") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"count": 0, "results": []}`))
+		} else {
+			integrationtest.NormalControllerServer(w, r)
+		}
+	})
+	modelsDrifted := getFullSyncCount(t, lib.FullSyncModel, lib.FullSyncDrifted)
+	ctrl.FullSync()
+	integrationtest.ResetMiddleware()
+	g.Expect(getFullSyncCount(t, lib.FullSyncModel, lib.FullSyncDrifted)).To(gomega.Equal(modelsDrifted + 1))
+	g.Eventually(func() string {
+		if vsCache, found := cache.SharedAviObjCache().VsCacheMeta.AviCacheGet(vsKey); found {
+			return vsCache.(*cache.AviVsCache).CloudConfigCksum
+		}
+		return ""
+	}, 10*time.Second).ShouldNot(gomega.BeEmpty())
+
+	// a model whose push fails is not recorded as pushed, so that the next full sync pushes it again
+	integrationtest.AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" || (r.Method == "POST" && strings.Contains(r.URL.EscapedPath(), "macro")) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "bad request"}`))
+		} else {
+			integrationtest.NormalControllerServer(w, r)
+		}
+	})
+	ingrFake.Spec.Rules[0].HTTP.Paths[0].Path = "/bar"
+	ingrFake.ResourceVersion = "3"
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	isModelPushed := func() bool {
+		_, pushed := objects.SharedFullSyncLister().GetModelChecksum(modelName)
+		return pushed
+	}
+	g.Eventually(isModelPushed, 10*time.Second).Should(gomega.BeFalse())
+	g.Consistently(isModelPushed, 2*time.Second).Should(gomega.BeFalse())
+	integrationtest.ResetMiddleware()
+	modelsSynced := getFullSyncCount(t, lib.FullSyncModel, lib.FullSyncSynced)
+	ctrl.FullSyncK8s()
+	g.Expect(getFullSyncCount(t, lib.FullSyncModel, lib.FullSyncSynced)).To(gomega.BeNumerically(">", modelsSynced))
+	g.Eventually(isModelPushed, 10*time.Second).Should(gomega.BeTrue())
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses("default").Delete(context.TODO(), "foo-fullsync", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	VerifyIngressDeletion(t, g, aviModel, 0)
+	TearDownTestForIngress(t, modelName)
+}
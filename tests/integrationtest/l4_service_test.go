@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"sync"
@@ -32,6 +33,7 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	apimodels "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/api/models"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	"github.com/onsi/gomega"
@@ -414,6 +416,51 @@ func TestCreateServiceLBCacheSync(t *testing.T) {
 	}, 10*time.Second).Should(gomega.Equal(false))
 }
 
+func TestServiceLBCacheIndexes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	SetUpTestForSvcLB(t)
+
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)}
+	poolKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: "cluster--red-ns-testsvc--8080"}
+	vsCache, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+	if !found {
+		t.Fatalf("Cache not found for VS: %v", vsKey)
+	}
+	vsCacheObj, ok := vsCache.(*cache.AviVsCache)
+	if !ok {
+		t.Fatalf("Invalid VS object. Cannot cast.")
+	}
+	poolCache, found := mcache.PoolCache.AviCacheGet(poolKey)
+	if !found {
+		t.Fatalf("Cache not found for Pool: %v", poolKey)
+	}
+	poolCacheObj, _ := poolCache.(*cache.AviPoolCache)
+
+	key, found := mcache.VsCacheMeta.AviCacheGetKeyByUuid(vsCacheObj.Uuid)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(key).To(gomega.Equal(vsKey))
+	name, found := mcache.PoolCache.AviCacheGetNameByUuid(poolCacheObj.Uuid)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(name).To(gomega.Equal(poolKey.Name))
+	g.Expect(mcache.VsCacheMeta.AviCacheGetKeysByTenant(AVINAMESPACE)).To(gomega.ContainElement(vsKey))
+	g.Expect(mcache.VsCacheMeta.AviCacheGetVSKeysReferencing(cache.VSRefPool, poolKey)).To(gomega.ConsistOf(vsKey))
+	g.Expect(mcache.VsCacheMeta.AviCacheGetVSKeysReferencing(cache.VSRefL4Policy, vsCacheObj.L4PolicyCollection[0])).To(gomega.ConsistOf(vsKey))
+
+	// The indexes must not hold on to the VS once it is deleted.
+	vsUuid := vsCacheObj.Uuid
+	TearDownTestForSvcLB(t, g)
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(false))
+	_, found = mcache.VsCacheMeta.AviCacheGetKeyByUuid(vsUuid)
+	g.Expect(found).To(gomega.BeFalse())
+	g.Expect(mcache.VsCacheMeta.AviCacheGetKeysByTenant(AVINAMESPACE)).NotTo(gomega.ContainElement(vsKey))
+	g.Expect(mcache.VsCacheMeta.AviCacheGetVSKeysReferencing(cache.VSRefPool, poolKey)).To(gomega.BeEmpty())
+}
+
 func TestCreateServiceLBWithFaultCacheSync(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -670,6 +717,140 @@ func TestAviSvcCreationWithStaticIP(t *testing.T) {
 	TearDownTestForSvcLB(t, g)
 }
 
+func TestAviSvcCreationWithStaticIPv6(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	staticIP := "fd00:80::80"
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:           SINGLEPORTSVC,
+		Namespace:      NAMESPACE,
+		Type:           corev1.ServiceTypeLoadBalancer,
+		LoadBalancerIP: staticIP,
+		ServicePorts:   []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: 8080}},
+	}).Service()
+	_, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].VSVIPRefs) > 0 {
+				return nodes[0].VSVIPRefs[0].IPv6Address
+			}
+		}
+		return ""
+	}, 20*time.Second).Should(gomega.Equal(staticIP))
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	vsVipNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].VSVIPRefs[0]
+	g.Expect(vsVipNode.IPAddress).To(gomega.Equal(""))
+	g.Expect(vsVipNode.IPFamily).To(gomega.Equal(lib.IPFamilyV6))
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcCreationDualStackAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: 8080}},
+	}).Service()
+	svcExample.Annotations = map[string]string{lib.VipIPFamilyAnnotation: lib.IPFamilyV4V6}
+	_, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].VSVIPRefs) > 0 {
+				return nodes[0].VSVIPRefs[0].IPFamily
+			}
+		}
+		return ""
+	}, 20*time.Second).Should(gomega.Equal(lib.IPFamilyV4V6))
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcCreationDefaultedIPv4Family(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv(lib.VIP_IP_FAMILY, lib.IPFamilyV6)
+	defer os.Unsetenv(lib.VIP_IP_FAMILY)
+
+	// the ipFamily defaulted by the API server does not override the vipIPFamily.
+	ipFamily := corev1.IPv4Protocol
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:         SINGLEPORTSVC,
+		Namespace:    NAMESPACE,
+		Type:         corev1.ServiceTypeLoadBalancer,
+		ServicePorts: []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: 8080}},
+	}).Service()
+	svcExample.Spec.IPFamily = &ipFamily
+	_, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].VSVIPRefs) > 0 {
+				return nodes[0].VSVIPRefs[0].IPFamily
+			}
+		}
+		return ""
+	}, 20*time.Second).Should(gomega.Equal(lib.IPFamilyV6))
+	TearDownTestForSvcLB(t, g)
+}
+
+func TestAviSvcCreationStaticIPv4WithIPv6Family(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	objects.SharedAviGraphLister().Delete(SINGLEPORTMODEL)
+	svcExample := (FakeService{
+		Name:           SINGLEPORTSVC,
+		Namespace:      NAMESPACE,
+		Type:           corev1.ServiceTypeLoadBalancer,
+		LoadBalancerIP: "10.10.10.1",
+		ServicePorts:   []Serviceport{{PortName: "foo1", Protocol: "TCP", PortNumber: 8080, TargetPort: 8080}},
+	}).Service()
+	svcExample.Annotations = map[string]string{lib.VipIPFamilyAnnotation: lib.IPFamilyV6}
+	_, err := KubeClient.CoreV1().Services(NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating Service: %v", err)
+	}
+	CreateEP(t, NAMESPACE, SINGLEPORTSVC, false, false, "1.1.1")
+	PollForCompletion(t, SINGLEPORTMODEL, 5)
+
+	// the IPv6 VIP is not upgraded to dual-stack for the static IPv4 address.
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].VSVIPRefs) > 0 {
+				return nodes[0].VSVIPRefs[0].IPFamily
+			}
+		}
+		return ""
+	}, 20*time.Second).Should(gomega.Equal(lib.IPFamilyV6))
+	_, aviModel := objects.SharedAviGraphLister().Get(SINGLEPORTMODEL)
+	vsVipNode := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].VSVIPRefs[0]
+	g.Expect(vsVipNode.IPAddress).To(gomega.Equal(""))
+	TearDownTestForSvcLB(t, g)
+}
+
 // Infra CRD tests via service annotation
 
 func TestWithInfraSettingStatusUpdates(t *testing.T) {
@@ -900,3 +1081,220 @@ func TestInfraSettingChangeMapping(t *testing.T) {
 	TeardownAviInfraSetting(t, settingName2)
 	TearDownTestForSvcLB(t, g)
 }
+
+func TestServiceLBCacheSnapshot(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("CACHE_SNAPSHOT", lib.CacheSnapshotConfigMap)
+	defer os.Unsetenv("CACHE_SNAPSHOT")
+
+	SetUpTestForSvcLB(t)
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: AVINAMESPACE, Name: fmt.Sprintf("cluster--%s-%s", NAMESPACE, SINGLEPORTSVC)}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	// the full sync persists the cache
+	ctrl.FullSync()
+	_, err := KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.AviCacheSnapshot, metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	snapshot, err := cache.LoadCacheSnapshot()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(snapshot).NotTo(gomega.BeNil())
+	g.Expect(snapshot.VirtualServices).To(gomega.HaveKey(AVINAMESPACE + "/" + vsKey.Name))
+	g.Expect(snapshot.Pools).To(gomega.HaveKey(AVINAMESPACE + "/cluster--red-ns-testsvc--8080"))
+	g.Expect(snapshot.L4Policies).To(gomega.HaveKey(AVINAMESPACE + "/cluster--red-ns-testsvc"))
+
+	// the controller lists the name and last_modified of the objects in the snapshot
+	modifiedPool := ""
+	AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || !strings.Contains(r.URL.RawQuery, "fields=name,_last_modified") {
+			NormalControllerServer(w, r)
+			return
+		}
+		lastModified := make(map[string]string)
+		switch objType := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")[1]; objType {
+		case "virtualservice":
+			for _, vs := range snapshot.VirtualServices {
+				lastModified[vs.Name] = vs.LastModified
+			}
+		case "pool":
+			for _, pool := range snapshot.Pools {
+				lastModified[pool.Name] = pool.LastModified
+			}
+		case "poolgroup":
+			for _, pg := range snapshot.PoolGroups {
+				lastModified[pg.Name] = pg.LastModified
+			}
+		case "vsvip":
+			for _, vsvip := range snapshot.VSVIPs {
+				lastModified[vsvip.Name] = vsvip.LastModified
+			}
+		case "httppolicyset":
+			for _, httppol := range snapshot.HTTPPolicies {
+				lastModified[httppol.Name] = httppol.LastModified
+			}
+		case "l4policyset":
+			for _, l4pol := range snapshot.L4Policies {
+				lastModified[l4pol.Name] = l4pol.LastModified
+			}
+		}
+		results := []map[string]string{}
+		for name, modified := range lastModified {
+			if name == modifiedPool {
+				modified = "modified"
+			}
+			results = append(results, map[string]string{"name": name, "_last_modified": modified})
+		}
+		data, _ := json.Marshal(map[string]interface{}{"count": len(results), "results": results})
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})
+	defer ResetMiddleware()
+
+	// a snapshot matching the controller populates the cache
+	client := cache.SharedAVIClients().AviClient[0]
+	restoredCache := cache.NewAviObjCache()
+	g.Expect(restoredCache.AviObjCachePopulateFromSnapshot(client, utils.CtrlVersion, utils.CloudName, snapshot)).To(gomega.Succeed())
+	vsCache, found := restoredCache.VsCacheMeta.AviCacheGet(vsKey)
+	g.Expect(found).To(gomega.BeTrue())
+	vsCacheObj := vsCache.(*cache.AviVsCache)
+	g.Expect(vsCacheObj.PoolKeyCollection).To(gomega.HaveLen(1))
+	g.Expect(vsCacheObj.L4PolicyCollection).To(gomega.HaveLen(1))
+	origVsCache, _ := mcache.VsCacheMeta.AviCacheGet(vsKey)
+	g.Expect(vsCacheObj.CloudConfigCksum).To(gomega.Equal(origVsCache.(*cache.AviVsCache).CloudConfigCksum))
+	_, found = restoredCache.PoolCache.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: "cluster--red-ns-testsvc--8080"})
+	g.Expect(found).To(gomega.BeTrue())
+
+	// a pool modified on the controller since the snapshot invalidates it
+	modifiedPool = "cluster--red-ns-testsvc--8080"
+	g.Expect(cache.NewAviObjCache().AviObjCachePopulateFromSnapshot(client, utils.CtrlVersion, utils.CloudName, snapshot)).NotTo(gomega.Succeed())
+	ResetMiddleware()
+
+	// the snapshot can also be kept in a file on the PVC of AKO
+	os.Setenv("CACHE_SNAPSHOT", lib.CacheSnapshotPVC)
+	os.Setenv("LOG_FILE_PATH", t.TempDir())
+	defer os.Unsetenv("LOG_FILE_PATH")
+	g.Expect(cache.SaveCacheSnapshot(snapshot)).To(gomega.Succeed())
+	pvcSnapshot, err := cache.LoadCacheSnapshot()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(pvcSnapshot.VirtualServices).To(gomega.HaveKey(AVINAMESPACE + "/" + vsKey.Name))
+	cache.DeleteCacheSnapshot()
+	pvcSnapshot, err = cache.LoadCacheSnapshot()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(pvcSnapshot).To(gomega.BeNil())
+	os.Setenv("CACHE_SNAPSHOT", lib.CacheSnapshotConfigMap)
+
+	TearDownTestForSvcLB(t, g)
+	cache.DeleteCacheSnapshot()
+	_, err = KubeClient.CoreV1().ConfigMaps(utils.GetAKONamespace()).Get(context.TODO(), lib.AviCacheSnapshot, metav1.GetOptions{})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestCachePopulatePages(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv("CACHE_POPULATE_CONCURRENCY", "2")
+	os.Setenv("CACHE_POPULATE_TIMEOUT", "1")
+	defer os.Unsetenv("CACHE_POPULATE_CONCURRENCY")
+	defer os.Unsetenv("CACHE_POPULATE_TIMEOUT")
+
+	// 950 pools in pages of 100, the second page fails once and the third one times out once
+	const poolCount, pageSize, pageCount = 950, 100, 10
+	var lock sync.Mutex
+	var inFlight, maxInFlight, pgRequests int
+	attempts := make(map[string]int)
+	AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		objType := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")[1]
+		if r.Method == "GET" && objType == "poolgroup" {
+			lock.Lock()
+			pgRequests++
+			lock.Unlock()
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		if r.Method != "GET" || objType != "pool" {
+			NormalControllerServer(w, r)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		lock.Lock()
+		attempts[page]++
+		attempt := attempts[page]
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		defer func() {
+			lock.Lock()
+			inFlight--
+			lock.Unlock()
+		}()
+		time.Sleep(20 * time.Millisecond)
+		if page == "2" && attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if page == "3" && attempt == 1 {
+			time.Sleep(2 * time.Second)
+		}
+
+		var pageNum int
+		fmt.Sscan(page, &pageNum)
+		results := []map[string]string{}
+		for i := (pageNum - 1) * pageSize; i < pageNum*pageSize && i < poolCount; i++ {
+			results = append(results, map[string]string{
+				"name":               fmt.Sprintf("cluster--paged-pool-%d", i),
+				"uuid":               fmt.Sprintf("pool-paged-%d", i),
+				"cloud_config_cksum": "1",
+				"_last_modified":     "1",
+				"service_metadata":   "{}",
+			})
+		}
+		resp := map[string]interface{}{"count": poolCount, "results": results}
+		if pageNum < pageCount {
+			resp["next"] = fmt.Sprintf("https://%s/api/pool/?page=%d", r.Host, pageNum+1)
+		}
+		data, _ := json.Marshal(resp)
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})
+	defer ResetMiddleware()
+
+	client := cache.SharedAVIClients().AviClient[0]
+	populatedCache := cache.NewAviObjCache()
+	populatedCache.PopulatePoolsToCache(client, utils.CloudName)
+	for i := 0; i < poolCount; i++ {
+		_, found := populatedCache.PoolCache.AviCacheGet(cache.NamespaceName{Namespace: AVINAMESPACE, Name: fmt.Sprintf("cluster--paged-pool-%d", i)})
+		g.Expect(found).To(gomega.BeTrue())
+	}
+	lock.Lock()
+	g.Expect(maxInFlight).To(gomega.BeNumerically("<=", 2))
+	g.Expect(attempts).To(gomega.HaveLen(pageCount))
+	g.Expect(attempts["1"]).To(gomega.Equal(1))
+	g.Expect(attempts["2"]).To(gomega.Equal(2))
+	g.Expect(attempts["3"]).To(gomega.Equal(2))
+	lock.Unlock()
+
+	// the errors which fail the same on a retry are not retried
+	_, _, err := populatedCache.AviPopulateAllPGs(client, utils.CloudName, &[]cache.AviPGCache{})
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(pgRequests).To(gomega.Equal(1))
+
+	// the progress is reported in the status API
+	recorder := httptest.NewRecorder()
+	(&apimodels.StatusModel{}).ApiOperationMap()[0].Handler(recorder, httptest.NewRequest("GET", "/api/status", nil))
+	var status apimodels.StatusModel
+	g.Expect(json.Unmarshal(recorder.Body.Bytes(), &status)).To(gomega.Succeed())
+	g.Expect(status.CachePopulate.Collections).To(gomega.HaveKey("pool"))
+	g.Expect(*status.CachePopulate.Collections["pool"]).To(gomega.Equal(apimodels.CollectionStatus{
+		Objects:      poolCount,
+		Pages:        pageCount,
+		PagesFetched: pageCount,
+	}))
+}
@@ -322,6 +322,8 @@ func TestCreateSNICacheSync(t *testing.T) {
 	g.Expect(sniCacheObj.HTTPKeyCollection).To(gomega.HaveLen(1))
 	g.Expect(sniCacheObj.HTTPKeyCollection[0].Name).To(gomega.ContainSubstring("cluster--default-foo.com"))
 	g.Expect(sniCacheObj.ParentVSRef).To(gomega.Equal(parentVSKey))
+	g.Expect(mcache.VsCacheMeta.AviCacheGetAllChildVSForParent(parentVSKey)).To(gomega.ConsistOf(sniCacheObj.Uuid))
+	g.Expect(mcache.VsCacheMeta.AviCacheGetVSKeysReferencing(cache.VSRefSSLKeyCert, sniCacheObj.SSLKeyCertCollection[0])).To(gomega.ConsistOf(sniVSKey))
 
 	TearDownIngressForCacheSyncCheck(t, modelName, g)
 }
@@ -16,9 +16,17 @@ package integrationtest
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -192,6 +200,40 @@ func AddSecret(secretName string, namespace string, cert string, key string) {
 	KubeClient.CoreV1().Secrets(namespace).Create(context.TODO(), fakeSecret, metav1.CreateOptions{})
 }
 
+// GenerateCert returns a PEM encoded self-signed certificate for the hosts, valid for the duration, and its key.
+func GenerateCert(hosts []string, validFor time.Duration) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return selfSignCert(hosts[0], hosts, validFor, &key.PublicKey, key, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// GenerateCNCert returns a PEM encoded self-signed certificate without SANs, which names the host by its CN only.
+func GenerateCNCert(host string, validFor time.Duration) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return selfSignCert(host, nil, validFor, &key.PublicKey, key, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// GenerateRSACert is GenerateCert with an RSA key instead of an ECDSA one.
+func GenerateRSACert(hosts []string, validFor time.Duration) (string, string) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keyDER := x509.MarshalPKCS1PrivateKey(key)
+	return selfSignCert(hosts[0], hosts, validFor, &key.PublicKey, key, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: keyDER})
+}
+
+func selfSignCert(commonName string, hosts []string, validFor time.Duration, pub, priv interface{}, keyBlock *pem.Block) (string, string) {
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     hosts,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validFor),
+	}
+	certDER, _ := x509.CreateCertificate(rand.Reader, &template, &template, pub, priv)
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	return string(cert), string(pem.EncodeToMemory(keyBlock))
+}
+
 // Fake ingress
 type FakeIngress struct {
 	DnsNames     []string
@@ -1091,6 +1133,7 @@ type FakeHostRule struct {
 	Name               string
 	Namespace          string
 	Fqdn               string
+	FqdnType           string
 	SslKeyCertificate  string
 	SslProfile         string
 	WafPolicy          string
@@ -1111,9 +1154,10 @@ func (hr FakeHostRule) HostRule() *akov1alpha1.HostRule {
 		},
 		Spec: akov1alpha1.HostRuleSpec{
 			VirtualHost: akov1alpha1.HostRuleVirtualHost{
-				Fqdn: hr.Fqdn,
+				Fqdn:     hr.Fqdn,
+				FqdnType: hr.FqdnType,
 				TLS: akov1alpha1.HostRuleTLS{
-					SSLKeyCertificate: akov1alpha1.HostRuleSecret{
+					SSLKeyCertificate: akov1alpha1.HostRuleSSLKeyCertificate{
 						Name: hr.SslKeyCertificate,
 						Type: "ref",
 					},
@@ -1175,12 +1219,13 @@ type FakeHTTPRule struct {
 }
 
 type FakeHTTPRulePath struct {
-	Path           string
-	SslProfile     string
-	DestinationCA  string
-	HealthMonitors []string
-	LbAlgorithm    string
-	Hash           string
+	Path              string
+	SslProfile        string
+	DestinationCA     string
+	ClientCertificate string
+	HealthMonitors    []string
+	LbAlgorithm       string
+	Hash              string
 }
 
 func (rr FakeHTTPRule) HTTPRule() *akov1alpha1.HTTPRule {
@@ -1190,9 +1235,10 @@ func (rr FakeHTTPRule) HTTPRule() *akov1alpha1.HTTPRule {
 			Target:         p.Path,
 			HealthMonitors: p.HealthMonitors,
 			TLS: akov1alpha1.HTTPRuleTLS{
-				Type:          "reencrypt",
-				SSLProfile:    p.SslProfile,
-				DestinationCA: p.DestinationCA,
+				Type:              "reencrypt",
+				SSLProfile:        p.SslProfile,
+				DestinationCA:     p.DestinationCA,
+				ClientCertificate: p.ClientCertificate,
 			},
 			LoadBalancerPolicy: akov1alpha1.HTTPRuleLBPolicy{
 				Algorithm: p.LbAlgorithm,
@@ -1239,6 +1285,38 @@ func TeardownHTTPRule(t *testing.T, rrname string) {
 	}
 }
 
+type FakeAuthRule struct {
+	Name      string
+	Namespace string
+	Fqdn      string
+	Paths     []string
+	JWKS      string
+}
+
+func (ar FakeAuthRule) AuthRule() *akov1alpha1.AuthRule {
+	return &akov1alpha1.AuthRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ar.Namespace,
+			Name:      ar.Name,
+		},
+		Spec: akov1alpha1.AuthRuleSpec{
+			Fqdn:  ar.Fqdn,
+			Paths: ar.Paths,
+			JWT: akov1alpha1.AuthRuleJWT{
+				Issuer:   "https://issuer.foo.com",
+				Audience: "foo.com",
+				JWKS:     ar.JWKS,
+			},
+		},
+	}
+}
+
+func TeardownAuthRule(t *testing.T, arname string) {
+	if err := lib.GetCRDClientset().AkoV1alpha1().AuthRules("default").Delete(context.TODO(), arname, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting AuthRule: %v", err)
+	}
+}
+
 func VerifyMetadataHostRule(g *gomega.WithT, vsKey cache.NamespaceName, hrnsname string, active bool) {
 	mcache := cache.SharedAviObjCache()
 	status := "INACTIVE"
@@ -22,7 +22,9 @@ import (
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 
+	"github.com/avinetworks/sdk/go/models"
 	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -201,3 +203,58 @@ func TestMultiNodeAdd(t *testing.T) {
 	}
 	g.Expect(len(nodeIPMap)).To(gomega.Equal(0))
 }
+
+func TestNodeAddDualStack(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/global"
+	nodeName := "testNodeDualStack"
+	nodeip, nodeip6 := "10.1.1.5", "fd00:10:1::5"
+	objects.SharedAviGraphLister().Delete(modelName)
+	nodeExample := (FakeNode{
+		Name:    nodeName,
+		PodCIDR: "10.244.5.0/24",
+		Version: "1",
+		NodeIP:  nodeip,
+	}).Node()
+	nodeExample.Spec.PodCIDRs = []string{"10.244.5.0/24", "fd00:10:244:5::/64"}
+	nodeExample.Status.Addresses = append(nodeExample.Status.Addresses, corev1.NodeAddress{
+		Type:    "InternalIP",
+		Address: nodeip6,
+	})
+
+	_, err := KubeClient.CoreV1().Nodes().Create(context.TODO(), nodeExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding Node: %v", err)
+	}
+
+	PollForCompletion(t, modelName, 5)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 10*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVRF()
+	g.Expect(len(nodes)).To(gomega.Equal(1))
+
+	var v4Route, v6Route *models.StaticRoute
+	for _, staticRoute := range nodes[0].StaticRoutes {
+		if *(staticRoute.NextHop.Addr) == nodeip {
+			v4Route = staticRoute
+		} else if *(staticRoute.NextHop.Addr) == nodeip6 {
+			v6Route = staticRoute
+		}
+	}
+	g.Expect(v4Route).NotTo(gomega.BeNil())
+	g.Expect(*(v4Route.Prefix.IPAddr.Addr)).To(gomega.Equal("10.244.5.0"))
+	g.Expect(*(v4Route.Prefix.IPAddr.Type)).To(gomega.Equal("V4"))
+	g.Expect(v6Route).NotTo(gomega.BeNil())
+	g.Expect(*(v6Route.Prefix.IPAddr.Addr)).To(gomega.Equal("fd00:10:244:5::"))
+	g.Expect(*(v6Route.Prefix.IPAddr.Type)).To(gomega.Equal("V6"))
+	g.Expect(*(v6Route.NextHop.Type)).To(gomega.Equal("V6"))
+	g.Expect(*(v6Route.Prefix.Mask)).To(gomega.Equal(int32(64)))
+
+	err = KubeClient.CoreV1().Nodes().Delete(context.TODO(), nodeName, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("error in deleting Node: %v", err)
+	}
+}
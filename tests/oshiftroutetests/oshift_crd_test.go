@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/onsi/gomega"
 	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -667,3 +669,59 @@ func TestOshiftHTTPRuleReencryptWithDestinationCA(t *testing.T) {
 	VerifySecureRouteDeletion(t, g, defaultModelName, 0, 0)
 	TearDownTestForRoute(t, defaultModelName)
 }
+
+func TestOshiftHTTPRuleWeightedBackends(t *testing.T) {
+	// route secure foo.com/foo
+	// create httprule /foo with a weighted backend, the backend is added to the route path and mapped to the route
+	// delete httprule, the backend is removed and unmapped
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := "admin/cluster--Shared-L7-0"
+	rrname := "samplerr-foo"
+
+	SetUpTestForRoute(t, modelName)
+	integrationtest.CreateSVC(t, "default", "absvc2", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEP(t, "default", "absvc2", false, false, "3.3.3")
+	routeExample := FakeRoute{Path: "/foo"}.SecureRoute()
+	if _, err := OshiftClient.RouteV1().Routes(defaultNamespace).Create(context.TODO(), routeExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding route: %v", err)
+	}
+	ValidateSniModel(t, g, modelName)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:           rrname,
+		Namespace:      "default",
+		Fqdn:           "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{Path: "/foo"}},
+	}.HTTPRule()
+	httprule.Spec.Paths[0].Backends = []akov1alpha1.HTTPRuleBackend{{ServiceName: "absvc2"}}
+	if _, err := CRDClient.AkoV1alpha1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	poolFoo := "cluster--default-foo.com_foo-foo-avisvc"
+	poolAB := "cluster--default-foo.com_foo-foo-absvc2"
+	getSniPoolNames := func() []string {
+		var poolNames []string
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 && len(nodes[0].SniNodes) > 0 {
+			for _, pool := range nodes[0].SniNodes[0].PoolRefs {
+				poolNames = append(poolNames, pool.Name)
+			}
+		}
+		return poolNames
+	}
+	g.Eventually(getSniPoolNames, 10*time.Second).Should(gomega.ConsistOf(poolFoo, poolAB))
+	_, routes := objects.OshiftRouteSvcLister().IngressMappings(defaultNamespace).GetSvcToIng("absvc2")
+	g.Expect(routes).To(gomega.ContainElement("foo"))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	g.Eventually(getSniPoolNames, 10*time.Second).Should(gomega.ConsistOf(poolFoo))
+	_, routes = objects.OshiftRouteSvcLister().IngressMappings(defaultNamespace).GetSvcToIng("absvc2")
+	g.Expect(routes).NotTo(gomega.ContainElement("foo"))
+
+	VerifySecureRouteDeletion(t, g, modelName, 0, 0)
+	TearDownTestForRoute(t, defaultModelName)
+	integrationtest.DelSVC(t, "default", "absvc2")
+	integrationtest.DelEP(t, "default", "absvc2")
+}
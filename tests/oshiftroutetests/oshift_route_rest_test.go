@@ -2,9 +2,12 @@ package oshiftroutetests
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
@@ -260,3 +263,95 @@ func TestPassthroughRouteWrongAlternateBackend(t *testing.T) {
 	TearDownRouteForRestCheck(t, DefaultPassthroughModel)
 	objects.SharedAviGraphLister().Delete(DefaultPassthroughModel)
 }
+
+func TestWildcardRouteStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// *.bar.com is sharded to Shared-L7-5
+	modelName := "admin/cluster--Shared-L7-5"
+	SetUpTestForRoute(t, modelName)
+	routeExample := FakeRoute{Hostname: "test.bar.com", Path: "/foo"}.Route()
+	routeExample.Spec.WildcardPolicy = routev1.WildcardPolicySubdomain
+	_, err := OshiftClient.RouteV1().Routes(defaultNamespace).Create(context.TODO(), routeExample, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in adding route: %v", err)
+	}
+
+	g.Eventually(func() string {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+			if len(nodes) > 0 && len(nodes[0].PoolRefs) == 1 {
+				return nodes[0].PoolRefs[0].PriorityLabel
+			}
+		}
+		return ""
+	}, 30*time.Second).Should(gomega.Equal("*.bar.com/foo"))
+
+	var route *routev1.Route
+	g.Eventually(func() string {
+		route, _ = OshiftClient.RouteV1().Routes("default").Get(context.TODO(), defaultRouteName, metav1.GetOptions{})
+		if (len(route.Status.Ingress)) != 1 {
+			return ""
+		}
+		return route.Status.Ingress[0].Host
+	}, 30*time.Second).Should(gomega.Equal("test.bar.com"))
+	g.Expect(route.Status.Ingress[0].WildcardPolicy).Should(gomega.Equal(routev1.WildcardPolicySubdomain))
+
+	TearDownRouteForRestCheck(t, modelName)
+}
+
+func TestRouteHostnameConflictStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	SetUpTestForRoute(t, defaultModelName)
+	AddLabelToNamespace(defaultKey, defaultValue, "red", defaultModelName, t)
+	os.Setenv("HOSTNAME_CONFLICT_POLICY", "first-wins")
+	defer os.Unsetenv("HOSTNAME_CONFLICT_POLICY")
+
+	routeExample := FakeRoute{Path: "/foo"}.Route()
+	routeExample.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+	if _, err := OshiftClient.RouteV1().Routes(defaultNamespace).Create(context.TODO(), routeExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding route: %v", err)
+	}
+	g.Eventually(func() int {
+		route, _ := OshiftClient.RouteV1().Routes(defaultNamespace).Get(context.TODO(), defaultRouteName, metav1.GetOptions{})
+		return len(route.Status.Ingress)
+	}, 30*time.Second).Should(gomega.Equal(1))
+
+	routeExample = FakeRoute{Namespace: "red", Path: "/bar"}.Route()
+	routeExample.CreationTimestamp = metav1.Now()
+	if _, err := OshiftClient.RouteV1().Routes("red").Create(context.TODO(), routeExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding route: %v", err)
+	}
+
+	// the newer route in red is not admitted, and names the owner of the host
+	var condition routev1.RouteIngressCondition
+	g.Eventually(func() string {
+		route, _ := OshiftClient.RouteV1().Routes("red").Get(context.TODO(), defaultRouteName, metav1.GetOptions{})
+		if len(route.Status.Ingress) != 1 || len(route.Status.Ingress[0].Conditions) != 1 {
+			return ""
+		}
+		condition = route.Status.Ingress[0].Conditions[0]
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(lib.HostnameConflictReason))
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("route default/" + defaultRouteName))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(defaultModelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Name).To(gomega.Equal("cluster--foo.com_foo-default-foo-avisvc"))
+
+	// once the owner is deleted, the route in red is admitted
+	if err := OshiftClient.RouteV1().Routes(defaultNamespace).Delete(context.TODO(), defaultRouteName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the route %v", err)
+	}
+	g.Eventually(func() corev1.ConditionStatus {
+		route, _ := OshiftClient.RouteV1().Routes("red").Get(context.TODO(), defaultRouteName, metav1.GetOptions{})
+		if len(route.Status.Ingress) != 1 || len(route.Status.Ingress[0].Conditions) != 1 {
+			return ""
+		}
+		return route.Status.Ingress[0].Conditions[0].Status
+	}, 30*time.Second).Should(gomega.Equal(corev1.ConditionTrue))
+
+	VerifyRouteDeletion(t, g, aviModel, 0, "red/"+defaultRouteName)
+	TearDownTestForRoute(t, defaultModelName)
+}
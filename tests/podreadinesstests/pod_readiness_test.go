@@ -0,0 +1,342 @@
+/*
+ * Copyright 2020-2021 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package podreadinesstests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/client/v1alpha1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+
+	utils "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+var KubeClient *k8sfake.Clientset
+var CRDClient *crdfake.Clientset
+var ctrl *k8s.AviController
+
+const (
+	defaultPodName = "test-pod"
+	defaultNS      = "default"
+	defaultPodIP   = "1.1.1.1"
+	defaultPort    = 8080
+	defaultLBModel = "admin/cluster--default-testsvc"
+	defaultL7Model = "admin/cluster--Shared-L7-0"
+)
+
+var podSelectors = map[string]string{"app": "readiness"}
+
+func TestMain(m *testing.M) {
+	os.Setenv("INGRESS_API", "extensionv1")
+	os.Setenv("NETWORK_NAME", "net123")
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "CLOUD_VCENTER")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("NODE_NETWORK_LIST", `[{"networkName":"net123","cidrs":["10.79.168.0/22"]}]`)
+	os.Setenv(lib.ENABLE_POD_READINESS_GATE, "true")
+	// the runtime check is enabled during bootup, so that the client for the pool runtime checks is created.
+	os.Setenv(lib.READINESS_GATE_RUNTIME, "true")
+
+	KubeClient = k8sfake.NewSimpleClientset()
+	CRDClient = crdfake.NewSimpleClientset()
+	lib.SetCRDClientset(CRDClient)
+
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.EndpointInformer,
+		utils.IngressInformer,
+		utils.IngressClassInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+		utils.NodeInformer,
+		utils.ConfigMapInformer,
+		utils.PodInformer,
+	}
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: KubeClient}, registeredInformers)
+	informers := k8s.K8sinformers{Cs: KubeClient}
+	k8s.NewCRDInformers(CRDClient)
+
+	mcache := cache.SharedAviObjCache()
+	cloudObj := &cache.AviCloudPropertyCache{Name: "Default-Cloud", VType: "mock"}
+	subdomains := []string{"avi.internal", ".com"}
+	cloudObj.NSIpamDNS = subdomains
+	mcache.CloudKeyCache.AviCacheAdd("Default-Cloud", cloudObj)
+
+	integrationtest.InitializeFakeAKOAPIServer()
+	integrationtest.NewAviFakeClientInstance()
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = k8s.SharedAviController()
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+
+	aviCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "avi-system",
+			Name:      "avi-k8s-config",
+		},
+	}
+	KubeClient.CoreV1().ConfigMaps("avi-system").Create(context.TODO(), aviCM, metav1.CreateOptions{})
+	integrationtest.PollForSyncStart(ctrl, 10)
+
+	ctrl.HandleConfigMap(informers, ctrlCh, stopCh, quickSyncCh)
+	integrationtest.KubeClient = KubeClient
+	integrationtest.AddDefaultIngressClass()
+
+	go ctrl.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+// createPodWithReadinessGate creates a Pod declaring the pool readiness gate. A Pod whose containers
+// are ready only waits on the pool readiness gate.
+func createPodWithReadinessGate(t *testing.T, containersReady bool) {
+	containersReadyStatus := corev1.ConditionFalse
+	if containersReady {
+		containersReadyStatus = corev1.ConditionTrue
+	}
+	testPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultPodName,
+			Namespace: defaultNS,
+			Labels:    podSelectors,
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: lib.PodReadinessGateCondition}},
+		},
+		Status: corev1.PodStatus{
+			PodIP: defaultPodIP,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.ContainersReady, Status: containersReadyStatus},
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	if _, err := KubeClient.CoreV1().Pods(defaultNS).Create(context.TODO(), testPod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Pod: %v", err)
+	}
+}
+
+// createEPForPod creates the Endpoints of a Service with the Pod address, ready or not.
+func createEPForPod(t *testing.T, svcName string, ready bool) {
+	if _, err := KubeClient.CoreV1().Endpoints(defaultNS).Create(context.TODO(), getEPForPod(svcName, ready), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating Endpoint: %v", err)
+	}
+}
+
+func updateEPForPod(t *testing.T, svcName string, ready bool) {
+	epExample := getEPForPod(svcName, ready)
+	epExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Endpoints(defaultNS).Update(context.TODO(), epExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Endpoint: %v", err)
+	}
+}
+
+func getEPForPod(svcName string, ready bool) *corev1.Endpoints {
+	address := corev1.EndpointAddress{
+		IP:        defaultPodIP,
+		TargetRef: &corev1.ObjectReference{Kind: utils.Pod, Namespace: defaultNS, Name: defaultPodName},
+	}
+	subset := corev1.EndpointSubset{
+		Ports: []corev1.EndpointPort{{Name: "foo0", Port: defaultPort, Protocol: "TCP"}},
+	}
+	if ready {
+		subset.Addresses = []corev1.EndpointAddress{address}
+	} else {
+		subset.NotReadyAddresses = []corev1.EndpointAddress{address}
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNS, Name: svcName},
+		Subsets:    []corev1.EndpointSubset{subset},
+	}
+}
+
+func isReadinessGateSet() bool {
+	pod, err := KubeClient.CoreV1().Pods(defaultNS).Get(context.TODO(), defaultPodName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return lib.IsPodReadinessGateSet(pod)
+}
+
+func setUpTestForSvcLB(t *testing.T) {
+	objects.SharedAviGraphLister().Delete(defaultLBModel)
+	createPodWithReadinessGate(t, true)
+	integrationtest.CreateServiceWithSelectors(t, defaultNS, integrationtest.SINGLEPORTSVC, corev1.ServiceTypeLoadBalancer, false, podSelectors)
+	createEPForPod(t, integrationtest.SINGLEPORTSVC, false)
+	integrationtest.PollForCompletion(t, defaultLBModel, 5)
+}
+
+func tearDownTestForSvcLB(t *testing.T, g *gomega.GomegaWithT) {
+	objects.SharedAviGraphLister().Delete(defaultLBModel)
+	integrationtest.DelSVC(t, defaultNS, integrationtest.SINGLEPORTSVC)
+	integrationtest.DelEP(t, defaultNS, integrationtest.SINGLEPORTSVC)
+	mcache := cache.SharedAviObjCache()
+	vsKey := cache.NamespaceName{Namespace: integrationtest.AVINAMESPACE, Name: fmt.Sprintf("cluster--%s-%s", defaultNS, integrationtest.SINGLEPORTSVC)}
+	g.Eventually(func() bool {
+		_, found := mcache.VsCacheMeta.AviCacheGet(vsKey)
+		return found
+	}, 40*time.Second).Should(gomega.Equal(false))
+	KubeClient.CoreV1().Pods(defaultNS).Delete(context.TODO(), defaultPodName, metav1.DeleteOptions{})
+}
+
+func TestPodReadinessGateForSvcLB(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv(lib.READINESS_GATE_RUNTIME, "false")
+	defer os.Setenv(lib.READINESS_GATE_RUNTIME, "true")
+
+	setUpTestForSvcLB(t)
+	g.Eventually(isReadinessGateSet, 30*time.Second).Should(gomega.Equal(true))
+
+	tearDownTestForSvcLB(t, g)
+}
+
+// TestPodReadinessGateUnchangedServers recreates the Pod behind an unchanged pool server,
+// for which the model is not pushed again, and verifies the readiness gate is set.
+func TestPodReadinessGateUnchangedServers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv(lib.READINESS_GATE_RUNTIME, "false")
+	defer os.Setenv(lib.READINESS_GATE_RUNTIME, "true")
+
+	setUpTestForSvcLB(t)
+	g.Eventually(isReadinessGateSet, 30*time.Second).Should(gomega.Equal(true))
+
+	// the Pod address turns ready once the gate is set, and stays so while the Pod is replaced.
+	updateEPForPod(t, integrationtest.SINGLEPORTSVC, true)
+	poolKey := cache.NamespaceName{Namespace: integrationtest.AVINAMESPACE, Name: fmt.Sprintf("cluster--%s-%s--%d", defaultNS, integrationtest.SINGLEPORTSVC, defaultPort)}
+	getPoolCksum := func() string {
+		poolCache, found := cache.SharedAviObjCache().PoolCache.AviCacheGet(poolKey)
+		if !found {
+			return ""
+		}
+		return poolCache.(*cache.AviPoolCache).CloudConfigCksum
+	}
+	g.Eventually(getPoolCksum, 10*time.Second).ShouldNot(gomega.BeEmpty())
+	time.Sleep(2 * time.Second)
+	poolCksum := getPoolCksum()
+
+	KubeClient.CoreV1().Pods(defaultNS).Delete(context.TODO(), defaultPodName, metav1.DeleteOptions{})
+	g.Eventually(func() bool {
+		_, err := utils.GetInformers().PodInformer.Lister().Pods(defaultNS).Get(defaultPodName)
+		return err == nil
+	}, 10*time.Second).Should(gomega.Equal(false))
+	createPodWithReadinessGate(t, true)
+	g.Eventually(isReadinessGateSet, 30*time.Second).Should(gomega.Equal(true))
+	g.Expect(getPoolCksum()).To(gomega.Equal(poolCksum))
+
+	tearDownTestForSvcLB(t, g)
+}
+
+// TestPodReadinessGateRuntimeCheck verifies that the readiness gate is set only once
+// the controller reports the pool server of the Pod up.
+func TestPodReadinessGateRuntimeCheck(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var serverUp, runtimeChecks int32
+	integrationtest.AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		url := r.URL.EscapedPath()
+		if r.Method == "GET" && strings.Contains(url, "/runtime/server") {
+			atomic.AddInt32(&runtimeChecks, 1)
+			state := "OPER_DOWN"
+			if atomic.LoadInt32(&serverUp) == 1 {
+				state = "OPER_UP"
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fmt.Sprintf(`[{"ip_addr": {"addr": "%s", "type": "V4"}, "port": %d, "oper_status": {"state": "%s"}}]`,
+				defaultPodIP, defaultPort, state)))
+			return
+		}
+		integrationtest.NormalControllerServer(w, r)
+	})
+	defer integrationtest.ResetMiddleware()
+
+	setUpTestForSvcLB(t)
+	g.Eventually(func() int32 {
+		return atomic.LoadInt32(&runtimeChecks)
+	}, 30*time.Second).Should(gomega.BeNumerically(">", 0))
+	g.Expect(isReadinessGateSet()).To(gomega.Equal(false))
+
+	atomic.StoreInt32(&serverUp, 1)
+	g.Eventually(isReadinessGateSet, 30*time.Second).Should(gomega.Equal(true))
+
+	tearDownTestForSvcLB(t, g)
+}
+
+func TestPodReadinessGateForIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	os.Setenv(lib.READINESS_GATE_RUNTIME, "false")
+	defer os.Setenv(lib.READINESS_GATE_RUNTIME, "true")
+
+	objects.SharedAviGraphLister().Delete(defaultL7Model)
+	createPodWithReadinessGate(t, false)
+	integrationtest.CreateServiceWithSelectors(t, defaultNS, "avisvc", corev1.ServiceTypeClusterIP, false, podSelectors)
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        "foo-with-targets",
+		Namespace:   defaultNS,
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		ServiceName: "avisvc",
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1beta1().Ingresses(defaultNS).Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	createEPForPod(t, "avisvc", false)
+	integrationtest.PollForCompletion(t, defaultL7Model, 5)
+	g.Consistently(isReadinessGateSet, 5*time.Second).Should(gomega.Equal(false))
+
+	// the pool is rebuilt with the Pod server once the Pod containers are ready.
+	pod, _ := KubeClient.CoreV1().Pods(defaultNS).Get(context.TODO(), defaultPodName, metav1.GetOptions{})
+	pod.Status.Conditions[0].Status = corev1.ConditionTrue
+	pod.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Pods(defaultNS).UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Pod: %v", err)
+	}
+	g.Eventually(isReadinessGateSet, 30*time.Second).Should(gomega.Equal(true))
+
+	if err := KubeClient.NetworkingV1beta1().Ingresses(defaultNS).Delete(context.TODO(), "foo-with-targets", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	integrationtest.DelSVC(t, defaultNS, "avisvc")
+	integrationtest.DelEP(t, defaultNS, "avisvc")
+	KubeClient.CoreV1().Pods(defaultNS).Delete(context.TODO(), defaultPodName, metav1.DeleteOptions{})
+	objects.SharedAviGraphLister().Delete(defaultL7Model)
+}
@@ -23,6 +23,7 @@ import (
 
 	svcapifake "sigs.k8s.io/service-apis/pkg/client/clientset/versioned/fake"
 
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/client/v1alpha1/clientset/versioned/fake"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
@@ -1256,3 +1257,70 @@ func TestServicesAPIInfraSettingChangeMapping(t *testing.T) {
 	integrationtest.TeardownAviInfraSetting(t, settingName2)
 	VerifyGatewayVSNodeDeletion(g, modelName)
 }
+
+func TestServicesAPICrossNamespaceServiceWithBackendGrant(t *testing.T) {
+	// a service in another namespace is attached to the gateway only once
+	// a BackendGrant in the namespace of the service allows it
+	g := gomega.NewGomegaWithT(t)
+
+	gwClassName, gatewayName, ns := "avi-lb", "my-gateway", "default"
+	modelName := "admin/cluster--default-my-gateway"
+
+	SetupGatewayClass(t, gwClassName, lib.AviGatewayController, "")
+	SetupGateway(t, gatewayName, ns, gwClassName)
+	SetupSvcApiLBService(t, "svc", "red", gatewayName, ns)
+
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 40*time.Second).Should(gomega.Equal(true))
+	g.Consistently(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+	}, 5*time.Second).Should(gomega.Equal(0))
+
+	grant := &akov1alpha1.BackendGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "red",
+			Name:      "gateway-grant",
+		},
+		Spec: akov1alpha1.BackendGrantSpec{
+			From: []akov1alpha1.BackendGrantFrom{{Kind: lib.Gateway, Namespace: ns}},
+			To:   []akov1alpha1.BackendGrantTo{{Name: "svc"}},
+		},
+	}
+	if _, err := CRDClient.AkoV1alpha1().BackendGrants("red").Create(context.TODO(), grant, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding BackendGrant: %v", err)
+	}
+	g.Eventually(func() []string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].ServiceMetadata.NamespaceServiceName
+	}, 40*time.Second).Should(gomega.Equal([]string{"red/svc"}))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Servers).To(gomega.HaveLen(3))
+
+	// the service is detached once the grant is removed
+	if err := CRDClient.AkoV1alpha1().BackendGrants("red").Delete(context.TODO(), "gateway-grant", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting BackendGrant: %v", err)
+	}
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs)
+	}, 40*time.Second).Should(gomega.Equal(0))
+
+	TeardownAdvLBService(t, "svc", "red")
+	TeardownGateway(t, gatewayName, ns)
+	TeardownGatewayClass(t, gwClassName)
+	VerifyGatewayVSNodeDeletion(g, modelName)
+}